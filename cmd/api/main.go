@@ -9,6 +9,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Embed the timezone database so interview timezones resolve on hosts without one
+	_ "time/tzdata"
 )
 
 // @title Career Link API
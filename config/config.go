@@ -16,6 +16,8 @@ type AppConfig struct {
 	Mailgun     MailgunConfig
 	SendGrid    SendGridConfig
 	FrontendURL string
+	// IANA zone used for daily schedules and as the default interview timezone
+	Timezone    string
 	JWT         JWT
 	Minio       MinioConfig
 	SwaggerAuth SwaggerAuthConfig
@@ -34,7 +36,9 @@ type DatabaseConfig struct {
 }
 
 type JWT struct {
-	Secret string
+	Secret               string
+	AccessTokenDuration  time.Duration
+	RefreshTokenDuration time.Duration
 }
 
 type LoggerConfig struct {
@@ -132,8 +136,11 @@ func Load() *AppConfig {
 			FromEmail: getEnv("SENDGRID_FROM_EMAIL", "prasetyo.agpr@gmail.com"),
 		},
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3001"),
+		Timezone:    getEnv("APP_TIMEZONE", "Asia/Jakarta"),
 		JWT: JWT{
-			Secret: getEnv("JWT", "secret"),
+			Secret:               getEnv("JWT", "secret"),
+			AccessTokenDuration:  getDuration("JWT_ACCESS_TOKEN_DURATION", 15*time.Minute),
+			RefreshTokenDuration: getDuration("JWT_REFRESH_TOKEN_DURATION", 30*24*time.Hour),
 		},
 		Minio: MinioConfig{
 			Endpoint:        getEnv("MINIO_ENDPOINT", "localhost:9000"),
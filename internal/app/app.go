@@ -6,11 +6,21 @@ import (
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
 	"github.com/agpprastyo/career-link/internal/common/health"
 	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/tasks"
 	"github.com/gofiber/fiber/v2"
 	fiberLogger "github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/swagger"
 
 	"github.com/agpprastyo/career-link/config"
+	jobDelivery "github.com/agpprastyo/career-link/internal/job/delivery"
+	jobRepository "github.com/agpprastyo/career-link/internal/job/repository"
+	jobUsecase "github.com/agpprastyo/career-link/internal/job/usecase"
+	notificationDelivery "github.com/agpprastyo/career-link/internal/notification/delivery"
+	notificationRepository "github.com/agpprastyo/career-link/internal/notification/repository"
+	notificationUsecase "github.com/agpprastyo/career-link/internal/notification/usecase"
+	outboxDelivery "github.com/agpprastyo/career-link/internal/outbox/delivery"
+	outboxRepository "github.com/agpprastyo/career-link/internal/outbox/repository"
+	outboxUsecase "github.com/agpprastyo/career-link/internal/outbox/usecase"
 	"github.com/agpprastyo/career-link/internal/user/delivery"
 	"github.com/agpprastyo/career-link/internal/user/repository"
 	"github.com/agpprastyo/career-link/internal/user/usecase"
@@ -22,6 +32,7 @@ import (
 
 	"github.com/agpprastyo/career-link/pkg/token"
 	"github.com/sirupsen/logrus"
+	"time"
 )
 
 // InitializeAPI manually initializes all dependencies
@@ -32,6 +43,11 @@ func InitializeAPI() (*Server, error) {
 	// Initialize logger
 	log := logger.New(cfg)
 
+	// Interviews and daily schedules rely on the configured timezone
+	if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+	}
+
 	// Initialize Redis client
 	redisClient, err := redis.NewClient(*cfg)
 	if err != nil {
@@ -65,41 +81,89 @@ func InitializeAPI() (*Server, error) {
 	}
 
 	// Initialize repositories
-	userRepo := initUserRepository(db, log, mailClient, minioClient, cfg.Server.VerifyBaseURL, redisClient)
+	userRepo := initUserRepository(db, log, mailClient, minioClient, cfg.Server.VerifyBaseURL, cfg.FrontendURL, redisClient)
+	jobRepo := jobRepository.NewJobRepository(db, log, redisClient, mailClient, minioClient, cfg.Server.VerifyBaseURL, cfg.FrontendURL)
+	notificationRepo := notificationRepository.NewNotificationRepository(db, log, redisClient, mailClient, cfg.FrontendURL)
+	outboxRepo := outboxRepository.NewOutboxRepository(db, log, mailClient)
+
+	// Initialize the hub delivering notifications published by any instance to the streams open on this one
+	notificationHub := notificationRepository.NewHub(redisClient, log)
 
 	// Initialize use cases
-	userUseCase := usecase.NewUserUseCase(userRepo, log, tokenMaker)
+	userUseCase := usecase.NewUserUseCase(userRepo, log, tokenMaker, cfg.JWT)
+	notificationUseCase := notificationUsecase.NewNotificationUseCase(notificationRepo, notificationHub, log)
+	jobUseCase := jobUsecase.NewJobUseCase(jobRepo, notificationUseCase, log)
+	outboxUseCase := outboxUsecase.NewOutboxUseCase(outboxRepo, log)
 
 	// Initialize handlers
 	userHandler := delivery.NewUserHandler(userUseCase, log, cfg, tokenMaker, redisClient, userRepo)
+	jobHandler := jobDelivery.NewJobHandler(jobUseCase, log, cfg, tokenMaker, userRepo)
+	notificationHandler := notificationDelivery.NewNotificationHandler(notificationUseCase, log, tokenMaker, userRepo)
+	outboxHandler := outboxDelivery.NewOutboxHandler(outboxUseCase, log, tokenMaker, userRepo)
 	healthHandler := health.NewHandler(db, redisClient)
 
+	// Initialize background jobs
+	scheduler := tasks.NewScheduler(log)
+	scheduler.Add(tasks.Job{
+		Name:     "email-outbox",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Minute, // Well inside the claim lease, so a run never races a retry of its own emails
+		Run:      outboxUseCase.DeliverEmails,
+	})
+	scheduler.Add(tasks.Job{
+		Name:     "saved-search-alerts",
+		Interval: 5 * time.Minute,
+		Run:      jobUseCase.SendSavedSearchAlerts,
+	})
+	scheduler.Add(tasks.Job{
+		Name:     "saved-job-deadline-reminders",
+		Interval: time.Hour,
+		Run:      jobUseCase.SendDeadlineReminders,
+	})
+	scheduler.Add(tasks.Job{
+		Name:     "knockout-rejection-emails",
+		Interval: 5 * time.Minute,
+		Run:      jobUseCase.SendRejectionEmails,
+	})
+	scheduler.Add(tasks.Job{
+		Name:     "notification-digests",
+		Interval: time.Hour,
+		Run:      notificationUseCase.SendNotificationDigests,
+	})
+
 	// Create the server with all dependencies
-	server := NewServer(cfg, log, userHandler, healthHandler)
+	server := NewServer(cfg, log, userHandler, jobHandler, notificationHandler, outboxHandler, healthHandler)
+	server.Scheduler = scheduler
+	server.NotificationHub = notificationHub
 
 	return server, nil
 }
 
-func initUserRepository(db *database.PostgresDB, log *logger.Logger, mail *mail.Client, minio *minio.Client, verifyBaseURL string, redis *redis.Client) *repository.UserRepository {
+func initUserRepository(db *database.PostgresDB, log *logger.Logger, mail *mail.Client, minio *minio.Client, verifyBaseURL, frontendURL string, redis *redis.Client) *repository.UserRepository {
 	if redis == nil {
 		log.WithFields(logrus.Fields{
 			"component": "UserRepository",
 		}).Fatal("Redis client is nil")
 	}
-	return repository.NewUserRepository(db, log, mail, minio, verifyBaseURL, redis)
+	return repository.NewUserRepository(db, log, mail, minio, verifyBaseURL, frontendURL, redis)
 }
 
 // Server represents the fully configured API server
 type Server struct {
-	App    *fiber.App
-	Config *config.AppConfig
-	Logger *logger.Logger
+	App             *fiber.App
+	Config          *config.AppConfig
+	Logger          *logger.Logger
+	Scheduler       *tasks.Scheduler
+	NotificationHub *notificationRepository.Hub
 }
 
 // NewServer creates and configures a new server instance with all routes
-func NewServer(cfg *config.AppConfig, log *logger.Logger, userHandler *delivery.UserHandler, healthHandler *health.Handler) *Server {
+func NewServer(cfg *config.AppConfig, log *logger.Logger, userHandler *delivery.UserHandler, jobHandler *jobDelivery.JobHandler, notificationHandler *notificationDelivery.NotificationHandler, outboxHandler *outboxDelivery.OutboxHandler, healthHandler *health.Handler) *Server {
 	// Initialize router with global middleware
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// Company verification requests carry up to five 5MB documents
+		BodyLimit: 26 * 1024 * 1024,
+	})
 	app.Use(fiberLogger.New())
 	app.Use(middleware.RecoveryMiddleware(log))
 
@@ -131,11 +195,24 @@ func NewServer(cfg *config.AppConfig, log *logger.Logger, userHandler *delivery.
 	api := app.Group("/api/v1")
 
 	userHandler.RegisterUserRoutes(api.Group("/users"))
-	userHandler.RegisterUserWithMiddlewareRoutes(api.Group("/profile"))
+	profile := api.Group("/profile")
+	userHandler.RegisterUserWithMiddlewareRoutes(profile)
+	userHandler.RegisterJobSeekerProfileRoutes(profile.Group("/job-seeker"))
+	jobHandler.RegisterSavedJobRoutes(profile.Group("/saved-jobs"))
+	notificationHandler.RegisterNotificationRoutes(profile.Group("/notifications"))
+	notificationHandler.RegisterNotificationSettingsRoutes(profile.Group("/notification-settings"))
 	userHandler.RegisterAdminRoutes(api.Group("/admin"))
+	outboxHandler.RegisterAdminRoutes(api.Group("/admin/email-outbox"))
 	userHandler.RegisterSuperAdminRoutes(api.Group("/super-admin"))
 	userHandler.RegisterCompanyRoutes(api.Group("/company"))
 
+	jobHandler.RegisterJobRoutes(api.Group("/jobs"))
+	jobHandler.RegisterApplicationRoutes(api.Group("/applications"))
+	jobHandler.RegisterSavedSearchRoutes(api.Group("/saved-searches"))
+	jobHandler.RegisterInterviewRoutes(api.Group("/interviews"))
+	jobHandler.RegisterMessageRoutes(api.Group("/messages"))
+	notificationHandler.RegisterNotificationStreamRoutes(api.Group("/notifications"))
+
 	// Add after all your route registrations
 	api.Use(func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -159,6 +236,14 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	if s.Scheduler != nil {
+		s.Scheduler.Start()
+	}
+
+	if s.NotificationHub != nil {
+		s.NotificationHub.Start()
+	}
+
 	s.Logger.Info("Server started successfully")
 	return nil
 }
@@ -166,5 +251,12 @@ func (s *Server) Start() error {
 // Shutdown gracefully stops the server
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.Logger.Info("Server is shutting down...")
+	if s.Scheduler != nil {
+		s.Scheduler.Stop()
+	}
+	// Closing the hub ends open notification streams, which the server otherwise waits for
+	if s.NotificationHub != nil {
+		s.NotificationHub.Stop()
+	}
 	return s.App.Shutdown()
 }
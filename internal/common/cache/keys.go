@@ -0,0 +1,16 @@
+// Package cache holds the Redis keys that more than one module reads or invalidates
+package cache
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// JobRecommendationsVersionKey is incremented whenever published postings change. Cached
+// recommendations built against an older version are stale.
+const JobRecommendationsVersionKey = "job_recommendations:version"
+
+// JobRecommendationsKey is the cached recommendation list of a job seeker
+func JobRecommendationsKey(jobSeekerID uuid.UUID) string {
+	return fmt.Sprintf("job_recommendations:%s", jobSeekerID)
+}
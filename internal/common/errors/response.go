@@ -5,6 +5,7 @@ import "github.com/gofiber/fiber/v2"
 // ErrorResponse represents the standard error response structure
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty"` // Machine readable reason, set when clients must react to a specific failure
 }
 
 // RespondWithError sends an error response with the specified status code and message
@@ -13,3 +14,11 @@ func RespondWithError(c *fiber.Ctx, code int, message string) error {
 		Error: message,
 	})
 }
+
+// RespondWithErrorCode sends an error response carrying a machine readable error code
+func RespondWithErrorCode(c *fiber.Ctx, status int, code, message string) error {
+	return c.Status(status).JSON(ErrorResponse{
+		Error: message,
+		Code:  code,
+	})
+}
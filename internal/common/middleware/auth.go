@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 
 	responseError "github.com/agpprastyo/career-link/internal/common/errors"
 	"github.com/agpprastyo/career-link/internal/user/entity"
@@ -23,25 +24,101 @@ func RequireAdminMiddleware() fiber.Handler {
 	}
 }
 
-// RequireSuperAdminMiddleware Super Admin Middleware
-func RequireSuperAdminMiddleware() fiber.Handler {
+// RequirePermission allows the request only when the admin's role grants every listed permission.
+// Must run after RequireAuthMiddleware.
+func RequirePermission(permissions ...entity.Permission) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		//usr := c.Locals("user").(entity.User)
-		admin := c.Locals("admin").(entity.Admin)
-		if admin.Role != entity.AdminRoleSuper {
-			return responseError.RespondWithError(c, fiber.StatusForbidden, "Super Admin role is required")
+		admin, ok := c.Locals("admin").(entity.Admin)
+		if !ok || !admin.IsActive {
+			return responseError.RespondWithError(c, fiber.StatusForbidden, "Active admin account is required")
 		}
+
+		for _, permission := range permissions {
+			if !admin.Role.HasPermission(permission) {
+				return responseError.RespondWithError(c, fiber.StatusForbidden, "Missing permission: "+string(permission))
+			}
+		}
+
 		return c.Next()
 	}
 }
 
-// RequireCompanyMiddleware Company Middleware
-func RequireCompanyMiddleware() fiber.Handler {
+// Error codes returned when an admin route needs a second factor
+const (
+	ErrCodeMFAEnrollmentRequired = "mfa_enrollment_required"
+	ErrCodeMFARequired           = "mfa_required"
+)
+
+// RequireAdminMFAMiddleware enforces the second factor policy for super admins and admins.
+// Accounts without a second factor are told to enroll, and sessions that did not pass one
+// are told to log in again with it. Must run after RequireAuthMiddleware.
+func RequireAdminMFAMiddleware(userRepo *repository.UserRepository, log *logger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		admin, ok := c.Locals("admin").(entity.Admin)
+		if !ok {
+			return responseError.RespondWithError(c, fiber.StatusForbidden, "Admin role is required")
+		}
+
+		if !admin.Role.RequiresMFA() {
+			return c.Next()
+		}
+
+		if verified, _ := c.Locals("mfa_verified").(bool); verified {
+			return c.Next()
+		}
+
+		mfa, err := userRepo.GetUserMFA(c.Context(), admin.UserID)
+		if err != nil && !errors.Is(err, repository.ErrMFANotEnrolled) {
+			log.WithError(err).Error("Failed to get admin MFA settings")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+
+		if !mfa.IsEnabled() {
+			return responseError.RespondWithErrorCode(c, fiber.StatusForbidden, ErrCodeMFAEnrollmentRequired,
+				"Two-factor authentication must be enabled to use admin routes")
+		}
+
+		return responseError.RespondWithErrorCode(c, fiber.StatusForbidden, ErrCodeMFARequired,
+			"This session was not verified with two-factor authentication, please log in again")
+	}
+}
+
+// RequireCompanyMemberMiddleware allows company accounts that belong to a company team and, when
+// roles are given, hold one of them. The membership is stored in the company_id and company_role
+// locals. Must run after RequireAuthMiddleware.
+func RequireCompanyMemberMiddleware(userRepo *repository.UserRepository, log *logger.Logger, roles ...entity.CompanyMemberRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		usr := c.Locals("user").(entity.User)
 		if usr.Role != entity.CompanyRole {
 			return responseError.RespondWithError(c, fiber.StatusForbidden, "Company role is required")
 		}
+
+		member, err := userRepo.GetCompanyMembership(c.Context(), usr.ID)
+		if err != nil {
+			if errors.Is(err, repository.ErrCompanyMemberNotFound) {
+				return responseError.RespondWithError(c, fiber.StatusForbidden, "Company membership is required")
+			}
+			log.WithError(err).Error("Failed to get company membership")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+
+		if len(roles) > 0 && !member.Role.In(roles...) {
+			return responseError.RespondWithError(c, fiber.StatusForbidden, "Your company role does not allow this action")
+		}
+
+		c.Locals("company_id", member.CompanyID.String())
+		c.Locals("company_role", member.Role)
+		return c.Next()
+	}
+}
+
+// RequireJobSeekerMiddleware Job Seeker Middleware
+func RequireJobSeekerMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		usr := c.Locals("user").(entity.User)
+		if usr.Role != entity.JobSeekerRole {
+			return responseError.RespondWithError(c, fiber.StatusForbidden, "Job seeker role is required")
+		}
 		return c.Next()
 	}
 }
@@ -66,13 +143,35 @@ func RequireAuthMiddleware(tokenMaker token.Maker, userRepo *repository.UserRepo
 			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid or expired token")
 		}
 
+		// Reject tokens revoked by logout, password changes or deactivation
+		ctx := c.Context()
+		revoked, err := userRepo.IsAccessTokenRevoked(ctx, payload)
+		if err != nil {
+			log.WithError(err).Error("Failed to check token revocation")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+		if revoked {
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Token has been revoked")
+		}
+
 		// Extract user data from token
 		userID := payload.UserID
 		c.Locals("user_id", userID)
 		c.Locals("email", payload.Email)
+		c.Locals("token_id", payload.ID)
+		c.Locals("token_expired_at", payload.ExpiredAt)
+		c.Locals("session_id", payload.SessionID)
+		c.Locals("mfa_verified", payload.MFAVerified)
+		c.Locals("token_payload", payload)
+
+		// Record device activity without holding up the request
+		go func() {
+			if err := userRepo.TouchSession(context.Background(), payload.SessionID); err != nil {
+				log.WithError(err).Warn("Failed to record session activity")
+			}
+		}()
 
 		// Try to get user from session using repository
-		ctx := c.Context()
 		user, err := userRepo.GetUserSessionByID(ctx, userID)
 
 		if err == nil && user != nil {
@@ -102,8 +201,19 @@ func RequireAuthMiddleware(tokenMaker token.Maker, userRepo *repository.UserRepo
 			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "User not found")
 		}
 
+		if !user.IsActive {
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "User is not active")
+		}
+
 		c.Locals("user", *user)
 
+		if user.Role == entity.AdminRole {
+			admin, err := userRepo.GetAdminByUserID(ctx, userUUID)
+			if err == nil && admin != nil {
+				c.Locals("admin", *admin)
+			}
+		}
+
 		// Store in session asynchronously
 		go func() {
 			bgCtx := context.Background()
@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// permissionApp serves a route guarded by RequirePermission for a request made by admin, or by a
+// non-admin user when admin is nil
+func permissionApp(admin *entity.Admin, permissions ...entity.Permission) *fiber.App {
+	app := fiber.New()
+	app.Get("/",
+		func(c *fiber.Ctx) error {
+			if admin != nil {
+				c.Locals("admin", *admin)
+			}
+			return c.Next()
+		},
+		middleware.RequirePermission(permissions...),
+		func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		},
+	)
+	return app
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		admin       *entity.Admin
+		permissions []entity.Permission
+		wantStatus  int
+	}{
+		{"not an admin", nil, []entity.Permission{entity.PermissionUsersRead}, fiber.StatusForbidden},
+		{"inactive admin", &entity.Admin{Role: entity.AdminRoleSuper}, []entity.Permission{entity.PermissionUsersRead}, fiber.StatusForbidden},
+		{"viewer reads users", &entity.Admin{Role: entity.AdminRoleViewer, IsActive: true}, []entity.Permission{entity.PermissionUsersRead}, fiber.StatusOK},
+		{"viewer writes users", &entity.Admin{Role: entity.AdminRoleViewer, IsActive: true}, []entity.Permission{entity.PermissionUsersWrite}, fiber.StatusForbidden},
+		{"admin verifies companies", &entity.Admin{Role: entity.AdminRoleAdmin, IsActive: true}, []entity.Permission{entity.PermissionCompaniesVerify}, fiber.StatusOK},
+		{"admin manages admins", &entity.Admin{Role: entity.AdminRoleAdmin, IsActive: true}, []entity.Permission{entity.PermissionAdminsManage}, fiber.StatusForbidden},
+		{"super admin manages admins", &entity.Admin{Role: entity.AdminRoleSuper, IsActive: true}, []entity.Permission{entity.PermissionAdminsManage}, fiber.StatusOK},
+		{"every permission is required", &entity.Admin{Role: entity.AdminRoleViewer, IsActive: true}, []entity.Permission{entity.PermissionUsersRead, entity.PermissionUsersWrite}, fiber.StatusForbidden},
+		{"unknown role", &entity.Admin{Role: "auditor", IsActive: true}, []entity.Permission{entity.PermissionUsersRead}, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := permissionApp(tt.admin, tt.permissions...).Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status: got %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Package testutil holds helpers shared by the module tests
+package testutil
+
+import (
+	"io"
+
+	"github.com/agpprastyo/career-link/config"
+	"github.com/agpprastyo/career-link/pkg/logger"
+)
+
+// Logger returns a logger that discards everything, so expected failures do not flood test output
+func Logger() *logger.Logger {
+	return logger.New(&config.AppConfig{Logger: config.LoggerConfig{Output: io.Discard}})
+}
@@ -0,0 +1,166 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Apply handles a job seeker applying to a job posting
+func (h *JobHandler) Apply(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	var req dto.ApplyRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			h.log.WithError(err).Error("Failed to decode apply request")
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+		}
+	}
+
+	req.Validator.CheckField(validator.MaxRunes(req.CoverLetter, 5000), "CoverLetter", "Cover letter is too long (max 5000 characters)")
+	req.Validator.CheckField(len(req.Answers) <= maxScreeningQuestions, "Answers", "Too many screening answers")
+	for _, answer := range req.Answers {
+		req.Validator.CheckField(validator.MaxRunes(answer.Answer, entity.MaxFreeTextAnswerLength), "Answers", "Screening answer is too long (max 2000 characters)")
+	}
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	app, err := h.jobUseCase.Apply(ctx, userID, jobID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Apply to job failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(app)
+}
+
+// ListJobApplications handles a company fetching the applications of one of its postings
+func (h *JobHandler) ListJobApplications(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	var stage *entity.ApplicationStage
+	if s := c.Query("stage"); s != "" {
+		filter := entity.ApplicationStage(s)
+		stage = &filter
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	apps, err := h.jobUseCase.ListJobApplications(ctx, userID, jobID, stage, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "List job applications failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(apps)
+}
+
+// ListMyApplications handles a job seeker fetching their own applications
+func (h *JobHandler) ListMyApplications(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	apps, err := h.jobUseCase.ListMyApplications(ctx, userID, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "List my applications failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(apps)
+}
+
+// GetApplication handles fetching an application with its stage history for either party
+func (h *JobHandler) GetApplication(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	ctx := c.Context()
+	usr := c.Locals("user").(userEntity.User)
+
+	var detail *dto.ApplicationDetailResponse
+	switch usr.Role {
+	case userEntity.CompanyRole:
+		detail, err = h.jobUseCase.GetApplicationForCompany(ctx, usr.ID, applicationID)
+	case userEntity.JobSeekerRole:
+		detail, err = h.jobUseCase.GetApplicationForJobSeeker(ctx, usr.ID, applicationID)
+	default:
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+	if err != nil {
+		return h.respondJobError(c, err, "Get application failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(detail)
+}
+
+// MoveApplicationStage handles a company moving a candidate to another pipeline stage
+func (h *JobHandler) MoveApplicationStage(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	var req dto.MoveStageRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode move stage request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Stage != "", "Stage", "Stage is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Note, 1000), "Note", "Note is too long (max 1000 characters)")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	app, err := h.jobUseCase.MoveApplicationStage(ctx, userID, applicationID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Move application stage failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Application stage updated successfully",
+		"data":    app,
+	})
+}
+
+// WithdrawApplication handles a job seeker withdrawing an application
+func (h *JobHandler) WithdrawApplication(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	app, err := h.jobUseCase.WithdrawApplication(ctx, userID, applicationID)
+	if err != nil {
+		return h.respondJobError(c, err, "Withdraw application failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Application withdrawn successfully",
+		"data":    app,
+	})
+}
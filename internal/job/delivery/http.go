@@ -0,0 +1,115 @@
+package delivery
+
+import (
+	"github.com/agpprastyo/career-link/config"
+	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/job/usecase"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobHandler handles HTTP requests for job posting operations
+type JobHandler struct {
+	config     *config.AppConfig
+	jobUseCase *usecase.JobUseCase
+	userRepo   *repository.UserRepository
+	log        *logger.Logger
+	tokenMaker token.Maker
+}
+
+// NewJobHandler creates a new job HTTP handler
+func NewJobHandler(jobUseCase *usecase.JobUseCase, log *logger.Logger, cfg *config.AppConfig, tokenMaker token.Maker, userRepo *repository.UserRepository) *JobHandler {
+	return &JobHandler{
+		jobUseCase: jobUseCase,
+		userRepo:   userRepo,
+		log:        log,
+		config:     cfg,
+		tokenMaker: tokenMaker,
+	}
+}
+
+// RegisterJobRoutes registers public job listing routes and company posting management routes.
+// Middleware is attached per route because listing and reading postings does not require a login.
+func (h *JobHandler) RegisterJobRoutes(router fiber.Router) {
+	auth := middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log)
+	company := middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log)
+	recruiter := middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, userEntity.CompanyOwner, userEntity.CompanyRecruiter)
+	jobSeeker := middleware.RequireJobSeekerMiddleware()
+
+	router.Get("/", h.ListJobs)
+	router.Get("/search", h.SearchJobs)
+	router.Get("/recommended", auth, jobSeeker, h.GetRecommendedJobs)
+	router.Get("/mine", auth, company, h.ListCompanyJobs)
+	router.Get("/:id", h.GetJob)
+	router.Get("/:id/screening-questions", h.GetScreeningQuestions)
+
+	router.Post("/", auth, recruiter, h.CreateJob)
+	router.Put("/:id", auth, recruiter, h.UpdateJob)
+	router.Patch("/:id/publish", auth, recruiter, h.PublishJob)
+	router.Patch("/:id/unpublish", auth, recruiter, h.UnpublishJob)
+	router.Patch("/:id/close", auth, recruiter, h.CloseJob)
+	router.Delete("/:id", auth, recruiter, h.DeleteJob)
+	router.Get("/:id/screening", auth, company, h.GetScreening)
+	router.Put("/:id/screening", auth, recruiter, h.ReplaceScreening)
+
+	router.Post("/:id/apply", auth, jobSeeker, h.Apply)
+	router.Post("/:id/save", auth, jobSeeker, h.SaveJob)
+	router.Delete("/:id/save", auth, jobSeeker, h.UnsaveJob)
+	router.Get("/:id/applications", auth, company, h.ListJobApplications)
+}
+
+// RegisterApplicationRoutes registers routes used by both sides of a job application
+func (h *JobHandler) RegisterApplicationRoutes(router fiber.Router) {
+	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
+
+	router.Get("/", middleware.RequireJobSeekerMiddleware(), h.ListMyApplications)
+	router.Get("/:id", h.GetApplication)
+	router.Patch("/:id/stage", middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, userEntity.CompanyOwner, userEntity.CompanyRecruiter), h.MoveApplicationStage)
+	router.Post("/:id/withdraw", middleware.RequireJobSeekerMiddleware(), h.WithdrawApplication)
+	router.Get("/:id/interviews", h.ListApplicationInterviews)
+	router.Post("/:id/interviews", middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, userEntity.CompanyOwner, userEntity.CompanyRecruiter), h.ProposeInterview)
+	router.Get("/:id/messages", h.ListMessages)
+	router.Post("/:id/messages", h.requireThreadWriter(), h.SendMessage)
+	router.Post("/:id/messages/read", h.MarkThreadRead)
+}
+
+// RegisterMessageRoutes registers the unread message counts across every thread of the user
+func (h *JobHandler) RegisterMessageRoutes(router fiber.Router) {
+	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
+
+	router.Get("/unread", h.GetUnreadMessages)
+}
+
+// RegisterInterviewRoutes registers routes acting on a single interview. Companies reschedule, candidates
+// pick a slot and either party can cancel.
+func (h *JobHandler) RegisterInterviewRoutes(router fiber.Router) {
+	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
+
+	router.Post("/:id/select", middleware.RequireJobSeekerMiddleware(), h.SelectInterviewSlot)
+	router.Patch("/:id/reschedule", middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, userEntity.CompanyOwner, userEntity.CompanyRecruiter), h.RescheduleInterview)
+	router.Post("/:id/cancel", h.CancelInterview)
+}
+
+// RegisterSavedJobRoutes registers the job seeker's bookmarked postings list. The router must already
+// require authentication.
+func (h *JobHandler) RegisterSavedJobRoutes(router fiber.Router) {
+	router.Get("/", middleware.RequireJobSeekerMiddleware(), h.ListSavedJobs)
+}
+
+// RegisterSavedSearchRoutes registers job seeker saved search routes and the public unsubscribe
+// endpoints linked from job alert emails
+func (h *JobHandler) RegisterSavedSearchRoutes(router fiber.Router) {
+	auth := middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log)
+	jobSeeker := middleware.RequireJobSeekerMiddleware()
+
+	router.Get("/unsubscribe", h.UnsubscribeSavedSearch)
+	router.Post("/unsubscribe", h.UnsubscribeSavedSearchOneClick)
+
+	router.Get("/", auth, jobSeeker, h.ListSavedSearches)
+	router.Post("/", auth, jobSeeker, h.CreateSavedSearch)
+	router.Put("/:id", auth, jobSeeker, h.UpdateSavedSearch)
+	router.Delete("/:id", auth, jobSeeker, h.DeleteSavedSearch)
+}
@@ -0,0 +1,225 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// maxInterviewSlots is how many start times a company can propose at once
+const maxInterviewSlots = 10
+
+// maxInterviewLeadTime is how far ahead an interview can be planned
+const maxInterviewLeadTime = 365 * 24 * time.Hour
+
+// ProposeInterview handles a company proposing interview slots for an application
+func (h *JobHandler) ProposeInterview(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	var req dto.InterviewProposalRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode interview proposal request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Timezone = strings.TrimSpace(req.Timezone)
+	if req.Timezone == "" {
+		req.Timezone = h.config.Timezone
+	}
+
+	now := time.Now()
+	seen := make(map[int64]bool, len(req.Slots))
+	req.Validator.CheckField(validator.Between(len(req.Slots), 1, maxInterviewSlots), "Slots", "Propose between 1 and 10 interview slots")
+	for _, slot := range req.Slots {
+		req.Validator.CheckField(slot.After(now), "Slots", "Interview slots must be in the future")
+		req.Validator.CheckField(slot.Before(now.Add(maxInterviewLeadTime)), "Slots", "Interview slots must be within one year")
+		req.Validator.CheckField(!seen[slot.Unix()], "Slots", "Interview slots must be unique")
+		seen[slot.Unix()] = true
+	}
+	validateInterviewDetails(&req.Validator, req.DurationMinutes, req.Timezone, req.Location)
+	req.Validator.CheckField(validator.MaxRunes(req.Notes, 2000), "Notes", "Notes are too long (max 2000 characters)")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	interview, err := h.jobUseCase.ProposeInterview(ctx, userID, applicationID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Propose interview failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(interview)
+}
+
+// ListApplicationInterviews handles fetching the interviews of an application for either party
+func (h *JobHandler) ListApplicationInterviews(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	ctx := c.Context()
+	usr := c.Locals("user").(userEntity.User)
+
+	var interviews []entity.Interview
+	switch usr.Role {
+	case userEntity.CompanyRole:
+		interviews, err = h.jobUseCase.ListInterviewsForCompany(ctx, usr.ID, applicationID)
+	case userEntity.JobSeekerRole:
+		interviews, err = h.jobUseCase.ListInterviewsForJobSeeker(ctx, usr.ID, applicationID)
+	default:
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+	if err != nil {
+		return h.respondJobError(c, err, "List interviews failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(interviews)
+}
+
+// SelectInterviewSlot handles a candidate picking one of the proposed interview slots
+func (h *JobHandler) SelectInterviewSlot(c *fiber.Ctx) error {
+	interviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid interview ID format")
+	}
+
+	var req dto.SelectInterviewSlotRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode select interview slot request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.SlotID != uuid.Nil, "SlotID", "Slot ID is required")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	interview, err := h.jobUseCase.SelectInterviewSlot(ctx, userID, interviewID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Select interview slot failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Interview scheduled successfully",
+		"data":    interview,
+	})
+}
+
+// RescheduleInterview handles a company moving a scheduled interview
+func (h *JobHandler) RescheduleInterview(c *fiber.Ctx) error {
+	interviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid interview ID format")
+	}
+
+	var req dto.RescheduleInterviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode reschedule interview request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	now := time.Now()
+	req.Validator.CheckField(req.StartsAt.After(now), "StartsAt", "Interview start must be in the future")
+	req.Validator.CheckField(req.StartsAt.Before(now.Add(maxInterviewLeadTime)), "StartsAt", "Interview start must be within one year")
+	if req.DurationMinutes != nil {
+		validateInterviewDuration(&req.Validator, *req.DurationMinutes)
+	}
+	if req.Timezone != nil {
+		*req.Timezone = strings.TrimSpace(*req.Timezone)
+		validateInterviewTimezone(&req.Validator, *req.Timezone)
+	}
+	if req.Location != nil {
+		req.Validator.CheckField(validator.MaxRunes(*req.Location, 500), "Location", "Location is too long (max 500 characters)")
+	}
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	interview, err := h.jobUseCase.RescheduleInterview(ctx, userID, interviewID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Reschedule interview failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Interview rescheduled successfully",
+		"data":    interview,
+	})
+}
+
+// CancelInterview handles either party cancelling an interview
+func (h *JobHandler) CancelInterview(c *fiber.Ctx) error {
+	interviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid interview ID format")
+	}
+
+	var req dto.CancelInterviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			h.log.WithError(err).Error("Failed to decode cancel interview request")
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+		}
+	}
+
+	req.Validator.CheckField(validator.MaxRunes(req.Reason, 1000), "Reason", "Reason is too long (max 1000 characters)")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	usr := c.Locals("user").(userEntity.User)
+
+	var interview *entity.Interview
+	switch usr.Role {
+	case userEntity.CompanyRole:
+		interview, err = h.jobUseCase.CancelInterviewForCompany(ctx, usr.ID, interviewID, req)
+	case userEntity.JobSeekerRole:
+		interview, err = h.jobUseCase.CancelInterviewForJobSeeker(ctx, usr.ID, interviewID, req)
+	default:
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+	if err != nil {
+		return h.respondJobError(c, err, "Cancel interview failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Interview cancelled successfully",
+		"data":    interview,
+	})
+}
+
+func validateInterviewDetails(v *validator.Validator, durationMinutes int, timezone, location string) {
+	validateInterviewDuration(v, durationMinutes)
+	validateInterviewTimezone(v, timezone)
+	v.CheckField(validator.MaxRunes(location, 500), "Location", "Location is too long (max 500 characters)")
+}
+
+func validateInterviewDuration(v *validator.Validator, minutes int) {
+	v.CheckField(validator.Between(minutes, 15, 480), "DurationMinutes", "Interview duration must be between 15 and 480 minutes")
+}
+
+// validateInterviewTimezone requires an IANA zone name. "Local" is rejected since it depends on the
+// server the request lands on.
+func validateInterviewTimezone(v *validator.Validator, timezone string) {
+	_, err := time.LoadLocation(timezone)
+	v.CheckField(timezone != "" && timezone != "Local" && err == nil, "Timezone", "Timezone must be an IANA time zone such as Asia/Jakarta")
+	v.CheckField(validator.MaxRunes(timezone, 64), "Timezone", "Timezone is too long")
+}
@@ -0,0 +1,267 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// ListJobs handles fetching published job postings
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	paging := pagination.ExtractFromRequest(c)
+
+	jobs, err := h.jobUseCase.ListPublishedJobs(ctx, paging)
+	if err != nil {
+		h.log.WithError(err).Error("List jobs failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(jobs)
+}
+
+// GetRecommendedJobs handles fetching the published postings that best fit the job seeker's profile,
+// each with the criteria it matched
+func (h *JobHandler) GetRecommendedJobs(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	jobs, err := h.jobUseCase.GetRecommendedJobs(ctx, userID, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "Get recommended jobs failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(jobs)
+}
+
+// GetJob handles fetching a single published or closed job posting
+func (h *JobHandler) GetJob(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	job, err := h.jobUseCase.GetPublishedJob(ctx, jobID)
+	if err != nil {
+		return h.respondJobError(c, err, "Get job failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(job)
+}
+
+// ListCompanyJobs handles fetching every job posting of the authenticated company, drafts included
+func (h *JobHandler) ListCompanyJobs(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	jobs, err := h.jobUseCase.ListCompanyJobs(ctx, userID, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "List company jobs failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(jobs)
+}
+
+// CreateJob handles creating a draft job posting for the authenticated company
+func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
+	var req dto.JobRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode create job request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateJobRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	job, err := h.jobUseCase.CreateJob(ctx, userID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Create job failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(job)
+}
+
+// UpdateJob handles replacing the content of a job posting
+func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	var req dto.JobRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update job request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateJobRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	job, err := h.jobUseCase.UpdateJob(ctx, userID, jobID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Update job failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(job)
+}
+
+// PublishJob handles publishing a job posting
+func (h *JobHandler) PublishJob(c *fiber.Ctx) error {
+	return h.changeJobStatus(c, h.jobUseCase.PublishJob, "Job published successfully")
+}
+
+// UnpublishJob handles moving a job posting back to draft
+func (h *JobHandler) UnpublishJob(c *fiber.Ctx) error {
+	return h.changeJobStatus(c, h.jobUseCase.UnpublishJob, "Job unpublished successfully")
+}
+
+// CloseJob handles closing a job posting
+func (h *JobHandler) CloseJob(c *fiber.Ctx) error {
+	return h.changeJobStatus(c, h.jobUseCase.CloseJob, "Job closed successfully")
+}
+
+// DeleteJob handles deleting a draft job posting
+func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.jobUseCase.DeleteJob(ctx, userID, jobID); err != nil {
+		return h.respondJobError(c, err, "Delete job failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Job successfully deleted",
+	})
+}
+
+type jobStatusFunc func(ctx context.Context, userID, jobID uuid.UUID) (*entity.JobPosting, error)
+
+func (h *JobHandler) changeJobStatus(c *fiber.Ctx, change jobStatusFunc, message string) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	job, err := change(ctx, userID, jobID)
+	if err != nil {
+		return h.respondJobError(c, err, "Change job status failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": message,
+		"data":    job,
+	})
+}
+
+// respondJobError maps job usecase errors to HTTP responses
+func (h *JobHandler) respondJobError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrJobNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Job not found")
+	case errors.Is(err, repository.ErrCompanyNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company profile not found")
+	case errors.Is(err, repository.ErrInvalidStatusTransition):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Job cannot move to the requested status")
+	case errors.Is(err, repository.ErrPublishLimitReached):
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Unverified companies can only have one published job. Request company verification to publish more")
+	case errors.Is(err, repository.ErrJobClosed):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Closed jobs cannot be edited")
+	case errors.Is(err, repository.ErrJobNotDeletable):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Only draft jobs without applications can be deleted, close the job instead")
+	case errors.Is(err, repository.ErrJobSeekerNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Job seeker profile not found")
+	case errors.Is(err, repository.ErrApplicationNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Application not found")
+	case errors.Is(err, repository.ErrAlreadyApplied):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "You have already applied to this job")
+	case errors.Is(err, repository.ErrJobNotOpen):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Job is not open for applications")
+	case errors.Is(err, repository.ErrInvalidStageTransition):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Application cannot move to the requested stage")
+	case errors.Is(err, repository.ErrSavedSearchNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Saved search not found")
+	case errors.Is(err, repository.ErrScreeningLocked):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Screening questions cannot change after the job received applications")
+	case errors.Is(err, repository.ErrInvalidScreeningAnswer):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid screening answers: "+strings.TrimPrefix(err.Error(), repository.ErrInvalidScreeningAnswer.Error()+": "))
+	case errors.Is(err, repository.ErrSavedJobNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Saved job not found")
+	case errors.Is(err, repository.ErrSavedSearchLimitReached):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "You can keep at most 20 saved searches")
+	case errors.Is(err, repository.ErrInterviewNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Interview not found")
+	case errors.Is(err, repository.ErrInterviewSlotNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Interview slot not found")
+	case errors.Is(err, repository.ErrInterviewSlotPassed):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "This interview slot has already passed")
+	case errors.Is(err, repository.ErrInvalidInterviewStatus):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Interview cannot be changed in its current state")
+	case errors.Is(err, repository.ErrNotInInterviewStage):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Application is not in the interview stage")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func validateJobRequest(req *dto.JobRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.Title), "Title", "Title is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Title, 255), "Title", "Title is too long")
+	req.Validator.CheckField(validator.NotBlank(req.Description), "Description", "Description is required")
+	req.Validator.CheckField(validator.In(entity.EmploymentType(req.EmploymentType), entity.EmploymentTypes...), "EmploymentType", "Employment type must be one of full_time, part_time, contract, internship or freelance")
+	req.Validator.CheckField(validator.NotBlank(req.Location), "Location", "Location is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Location, 255), "Location", "Location is too long")
+
+	if req.SalaryMin != nil {
+		req.Validator.CheckField(*req.SalaryMin >= 0, "SalaryMin", "Minimum salary cannot be negative")
+	}
+	if req.SalaryMax != nil {
+		req.Validator.CheckField(*req.SalaryMax >= 0, "SalaryMax", "Maximum salary cannot be negative")
+	}
+	if req.SalaryMin != nil && req.SalaryMax != nil {
+		req.Validator.CheckField(*req.SalaryMin <= *req.SalaryMax, "SalaryMax", "Maximum salary must be greater than or equal to minimum salary")
+	}
+	if req.ApplicationDeadline != nil {
+		req.Validator.CheckField(req.ApplicationDeadline.After(time.Now()), "ApplicationDeadline", "Application deadline must be in the future")
+	}
+
+	req.Validator.CheckField(len(req.RequiredSkills) <= 30, "RequiredSkills", "Too many required skills (max 30)")
+	req.Validator.CheckField(validator.NoDuplicates(req.RequiredSkills), "RequiredSkills", "Required skills must be unique")
+	for _, skill := range req.RequiredSkills {
+		req.Validator.CheckField(validator.MaxRunes(skill, 100), "RequiredSkills", "Skill name is too long")
+	}
+}
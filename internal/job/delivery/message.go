@@ -0,0 +1,173 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/upload"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxMessageAttachments is how many files one message may carry
+	maxMessageAttachments = 5
+	// maxMessageAttachmentSize is the largest message attachment accepted, in bytes
+	maxMessageAttachmentSize = 5 * 1024 * 1024
+)
+
+// messageAttachmentRules are the files a message may carry
+var messageAttachmentRules = upload.Rules{
+	Label:   "Attachment",
+	MaxSize: maxMessageAttachmentSize,
+	Types:   upload.DocumentTypes,
+}
+
+// SendMessage handles either party posting a message to the thread of an application
+func (h *JobHandler) SendMessage(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	side, ok := threadSide(c)
+	if !ok {
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+
+	var req dto.SendMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode send message request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	// Attachments are only possible with a multipart form
+	if form, err := c.MultipartForm(); err == nil {
+		files := form.File["attachments"]
+		if len(files) > maxMessageAttachments {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Too many attachments (max 5)")
+		}
+
+		for _, file := range files {
+			attachment, msg, err := upload.Read(file, messageAttachmentRules)
+			if err != nil {
+				h.log.WithError(err).Error("Failed to read message attachment")
+				return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+			}
+			if msg != "" {
+				return responseError.RespondWithError(c, fiber.StatusBadRequest, msg)
+			}
+			req.Attachments = append(req.Attachments, dto.MessageAttachmentUpload(attachment))
+		}
+	}
+
+	req.Validator.CheckField(validator.NotBlank(req.Body) || len(req.Attachments) > 0, "Body", "Message body or an attachment is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Body, 5000), "Body", "Message is too long (max 5000 characters)")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	msg, err := h.jobUseCase.SendMessage(ctx, userID, side, applicationID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Send message failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(msg)
+}
+
+// ListMessages handles either party fetching the thread of an application, newest messages first
+func (h *JobHandler) ListMessages(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	side, ok := threadSide(c)
+	if !ok {
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	messages, err := h.jobUseCase.ListMessages(ctx, userID, side, applicationID, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "List messages failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(messages)
+}
+
+// MarkThreadRead handles either party marking the thread of an application as read
+func (h *JobHandler) MarkThreadRead(c *fiber.Ctx) error {
+	applicationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid application ID format")
+	}
+
+	side, ok := threadSide(c)
+	if !ok {
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.jobUseCase.MarkThreadRead(ctx, userID, side, applicationID); err != nil {
+		return h.respondJobError(c, err, "Mark thread read failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Thread marked as read",
+	})
+}
+
+// GetUnreadMessages handles fetching the user's unread message counts
+func (h *JobHandler) GetUnreadMessages(c *fiber.Ctx) error {
+	side, ok := threadSide(c)
+	if !ok {
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Company or job seeker role is required")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	unread, err := h.jobUseCase.GetUnreadMessages(ctx, userID, side)
+	if err != nil {
+		return h.respondJobError(c, err, "Get unread messages failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(unread)
+}
+
+// requireThreadWriter lets job seekers through and limits company users to owners and recruiters,
+// so viewers can read threads but not post to them
+func (h *JobHandler) requireThreadWriter() fiber.Handler {
+	recruiter := middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, userEntity.CompanyOwner, userEntity.CompanyRecruiter)
+	return func(c *fiber.Ctx) error {
+		if c.Locals("user").(userEntity.User).Role == userEntity.CompanyRole {
+			return recruiter(c)
+		}
+		return c.Next()
+	}
+}
+
+// threadSide returns the side of an application the current user acts for
+func threadSide(c *fiber.Ctx) (entity.ThreadSide, bool) {
+	switch c.Locals("user").(userEntity.User).Role {
+	case userEntity.CompanyRole:
+		return entity.SideCompany, true
+	case userEntity.JobSeekerRole:
+		return entity.SideJobSeeker, true
+	default:
+		return "", false
+	}
+}
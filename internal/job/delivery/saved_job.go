@@ -0,0 +1,60 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SaveJob handles a job seeker bookmarking a posting
+func (h *JobHandler) SaveJob(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.jobUseCase.SaveJob(ctx, userID, jobID); err != nil {
+		return h.respondJobError(c, err, "Save job failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Job saved successfully",
+	})
+}
+
+// UnsaveJob handles a job seeker removing a bookmarked posting
+func (h *JobHandler) UnsaveJob(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.jobUseCase.UnsaveJob(ctx, userID, jobID); err != nil {
+		return h.respondJobError(c, err, "Unsave job failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Job removed from saved jobs",
+	})
+}
+
+// ListSavedJobs handles a job seeker fetching their bookmarked postings
+func (h *JobHandler) ListSavedJobs(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	jobs, err := h.jobUseCase.ListSavedJobs(ctx, userID, paging)
+	if err != nil {
+		return h.respondJobError(c, err, "List saved jobs failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(jobs)
+}
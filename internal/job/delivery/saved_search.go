@@ -0,0 +1,184 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// ListSavedSearches handles a job seeker fetching their saved searches
+func (h *JobHandler) ListSavedSearches(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	searches, err := h.jobUseCase.ListSavedSearches(ctx, userID)
+	if err != nil {
+		return h.respondJobError(c, err, "List saved searches failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(searches)
+}
+
+// CreateSavedSearch handles a job seeker saving a search with its alert settings
+func (h *JobHandler) CreateSavedSearch(c *fiber.Ctx) error {
+	var req dto.SavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode create saved search request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateSavedSearchRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	search, err := h.jobUseCase.CreateSavedSearch(ctx, userID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Create saved search failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(search)
+}
+
+// UpdateSavedSearch handles replacing the filters and alert settings of a saved search
+func (h *JobHandler) UpdateSavedSearch(c *fiber.Ctx) error {
+	searchID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid saved search ID format")
+	}
+
+	var req dto.SavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update saved search request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateSavedSearchRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	search, err := h.jobUseCase.UpdateSavedSearch(ctx, userID, searchID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Update saved search failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(search)
+}
+
+// DeleteSavedSearch handles removing a saved search
+func (h *JobHandler) DeleteSavedSearch(c *fiber.Ctx) error {
+	searchID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid saved search ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.jobUseCase.DeleteSavedSearch(ctx, userID, searchID); err != nil {
+		return h.respondJobError(c, err, "Delete saved search failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Saved search successfully deleted",
+	})
+}
+
+// UnsubscribeSavedSearch handles the unsubscribe link of a job alert email. It only renders a
+// confirmation page: link scanners follow GET links, so the form posts to UnsubscribeSavedSearchOneClick.
+func (h *JobHandler) UnsubscribeSavedSearch(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Token is required")
+	}
+
+	search, err := h.jobUseCase.GetSavedSearchByUnsubscribeToken(c.Context(), token)
+	if err != nil {
+		if errors.Is(err, repository.ErrSavedSearchNotFound) {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid token")
+		}
+		h.log.WithError(err).Error("Get saved search by unsubscribe token failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	confirmHTML, err := templates.GetUnsubscribeConfirmHTML(search.Name, c.OriginalURL())
+	if err != nil {
+		h.log.WithError(err).Error("Failed to load unsubscribe confirmation template")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Error rendering confirmation page")
+	}
+
+	return c.Status(fiber.StatusOK).Type("html").SendString(confirmHTML)
+}
+
+// UnsubscribeSavedSearchOneClick turns off the alerts of a saved search. It serves both the one-click
+// POST that mail clients send for the List-Unsubscribe header and the confirmation page form, which
+// gets the success page back.
+func (h *JobHandler) UnsubscribeSavedSearchOneClick(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Token is required")
+	}
+
+	search, err := h.jobUseCase.UnsubscribeSavedSearch(c.Context(), token)
+	if err != nil {
+		if errors.Is(err, repository.ErrSavedSearchNotFound) {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid token")
+		}
+		h.log.WithError(err).Error("Unsubscribe saved search failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	// Mail clients send no Accept header and get JSON, browsers submitting the form get the page
+	if c.Accepts(fiber.MIMEApplicationJSON, fiber.MIMETextHTML) == fiber.MIMETextHTML {
+		successHTML, err := templates.GetUnsubscribeSuccessHTML(search.Name, h.config.FrontendURL+"/saved-searches")
+		if err != nil {
+			h.log.WithError(err).Error("Failed to load unsubscribe success template")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Error rendering success page")
+		}
+		return c.Status(fiber.StatusOK).Type("html").SendString(successHTML)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Unsubscribed from job alerts successfully",
+	})
+}
+
+func validateSavedSearchRequest(req *dto.SavedSearchRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.Name), "Name", "Name is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Name, 100), "Name", "Name is too long (max 100 characters)")
+	req.Validator.CheckField(validator.MaxRunes(req.Query, 200), "Query", "Search query is too long (max 200 characters)")
+	req.Validator.CheckField(validator.MaxRunes(req.Location, 255), "Location", "Location is too long")
+	req.Validator.CheckField(validator.In(entity.AlertFrequency(req.AlertFrequency), entity.AlertFrequencies...), "AlertFrequency", "Alert frequency must be one of instant, daily or weekly")
+
+	for _, t := range req.EmploymentTypes {
+		req.Validator.CheckField(validator.In(entity.EmploymentType(strings.TrimSpace(t)), entity.EmploymentTypes...), "EmploymentTypes", "Invalid employment type: "+t)
+	}
+	for _, size := range req.CompanySizes {
+		req.Validator.CheckField(validator.In(userEntity.CompanySizeRange(strings.TrimSpace(size)), userEntity.CompanySizeRanges...), "CompanySizes", "Invalid company size: "+size)
+	}
+
+	if req.SalaryMin != nil {
+		req.Validator.CheckField(*req.SalaryMin >= 0, "SalaryMin", "Minimum salary cannot be negative")
+	}
+	if req.SalaryMax != nil {
+		req.Validator.CheckField(*req.SalaryMax >= 0, "SalaryMax", "Maximum salary cannot be negative")
+	}
+	if req.SalaryMin != nil && req.SalaryMax != nil {
+		req.Validator.CheckField(*req.SalaryMin <= *req.SalaryMax, "SalaryMax", "Maximum salary must not be less than minimum salary")
+	}
+}
@@ -0,0 +1,141 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxScreeningQuestions is how many screening questions a posting can have
+const maxScreeningQuestions = 20
+
+// GetScreeningQuestions handles fetching the screening questions an applicant has to answer
+func (h *JobHandler) GetScreeningQuestions(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	questions, err := h.jobUseCase.GetPublicScreeningQuestions(c.Context(), jobID)
+	if err != nil {
+		return h.respondJobError(c, err, "Get screening questions failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(questions)
+}
+
+// GetScreening handles a company fetching the screening questions of a posting with their knockout rules
+func (h *JobHandler) GetScreening(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	screening, err := h.jobUseCase.GetScreening(ctx, userID, jobID)
+	if err != nil {
+		return h.respondJobError(c, err, "Get screening failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(screening)
+}
+
+// ReplaceScreening handles a company replacing the screening questions of a posting
+func (h *JobHandler) ReplaceScreening(c *fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid job ID format")
+	}
+
+	var req dto.ScreeningRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode screening request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateScreeningRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	screening, err := h.jobUseCase.ReplaceScreening(ctx, userID, jobID, req)
+	if err != nil {
+		return h.respondJobError(c, err, "Replace screening failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(screening)
+}
+
+func validateScreeningRequest(req *dto.ScreeningRequest) {
+	req.Validator.CheckField(len(req.Questions) <= maxScreeningQuestions, "Questions", "Too many screening questions (max 20)")
+	if req.RejectionEmailDelayHours != nil {
+		req.Validator.CheckField(validator.Between(*req.RejectionEmailDelayHours, 0, 720), "RejectionEmailDelayHours", "Rejection email delay must be between 0 and 720 hours")
+	}
+
+	for i, q := range req.Questions {
+		key := "Questions[" + strconv.Itoa(i) + "]"
+		label := "Question " + strconv.Itoa(i+1)
+
+		req.Validator.CheckField(validator.NotBlank(q.Question), key, label+": question text is required")
+		req.Validator.CheckField(validator.MaxRunes(q.Question, 500), key, label+": question text is too long (max 500 characters)")
+		req.Validator.CheckField(validator.In(entity.ScreeningQuestionType(q.Type), entity.ScreeningQuestionTypes...), key, label+": type must be one of yes_no, multiple_choice, numeric or free_text")
+
+		options := trimAll(q.Options)
+		knockouts := trimAll(q.KnockoutAnswers)
+		hasRange := q.MinAccepted != nil || q.MaxAccepted != nil
+
+		switch entity.ScreeningQuestionType(q.Type) {
+		case entity.QuestionYesNo, entity.QuestionMultipleChoice:
+			answers := entity.YesNoAnswers
+			if q.Type == string(entity.QuestionMultipleChoice) {
+				answers = options
+				req.Validator.CheckField(validator.Between(len(options), 2, 20), key, label+": multiple choice questions need 2 to 20 options")
+				req.Validator.CheckField(validator.NoDuplicates(options), key, label+": options must be unique")
+				for _, option := range options {
+					req.Validator.CheckField(validator.NotBlank(option), key, label+": options cannot be blank")
+					req.Validator.CheckField(validator.MaxRunes(option, 200), key, label+": option is too long (max 200 characters)")
+				}
+			} else {
+				req.Validator.CheckField(len(options) == 0, key, label+": yes/no questions take no options")
+			}
+
+			req.Validator.CheckField(!hasRange, key, label+": only numeric questions take an accepted range")
+			for _, answer := range knockouts {
+				req.Validator.CheckField(slices.Contains(answers, answer), key, label+": knockout answer "+strconv.Quote(answer)+" is not a possible answer")
+			}
+			req.Validator.CheckField(len(knockouts) == 0 || !validator.AllIn(answers, knockouts...), key, label+": at least one answer must pass the knockout")
+		case entity.QuestionNumeric:
+			req.Validator.CheckField(len(options) == 0 && len(knockouts) == 0, key, label+": numeric questions take an accepted range instead of options")
+			if q.MinAccepted != nil && q.MaxAccepted != nil {
+				req.Validator.CheckField(*q.MinAccepted <= *q.MaxAccepted, key, label+": minimum accepted value must not exceed the maximum")
+			}
+		case entity.QuestionFreeText:
+			req.Validator.CheckField(len(options) == 0 && len(knockouts) == 0 && !hasRange, key, label+": free text questions cannot be knockouts")
+		}
+
+		// An optional knockout question could simply be skipped
+		if len(knockouts) > 0 || hasRange {
+			req.Validator.CheckField(q.Required == nil || *q.Required, key, label+": knockout questions must be required")
+		}
+	}
+}
+
+// trimAll trims every value, keeping blank ones so they can be reported
+func trimAll(values []string) []string {
+	trimmed := make([]string, len(values))
+	for i, value := range values {
+		trimmed[i] = strings.TrimSpace(value)
+	}
+	return trimmed
+}
@@ -0,0 +1,87 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"strings"
+)
+
+// SearchJobs handles full-text search over published job postings. List filters take comma
+// separated values, e.g. employment_type=full_time,contract.
+func (h *JobHandler) SearchJobs(c *fiber.Ctx) error {
+	var v validator.Validator
+	var filter entity.JobSearchFilter
+
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		v.CheckField(validator.MaxRunes(q, 200), "q", "Search query is too long (max 200 characters)")
+		filter.Query = &q
+	}
+	if location := strings.TrimSpace(c.Query("location")); location != "" {
+		filter.Location = &location
+	}
+	if remote := c.Query("remote"); remote != "" {
+		isRemote, err := strconv.ParseBool(remote)
+		v.CheckField(err == nil, "remote", "Remote must be true or false")
+		filter.IsRemote = &isRemote
+	}
+	for _, t := range splitQueryList(c.Query("employment_type")) {
+		v.CheckField(validator.In(entity.EmploymentType(t), entity.EmploymentTypes...), "employment_type", "Invalid employment type: "+t)
+		filter.EmploymentTypes = append(filter.EmploymentTypes, entity.EmploymentType(t))
+	}
+	filter.SalaryMin = parseSalaryQuery(c, &v, "salary_min")
+	filter.SalaryMax = parseSalaryQuery(c, &v, "salary_max")
+	if filter.SalaryMin != nil && filter.SalaryMax != nil {
+		v.CheckField(*filter.SalaryMin <= *filter.SalaryMax, "salary_max", "Maximum salary must not be less than minimum salary")
+	}
+	filter.Industries = splitQueryList(c.Query("industry"))
+	for _, size := range splitQueryList(c.Query("company_size")) {
+		// An unencoded "+" arrives as a space, which leaves "1000" after trimming
+		if size+"+" == string(userEntity.Size1000Plus) {
+			size = string(userEntity.Size1000Plus)
+		}
+		v.CheckField(validator.In(userEntity.CompanySizeRange(size), userEntity.CompanySizeRanges...), "company_size", "Invalid company size: "+size)
+		filter.CompanySizes = append(filter.CompanySizes, size)
+	}
+
+	if v.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, v.FirstErrorMessage())
+	}
+
+	paging := pagination.ExtractFromRequest(c)
+
+	results, err := h.jobUseCase.SearchJobs(c.Context(), filter, paging)
+	if err != nil {
+		h.log.WithError(err).Error("Search jobs failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(results)
+}
+
+// splitQueryList splits a comma separated query value, dropping blank items
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseSalaryQuery parses an optional non-negative salary query parameter
+func parseSalaryQuery(c *fiber.Ctx, v *validator.Validator, key string) *int {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	salary, err := strconv.Atoi(value)
+	v.CheckField(err == nil && salary >= 0, key, "Salary must be a non-negative number")
+	return &salary
+}
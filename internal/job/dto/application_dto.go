@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+)
+
+// ApplyRequest represents a job seeker's application to a job posting
+type ApplyRequest struct {
+	CoverLetter string                   `json:"cover_letter,omitempty"`
+	Answers     []ScreeningAnswerRequest `json:"answers,omitempty"` // Required when the posting has screening questions
+	Validator   validator.Validator      `json:"-"`
+}
+
+// MoveStageRequest represents a company moving a candidate to another pipeline stage
+type MoveStageRequest struct {
+	Stage     string              `json:"stage"`
+	Note      string              `json:"note,omitempty"`
+	Validator validator.Validator `json:"-"`
+}
+
+// ApplicationDetailResponse contains an application with its screening answers and full stage history
+type ApplicationDetailResponse struct {
+	Application entity.JobApplication           `json:"application"`
+	Answers     []entity.ApplicationAnswer      `json:"answers"`
+	History     []entity.ApplicationStageChange `json:"history"`
+}
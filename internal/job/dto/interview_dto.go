@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/google/uuid"
+	"time"
+)
+
+// InterviewProposalRequest represents a company proposing interview slots to a candidate. Slots are
+// RFC 3339 timestamps and must carry an offset, e.g. 2025-07-01T09:00:00+07:00.
+type InterviewProposalRequest struct {
+	Slots           []time.Time `json:"slots"`
+	DurationMinutes int         `json:"duration_minutes"`
+	// IANA zone the times are shown in, e.g. Asia/Jakarta; defaults to the configured timezone
+	Timezone  string              `json:"timezone,omitempty"`
+	Location  string              `json:"location,omitempty"` // Address or meeting link
+	Notes     string              `json:"notes,omitempty"`
+	Validator validator.Validator `json:"-"`
+}
+
+// SelectInterviewSlotRequest represents a candidate picking one of the proposed slots
+type SelectInterviewSlotRequest struct {
+	SlotID    uuid.UUID           `json:"slot_id"`
+	Validator validator.Validator `json:"-"`
+}
+
+// RescheduleInterviewRequest represents a company moving a scheduled interview. Omitted fields keep
+// their current value.
+type RescheduleInterviewRequest struct {
+	StartsAt        time.Time           `json:"starts_at"`
+	DurationMinutes *int                `json:"duration_minutes,omitempty"`
+	Timezone        *string             `json:"timezone,omitempty"`
+	Location        *string             `json:"location,omitempty"` // An empty string removes the location
+	Validator       validator.Validator `json:"-"`
+}
+
+// CancelInterviewRequest represents either party cancelling an interview
+type CancelInterviewRequest struct {
+	Reason    string              `json:"reason,omitempty"`
+	Validator validator.Validator `json:"-"`
+}
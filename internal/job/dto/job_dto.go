@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"time"
+)
+
+// JobRequest represents the payload used to create or fully update a job posting
+type JobRequest struct {
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	EmploymentType string   `json:"employment_type"`
+	Location       string   `json:"location"`
+	IsRemote       bool     `json:"is_remote"`
+	SalaryMin      *int     `json:"salary_min,omitempty"`
+	SalaryMax      *int     `json:"salary_max,omitempty"`
+	RequiredSkills []string `json:"required_skills"`
+	// RFC 3339 timestamp after which applications are refused
+	ApplicationDeadline *time.Time          `json:"application_deadline,omitempty"`
+	Validator           validator.Validator `json:"-"`
+}
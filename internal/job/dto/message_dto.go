@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+)
+
+// SendMessageRequest represents a message posted to an application thread, as JSON or as a multipart
+// form carrying attachments
+type SendMessageRequest struct {
+	Body        string                    `json:"body" form:"body"`
+	Attachments []MessageAttachmentUpload `json:"-" form:"-"`
+	Validator   validator.Validator       `json:"-" form:"-"`
+}
+
+// MessageAttachmentUpload is a file attached to a message
+type MessageAttachmentUpload struct {
+	FileName    string
+	FileExt     string
+	FileContent []byte
+	ContentType string
+}
+
+// UnreadMessagesResponse contains the user's unread message count and the threads they are in
+type UnreadMessagesResponse struct {
+	Total   int                   `json:"total"`
+	Threads []entity.UnreadThread `json:"threads"`
+}
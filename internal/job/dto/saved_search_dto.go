@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/agpprastyo/career-link/pkg/validator"
+
+// SavedSearchRequest represents the payload used to create or fully update a saved search. The filters
+// take the same values as the job search query parameters.
+type SavedSearchRequest struct {
+	Name            string              `json:"name"`
+	Query           string              `json:"query,omitempty"`
+	Location        string              `json:"location,omitempty"`
+	IsRemote        *bool               `json:"is_remote,omitempty"`
+	EmploymentTypes []string            `json:"employment_types"`
+	SalaryMin       *int                `json:"salary_min,omitempty"`
+	SalaryMax       *int                `json:"salary_max,omitempty"`
+	Industries      []string            `json:"industries"`
+	CompanySizes    []string            `json:"company_sizes"`
+	AlertFrequency  string              `json:"alert_frequency"`
+	AlertsEnabled   *bool               `json:"alerts_enabled,omitempty"` // Defaults to true
+	Validator       validator.Validator `json:"-"`
+}
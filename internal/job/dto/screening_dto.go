@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/google/uuid"
+)
+
+// ScreeningRequest represents the payload used to replace the screening questions of a posting
+type ScreeningRequest struct {
+	Questions []ScreeningQuestionRequest `json:"questions"`
+	// Hours to wait before emailing applicants rejected by a knockout; omit to send no email
+	RejectionEmailDelayHours *int                `json:"rejection_email_delay_hours,omitempty"`
+	Validator                validator.Validator `json:"-"`
+}
+
+// ScreeningQuestionRequest is one screening question. Yes/no and multiple choice questions become
+// knockouts by listing knockout_answers; numeric questions by setting an accepted range.
+type ScreeningQuestionRequest struct {
+	Question        string   `json:"question"`
+	Type            string   `json:"type"`
+	Options         []string `json:"options,omitempty"`
+	Required        *bool    `json:"required,omitempty"` // Defaults to true
+	KnockoutAnswers []string `json:"knockout_answers,omitempty"`
+	MinAccepted     *float64 `json:"min_accepted,omitempty"`
+	MaxAccepted     *float64 `json:"max_accepted,omitempty"`
+}
+
+// ScreeningAnswerRequest is an applicant's answer to one screening question
+type ScreeningAnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Answer     string    `json:"answer"`
+}
+
+// PublicScreeningQuestion is a screening question as shown to applicants, without its knockout rule
+type PublicScreeningQuestion struct {
+	ID       uuid.UUID                    `json:"id"`
+	Question string                       `json:"question"`
+	Type     entity.ScreeningQuestionType `json:"type"`
+	Options  []string                     `json:"options"`
+	Required bool                         `json:"required"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+)
+
+// JobSearchResponse is a page of search results with the facet counts of the whole result set
+type JobSearchResponse struct {
+	pagination.PageResponse
+	Facets entity.JobSearchFacets `json:"facets"`
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ApplicationStage is an enum-like type for the hiring pipeline stage of an application
+type ApplicationStage string
+
+const (
+	StageApplied   ApplicationStage = "applied"
+	StageScreening ApplicationStage = "screening"
+	StageInterview ApplicationStage = "interview"
+	StageOffer     ApplicationStage = "offer"
+	StageHired     ApplicationStage = "hired"
+	StageRejected  ApplicationStage = "rejected"
+	StageWithdrawn ApplicationStage = "withdrawn"
+)
+
+// applicationTransitions lists the stages reachable from each non-final stage.
+// Hired, rejected and withdrawn are final and have no outgoing transitions.
+var applicationTransitions = map[ApplicationStage][]ApplicationStage{
+	StageApplied:   {StageScreening, StageRejected, StageWithdrawn},
+	StageScreening: {StageInterview, StageRejected, StageWithdrawn},
+	StageInterview: {StageOffer, StageRejected, StageWithdrawn},
+	StageOffer:     {StageHired, StageRejected, StageWithdrawn},
+}
+
+// IsFinal reports whether no further transition is possible from the stage
+func (s ApplicationStage) IsFinal() bool {
+	_, ok := applicationTransitions[s]
+	return !ok
+}
+
+// CanTransitionTo reports whether the pipeline allows moving from s to next
+func (s ApplicationStage) CanTransitionTo(next ApplicationStage) bool {
+	for _, allowed := range applicationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// JobApplication represents the job_applications table
+type JobApplication struct {
+	ID             uuid.UUID        `json:"id" db:"id"`
+	JobID          uuid.UUID        `json:"job_id" db:"job_id"`
+	JobSeekerID    uuid.UUID        `json:"job_seeker_id" db:"job_seeker_id"`
+	Stage          ApplicationStage `json:"stage" db:"stage"`
+	CoverLetter    *string          `json:"cover_letter,omitempty" db:"cover_letter"`
+	KnockedOut     bool             `json:"knocked_out" db:"knocked_out"` // Rejected automatically by a screening question
+	StageChangedAt time.Time        `json:"stage_changed_at" db:"stage_changed_at"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// ApplicationWithApplicant is an application joined with the applicant's basic profile, used by companies
+type ApplicationWithApplicant struct {
+	JobApplication
+	FirstName string `json:"first_name" db:"first_name"`
+	LastName  string `json:"last_name" db:"last_name"`
+	Email     string `json:"email" db:"email"`
+}
+
+// ApplicationWithJob is an application joined with the posting it targets, used by job seekers
+type ApplicationWithJob struct {
+	JobApplication
+	JobTitle  string    `json:"job_title" db:"job_title"`
+	JobStatus JobStatus `json:"job_status" db:"job_status"`
+	CompanyID uuid.UUID `json:"company_id" db:"company_id"`
+}
+
+// ApplicationStageChange represents the job_application_stage_history table
+type ApplicationStageChange struct {
+	ID            uuid.UUID         `json:"id" db:"id"`
+	ApplicationID uuid.UUID         `json:"application_id" db:"application_id"`
+	FromStage     *ApplicationStage `json:"from_stage,omitempty" db:"from_stage"`
+	ToStage       ApplicationStage  `json:"to_stage" db:"to_stage"`
+	ChangedBy     *uuid.UUID        `json:"changed_by,omitempty" db:"changed_by"`
+	Note          *string           `json:"note,omitempty" db:"note"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
+
+// ApplicationAudience are the users on each side of an application, who get its notifications
+type ApplicationAudience struct {
+	ApplicationID   uuid.UUID   `db:"application_id"`
+	JobTitle        string      `db:"job_title"`
+	CompanyName     string      `db:"company_name"`
+	CandidateName   string      `db:"candidate_name"`
+	CandidateUserID uuid.UUID   `db:"candidate_user_id"`
+	CompanyUserIDs  []uuid.UUID `db:"-"`
+}
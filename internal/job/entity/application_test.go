@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/job/entity"
+)
+
+var allStages = []entity.ApplicationStage{
+	entity.StageApplied,
+	entity.StageScreening,
+	entity.StageInterview,
+	entity.StageOffer,
+	entity.StageHired,
+	entity.StageRejected,
+	entity.StageWithdrawn,
+}
+
+func TestApplicationStageCanTransitionTo(t *testing.T) {
+	allowed := map[entity.ApplicationStage][]entity.ApplicationStage{
+		entity.StageApplied:   {entity.StageScreening, entity.StageRejected, entity.StageWithdrawn},
+		entity.StageScreening: {entity.StageInterview, entity.StageRejected, entity.StageWithdrawn},
+		entity.StageInterview: {entity.StageOffer, entity.StageRejected, entity.StageWithdrawn},
+		entity.StageOffer:     {entity.StageHired, entity.StageRejected, entity.StageWithdrawn},
+	}
+
+	for _, from := range allStages {
+		for _, to := range allStages {
+			want := false
+			for _, next := range allowed[from] {
+				if next == to {
+					want = true
+				}
+			}
+
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s -> %s: got %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestApplicationStageIsFinal(t *testing.T) {
+	tests := []struct {
+		stage entity.ApplicationStage
+		want  bool
+	}{
+		{entity.StageApplied, false},
+		{entity.StageScreening, false},
+		{entity.StageInterview, false},
+		{entity.StageOffer, false},
+		{entity.StageHired, true},
+		{entity.StageRejected, true},
+		{entity.StageWithdrawn, true},
+		{entity.ApplicationStage("unknown"), true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.stage.IsFinal(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.stage, got, tt.want)
+		}
+	}
+}
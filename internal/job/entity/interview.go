@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// InterviewStatus is an enum-like type for the state of an interview
+type InterviewStatus string
+
+const (
+	InterviewProposed  InterviewStatus = "proposed"  // Waiting for the candidate to pick a slot
+	InterviewScheduled InterviewStatus = "scheduled" // A start time is agreed and invites were sent
+	InterviewCancelled InterviewStatus = "cancelled"
+)
+
+// Interview represents the interviews table
+type Interview struct {
+	ID              uuid.UUID       `json:"id" db:"id"`
+	ApplicationID   uuid.UUID       `json:"application_id" db:"application_id"`
+	Status          InterviewStatus `json:"status" db:"status"`
+	DurationMinutes int             `json:"duration_minutes" db:"duration_minutes"`
+	// IANA name of the zone times are shown in, e.g. Asia/Jakarta
+	Timezone     string          `json:"timezone" db:"timezone"`
+	Location     *string         `json:"location,omitempty" db:"location"` // Address or meeting link
+	Notes        *string         `json:"notes,omitempty" db:"notes"`
+	StartsAt     *time.Time      `json:"starts_at,omitempty" db:"starts_at"` // Set once a slot is picked
+	Sequence     int             `json:"-" db:"sequence"`                    // Revision of the calendar invite
+	ProposedBy   *uuid.UUID      `json:"proposed_by,omitempty" db:"proposed_by"`
+	CancelledBy  *uuid.UUID      `json:"cancelled_by,omitempty" db:"cancelled_by"`
+	CancelReason *string         `json:"cancel_reason,omitempty" db:"cancel_reason"`
+	CancelledAt  *time.Time      `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+	Slots        []InterviewSlot `json:"slots" db:"-"`
+}
+
+// InterviewSlot represents the interview_slots table
+type InterviewSlot struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	InterviewID uuid.UUID `json:"-" db:"interview_id"`
+	StartsAt    time.Time `json:"starts_at" db:"starts_at"`
+}
+
+// Duration returns how long the interview lasts
+func (i *Interview) Duration() time.Duration {
+	return time.Duration(i.DurationMinutes) * time.Minute
+}
+
+// Zone returns the zone the interview times are shown in, falling back to UTC for unknown zones
+func (i *Interview) Zone() *time.Location {
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// InterviewParticipants are the details of the people an interview email goes to
+type InterviewParticipants struct {
+	ApplicationID      uuid.UUID `db:"application_id"`
+	JobTitle           string    `db:"job_title"`
+	CompanyName        string    `db:"company_name"`
+	CompanyEmail       string    `db:"company_email"`
+	CompanyUserID      uuid.UUID `db:"company_user_id"`
+	CandidateUserID    uuid.UUID `db:"candidate_user_id"`
+	CandidateEmail     string    `db:"candidate_email"`
+	CandidateFirstName string    `db:"candidate_first_name"`
+	CandidateLastName  string    `db:"candidate_last_name"`
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// EmploymentType is an enum-like type for job posting employment type
+type EmploymentType string
+
+const (
+	FullTime   EmploymentType = "full_time"
+	PartTime   EmploymentType = "part_time"
+	Contract   EmploymentType = "contract"
+	Internship EmploymentType = "internship"
+	Freelance  EmploymentType = "freelance"
+)
+
+// EmploymentTypes lists every supported employment type
+var EmploymentTypes = []EmploymentType{FullTime, PartTime, Contract, Internship, Freelance}
+
+// JobStatus is an enum-like type for job posting status
+type JobStatus string
+
+const (
+	JobStatusDraft     JobStatus = "draft"
+	JobStatusPublished JobStatus = "published"
+	JobStatusClosed    JobStatus = "closed"
+)
+
+// JobPosting represents the job_postings table
+type JobPosting struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	CompanyID      uuid.UUID      `json:"company_id" db:"company_id"` // Foreign key to companies table
+	Title          string         `json:"title" db:"title"`
+	Description    string         `json:"description" db:"description"`
+	EmploymentType EmploymentType `json:"employment_type" db:"employment_type"`
+	Location       string         `json:"location" db:"location"`
+	IsRemote       bool           `json:"is_remote" db:"is_remote"`
+	SalaryMin      *int           `json:"salary_min,omitempty" db:"salary_min"` // Use a pointer to allow null
+	SalaryMax      *int           `json:"salary_max,omitempty" db:"salary_max"`
+	RequiredSkills pq.StringArray `json:"required_skills" db:"required_skills"`
+	Status         JobStatus      `json:"status" db:"status"`
+	PublishedAt    *time.Time     `json:"published_at,omitempty" db:"published_at"`
+	ClosedAt       *time.Time     `json:"closed_at,omitempty" db:"closed_at"`
+	// Applications are refused once the deadline passes, even while the posting stays published
+	ApplicationDeadline *time.Time `json:"application_deadline,omitempty" db:"application_deadline"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen reports whether the posting accepts applications at now
+func (j *JobPosting) IsOpen(now time.Time) bool {
+	return j.Status == JobStatusPublished && (j.ApplicationDeadline == nil || now.Before(*j.ApplicationDeadline))
+}
+
+// CanTransitionTo reports whether the posting may move from its current status to next
+func (j *JobPosting) CanTransitionTo(next JobStatus) bool {
+	switch j.Status {
+	case JobStatusDraft:
+		return next == JobStatusPublished || next == JobStatusClosed
+	case JobStatusPublished:
+		return next == JobStatusDraft || next == JobStatusClosed
+	default:
+		// Closed postings are final
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ThreadSide is an enum-like type for the side of an application a user acts for in its message thread
+type ThreadSide string
+
+const (
+	SideCompany   ThreadSide = "company"
+	SideJobSeeker ThreadSide = "job_seeker"
+)
+
+// ApplicationMessage represents the application_messages table, joined with the sender's username
+type ApplicationMessage struct {
+	ID            uuid.UUID           `json:"id" db:"id"`
+	ApplicationID uuid.UUID           `json:"application_id" db:"application_id"`
+	SenderID      *uuid.UUID          `json:"sender_id,omitempty" db:"sender_id"`
+	SenderRole    ThreadSide          `json:"sender_role" db:"sender_role"`
+	SenderName    string              `json:"sender_name" db:"sender_name"`
+	Body          string              `json:"body" db:"body"`
+	ReadAt        *time.Time          `json:"read_at,omitempty" db:"read_at"` // When the other side first read it
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+	Attachments   []MessageAttachment `json:"attachments" db:"-"`
+}
+
+// MessageAttachment represents the application_message_attachments table
+type MessageAttachment struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	MessageID   uuid.UUID `json:"-" db:"message_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ObjectName  string    `json:"-" db:"object_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	URL         string    `json:"url,omitempty" db:"-"` // Temporary download URL
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// UnreadThread is a message thread with messages the user has not read yet
+type UnreadThread struct {
+	ApplicationID uuid.UUID `json:"application_id" db:"application_id"`
+	JobTitle      string    `json:"job_title" db:"job_title"`
+	Unread        int       `json:"unread" db:"unread"`
+	LastMessageAt time.Time `json:"last_message_at" db:"last_message_at"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+// RecommendationCriterion names a signal that contributed to a recommendation score
+type RecommendationCriterion string
+
+const (
+	CriterionSkills     RecommendationCriterion = "skills"
+	CriterionJobType    RecommendationCriterion = "job_type"
+	CriterionIndustry   RecommendationCriterion = "industry"
+	CriterionLocation   RecommendationCriterion = "location"
+	CriterionRemote     RecommendationCriterion = "remote"
+	CriterionSalary     RecommendationCriterion = "salary"
+	CriterionExperience RecommendationCriterion = "experience"
+)
+
+// RecommendationProfile holds the job seeker data postings are scored against
+type RecommendationProfile struct {
+	Skills           []string
+	Preferences      []RecommendationPreference
+	ExperienceTitles []string
+}
+
+// RecommendationPreference is a row of job_seeker_preferences as used for scoring
+type RecommendationPreference struct {
+	JobType           string `db:"preferred_job_type"`
+	Industry          string `db:"preferred_industry"`
+	Location          string `db:"preferred_location"`
+	SalaryExpectation *int   `db:"salary_expectation"`
+	Remote            bool   `db:"remote_preference"`
+}
+
+// RecommendationMatch explains how many points a criterion added to a recommendation
+type RecommendationMatch struct {
+	Criterion RecommendationCriterion `json:"criterion"`
+	Points    int                     `json:"points"`
+	Detail    string                  `json:"detail"`
+}
+
+// RecommendedJob is a published posting scored against a job seeker's profile, out of 100
+type RecommendedJob struct {
+	JobPosting
+	CompanyName     string                `json:"company_name" db:"company_name"`
+	CompanyIndustry *string               `json:"company_industry,omitempty" db:"company_industry"`
+	Score           int                   `json:"score" db:"-"`
+	Matches         []RecommendationMatch `json:"matches" db:"-"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SavedJob is a posting bookmarked by a job seeker, with its state at the time of listing
+type SavedJob struct {
+	JobPosting
+	CompanyName string    `json:"company_name" db:"company_name"`
+	SavedAt     time.Time `json:"saved_at" db:"saved_at"`
+	IsOpen      bool      `json:"is_open" db:"is_open"`
+	HasApplied  bool      `json:"has_applied" db:"has_applied"`
+	// Still open, but the application deadline passes within the warning window
+	DeadlineSoon bool `json:"deadline_soon" db:"deadline_soon"`
+}
+
+// SavedJobReminder is a saved posting claimed for a deadline reminder, with the recipient's details
+type SavedJobReminder struct {
+	JobSeekerID         uuid.UUID  `db:"job_seeker_id"`
+	JobID               uuid.UUID  `db:"job_id"`
+	UserID              uuid.UUID  `db:"user_id"`
+	Email               string     `db:"email"`
+	FirstName           string     `db:"first_name"`
+	Title               string     `db:"title"`
+	CompanyName         string     `db:"company_name"`
+	ApplicationDeadline time.Time  `db:"application_deadline"`
+	PreviousRemindedAt  *time.Time `db:"previous_reminded_at"` // Restored when the reminder cannot be sent
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// AlertFrequency is how often a saved search sends new matching postings
+type AlertFrequency string
+
+const (
+	AlertInstant AlertFrequency = "instant" // On the next scheduler run after a match is published
+	AlertDaily   AlertFrequency = "daily"
+	AlertWeekly  AlertFrequency = "weekly"
+)
+
+// AlertFrequencies lists every alert frequency
+var AlertFrequencies = []AlertFrequency{AlertInstant, AlertDaily, AlertWeekly}
+
+// SavedSearch represents the saved_searches table
+type SavedSearch struct {
+	ID               uuid.UUID      `json:"id" db:"id"`
+	JobSeekerID      uuid.UUID      `json:"job_seeker_id" db:"job_seeker_id"`
+	Name             string         `json:"name" db:"name"`
+	Query            *string        `json:"query,omitempty" db:"query"`
+	Location         *string        `json:"location,omitempty" db:"location"`
+	IsRemote         *bool          `json:"is_remote,omitempty" db:"is_remote"`
+	EmploymentTypes  pq.StringArray `json:"employment_types" db:"employment_types"`
+	SalaryMin        *int           `json:"salary_min,omitempty" db:"salary_min"`
+	SalaryMax        *int           `json:"salary_max,omitempty" db:"salary_max"`
+	Industries       pq.StringArray `json:"industries" db:"industries"`
+	CompanySizes     pq.StringArray `json:"company_sizes" db:"company_sizes"`
+	AlertFrequency   AlertFrequency `json:"alert_frequency" db:"alert_frequency"`
+	AlertsEnabled    bool           `json:"alerts_enabled" db:"alerts_enabled"`
+	LastAlertedAt    time.Time      `json:"last_alerted_at" db:"last_alerted_at"`
+	UnsubscribeToken string         `json:"-" db:"unsubscribe_token"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// Filter returns the search the saved search stands for
+func (s *SavedSearch) Filter() JobSearchFilter {
+	types := make([]EmploymentType, 0, len(s.EmploymentTypes))
+	for _, t := range s.EmploymentTypes {
+		types = append(types, EmploymentType(t))
+	}
+
+	return JobSearchFilter{
+		Query:           s.Query,
+		Location:        s.Location,
+		IsRemote:        s.IsRemote,
+		EmploymentTypes: types,
+		SalaryMin:       s.SalaryMin,
+		SalaryMax:       s.SalaryMax,
+		Industries:      s.Industries,
+		CompanySizes:    s.CompanySizes,
+	}
+}
+
+// SavedSearchAlert is a saved search claimed for alerting, with the recipient's details
+type SavedSearchAlert struct {
+	SavedSearch
+	UserID    uuid.UUID `db:"user_id"`
+	Email     string    `db:"email"`
+	FirstName string    `db:"first_name"`
+	Since     time.Time `db:"since"` // Previous last_alerted_at; postings published after it are new
+}
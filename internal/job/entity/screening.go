@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// ScreeningQuestionType is an enum-like type for the answer format of a screening question
+type ScreeningQuestionType string
+
+const (
+	QuestionYesNo          ScreeningQuestionType = "yes_no"
+	QuestionMultipleChoice ScreeningQuestionType = "multiple_choice"
+	QuestionNumeric        ScreeningQuestionType = "numeric"
+	QuestionFreeText       ScreeningQuestionType = "free_text"
+)
+
+// ScreeningQuestionTypes lists every screening question type
+var ScreeningQuestionTypes = []ScreeningQuestionType{QuestionYesNo, QuestionMultipleChoice, QuestionNumeric, QuestionFreeText}
+
+// YesNoAnswers are the only valid answers to a yes/no question
+var YesNoAnswers = []string{"yes", "no"}
+
+// MaxFreeTextAnswerLength is the longest accepted free text answer, in characters
+const MaxFreeTextAnswerLength = 2000
+
+// ScreeningQuestion represents the job_screening_questions table
+type ScreeningQuestion struct {
+	ID       uuid.UUID             `json:"id" db:"id"`
+	JobID    uuid.UUID             `json:"job_id" db:"job_id"`
+	Position int                   `json:"position" db:"position"`
+	Question string                `json:"question" db:"question"`
+	Type     ScreeningQuestionType `json:"type" db:"type"`
+	Options  pq.StringArray        `json:"options" db:"options"` // Choices of a multiple choice question
+	Required bool                  `json:"required" db:"required"`
+	// Answers that reject the applicant, for yes/no and multiple choice questions
+	KnockoutAnswers pq.StringArray `json:"knockout_answers" db:"knockout_answers"`
+	// Accepted range of a numeric answer; answers outside it reject the applicant
+	MinAccepted *float64  `json:"min_accepted,omitempty" db:"min_accepted"`
+	MaxAccepted *float64  `json:"max_accepted,omitempty" db:"max_accepted"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Screening is the set of screening questions of a posting with its knockout settings
+type Screening struct {
+	Questions []ScreeningQuestion `json:"questions"`
+	// Hours to wait before emailing applicants rejected by a knockout; nil sends no email
+	RejectionEmailDelayHours *int `json:"rejection_email_delay_hours"`
+}
+
+// IsKnockout reports whether some answer to the question rejects the applicant
+func (q *ScreeningQuestion) IsKnockout() bool {
+	return len(q.KnockoutAnswers) > 0 || q.MinAccepted != nil || q.MaxAccepted != nil
+}
+
+// ValidAnswer reports whether answer is well-formed for the question type
+func (q *ScreeningQuestion) ValidAnswer(answer string) bool {
+	switch q.Type {
+	case QuestionYesNo:
+		return slices.Contains(YesNoAnswers, answer)
+	case QuestionMultipleChoice:
+		return slices.Contains(q.Options, answer)
+	case QuestionNumeric:
+		value, err := strconv.ParseFloat(answer, 64)
+		return err == nil && !math.IsNaN(value) && !math.IsInf(value, 0)
+	default:
+		return utf8.RuneCountInString(answer) <= MaxFreeTextAnswerLength
+	}
+}
+
+// KnocksOut reports whether a valid answer fails the knockout rule of the question
+func (q *ScreeningQuestion) KnocksOut(answer string) bool {
+	switch q.Type {
+	case QuestionYesNo, QuestionMultipleChoice:
+		return slices.Contains(q.KnockoutAnswers, answer)
+	case QuestionNumeric:
+		value, err := strconv.ParseFloat(answer, 64)
+		if err != nil {
+			return false
+		}
+		return (q.MinAccepted != nil && value < *q.MinAccepted) || (q.MaxAccepted != nil && value > *q.MaxAccepted)
+	default:
+		return false
+	}
+}
+
+// ApplicationAnswer represents the job_application_answers table, joined with its question
+type ApplicationAnswer struct {
+	ApplicationID uuid.UUID             `json:"-" db:"application_id"`
+	QuestionID    uuid.UUID             `json:"question_id" db:"question_id"`
+	Question      string                `json:"question" db:"question"`
+	Type          ScreeningQuestionType `json:"type" db:"type"`
+	Answer        string                `json:"answer" db:"answer"`
+	KnockedOut    bool                  `json:"knocked_out" db:"knocked_out"`
+}
+
+// KnockoutRejection is the automatic rejection of an application that failed a knockout question
+type KnockoutRejection struct {
+	Note       string
+	EmailDueAt *time.Time // When to email the applicant, nil sends no email
+}
+
+// RejectionEmail is a knockout rejection email claimed for sending, with the recipient's details
+type RejectionEmail struct {
+	ApplicationID uuid.UUID `db:"application_id"`
+	DueAt         time.Time `db:"due_at"` // Restored when the email cannot be sent
+	UserID        uuid.UUID `db:"user_id"`
+	Email         string    `db:"email"`
+	FirstName     string    `db:"first_name"`
+	JobTitle      string    `db:"job_title"`
+	CompanyName   string    `db:"company_name"`
+}
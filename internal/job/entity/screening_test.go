@@ -0,0 +1,95 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/lib/pq"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestScreeningQuestionValidAnswer(t *testing.T) {
+	yesNo := entity.ScreeningQuestion{Type: entity.QuestionYesNo}
+	choice := entity.ScreeningQuestion{Type: entity.QuestionMultipleChoice, Options: pq.StringArray{"Remote", "On site"}}
+	numeric := entity.ScreeningQuestion{Type: entity.QuestionNumeric}
+	freeText := entity.ScreeningQuestion{Type: entity.QuestionFreeText}
+
+	tests := []struct {
+		name     string
+		question entity.ScreeningQuestion
+		answer   string
+		want     bool
+	}{
+		{"yes", yesNo, "yes", true},
+		{"no", yesNo, "no", true},
+		{"yes/no is case sensitive", yesNo, "Yes", false},
+		{"yes/no rejects other words", yesNo, "maybe", false},
+		{"listed option", choice, "Remote", true},
+		{"unlisted option", choice, "Hybrid", false},
+		{"option is case sensitive", choice, "remote", false},
+		{"integer", numeric, "5", true},
+		{"decimal", numeric, "2.5", true},
+		{"negative", numeric, "-1", true},
+		{"not a number", numeric, "five", false},
+		{"NaN", numeric, "NaN", false},
+		{"infinity", numeric, "Inf", false},
+		{"overflow", numeric, "1e400", false},
+		{"free text", freeText, "I like Go", true},
+		{"free text at the limit", freeText, strings.Repeat("é", entity.MaxFreeTextAnswerLength), true},
+		{"free text over the limit", freeText, strings.Repeat("a", entity.MaxFreeTextAnswerLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.ValidAnswer(tt.answer); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreeningQuestionKnocksOut(t *testing.T) {
+	yesNo := entity.ScreeningQuestion{Type: entity.QuestionYesNo, KnockoutAnswers: pq.StringArray{"no"}}
+	choice := entity.ScreeningQuestion{Type: entity.QuestionMultipleChoice, Options: pq.StringArray{"Remote", "On site"}, KnockoutAnswers: pq.StringArray{"On site"}}
+	bounded := entity.ScreeningQuestion{Type: entity.QuestionNumeric, MinAccepted: float(2), MaxAccepted: float(10)}
+	minOnly := entity.ScreeningQuestion{Type: entity.QuestionNumeric, MinAccepted: float(3)}
+	maxOnly := entity.ScreeningQuestion{Type: entity.QuestionNumeric, MaxAccepted: float(40)}
+	freeText := entity.ScreeningQuestion{Type: entity.QuestionFreeText, KnockoutAnswers: pq.StringArray{"no"}}
+
+	tests := []struct {
+		name     string
+		question entity.ScreeningQuestion
+		answer   string
+		want     bool
+	}{
+		{"yes/no knockout answer", yesNo, "no", true},
+		{"yes/no passing answer", yesNo, "yes", false},
+		{"yes/no without knockout", entity.ScreeningQuestion{Type: entity.QuestionYesNo}, "no", false},
+		{"choice knockout answer", choice, "On site", true},
+		{"choice passing answer", choice, "Remote", false},
+		{"below minimum", bounded, "1.99", true},
+		{"at minimum", bounded, "2", false},
+		{"inside range", bounded, "5", false},
+		{"at maximum", bounded, "10", false},
+		{"above maximum", bounded, "10.01", true},
+		{"min only, below", minOnly, "2", true},
+		{"min only, large", minOnly, "1000", false},
+		{"max only, above", maxOnly, "41", true},
+		{"max only, negative", maxOnly, "-5", false},
+		{"numeric without bounds", entity.ScreeningQuestion{Type: entity.QuestionNumeric}, "-100", false},
+		{"numeric not a number", bounded, "many", false},
+		{"free text never knocks out", freeText, "no", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.KnocksOut(tt.answer); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// JobSearchFilter holds the keyword and filters of a job search. Nil and empty fields do not filter.
+type JobSearchFilter struct {
+	Query           *string
+	Location        *string
+	IsRemote        *bool
+	EmploymentTypes []EmploymentType
+	SalaryMin       *int // Postings paying at least this much at the top of their range
+	SalaryMax       *int // Postings paying at most this much at the bottom of their range
+	Industries      []string
+	CompanySizes    []string
+	PublishedAfter  *time.Time // Only postings published after this moment, used by saved search alerts
+}
+
+// JobSearchResult is a published posting matching a search, with the company details used for filtering
+type JobSearchResult struct {
+	JobPosting
+	CompanyName     string  `json:"company_name" db:"company_name"`
+	CompanyIndustry *string `json:"company_industry,omitempty" db:"company_industry"`
+	CompanySize     string  `json:"company_size" db:"company_size"`
+	Rank            float64 `json:"rank" db:"rank"`
+}
+
+// FacetCount is the number of matching postings for one value of a filter
+type FacetCount struct {
+	Value string `json:"value" db:"value"`
+	Count int    `json:"count" db:"count"`
+}
+
+// JobSearchFacets holds the counts per value of each search filter. Each facet is counted with
+// every other filter applied but not its own, so selecting a value does not hide its siblings.
+type JobSearchFacets struct {
+	EmploymentTypes []FacetCount `json:"employment_types"`
+	Remote          []FacetCount `json:"remote"`
+	Locations       []FacetCount `json:"locations"`
+	SalaryRanges    []FacetCount `json:"salary_ranges"`
+	Industries      []FacetCount `json:"industries"`
+	CompanySizes    []FacetCount `json:"company_sizes"`
+}
+
+// SalaryRange is a salary facet bucket. Max is exclusive and nil for the open-ended top bucket.
+type SalaryRange struct {
+	Min int
+	Max *int
+}
+
+// Label returns the facet value of the bucket, e.g. "5000000-10000000" or "50000000+"
+func (s SalaryRange) Label() string {
+	if s.Max == nil {
+		return strconv.Itoa(s.Min) + "+"
+	}
+	return strconv.Itoa(s.Min) + "-" + strconv.Itoa(*s.Max)
+}
+
+// SalaryRanges lists the salary facet buckets, matched against the top of a posting's salary range
+var SalaryRanges = []SalaryRange{
+	{Min: 0, Max: intPtr(5_000_000)},
+	{Min: 5_000_000, Max: intPtr(10_000_000)},
+	{Min: 10_000_000, Max: intPtr(20_000_000)},
+	{Min: 20_000_000, Max: intPtr(50_000_000)},
+	{Min: 50_000_000},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const applicationColumns = `a.id, a.job_id, a.job_seeker_id, a.stage, a.cover_letter, a.knocked_out, a.stage_changed_at, a.created_at, a.updated_at`
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// GetJobSeekerIDByUserID resolves the job seeker profile of a job seeker user
+func (r *JobRepository) GetJobSeekerIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	const query = `SELECT id FROM job_seekers WHERE user_id = $1`
+
+	var jobSeekerID uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&jobSeekerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrJobSeekerNotFound
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to get job seeker by user ID")
+		return uuid.Nil, err
+	}
+
+	return jobSeekerID, nil
+}
+
+// CreateApplication inserts a new application together with its screening answers and first stage
+// history entry. The posting is locked while evaluate checks the answers against its current screening,
+// so the screening cannot change and the posting cannot close in between. When evaluate returns a
+// rejection the application is moved straight on to rejected.
+func (r *JobRepository) CreateApplication(ctx context.Context, app *entity.JobApplication, changedBy uuid.UUID, evaluate func(screening *entity.Screening) ([]entity.ApplicationAnswer, *entity.KnockoutRejection, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	screening, err := r.lockScreeningTx(ctx, tx, app.JobID)
+	if err != nil {
+		return err
+	}
+
+	answers, rejection, err := evaluate(screening)
+	if err != nil {
+		return err
+	}
+
+	applicationQuery := `
+		INSERT INTO job_applications (id, job_id, job_seeker_id, stage, cover_letter)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING stage_changed_at, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, applicationQuery,
+		app.ID,
+		app.JobID,
+		app.JobSeekerID,
+		app.Stage,
+		app.CoverLetter,
+	).Scan(&app.StageChangedAt, &app.CreatedAt, &app.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrAlreadyApplied
+		}
+		r.log.WithError(err).WithField("job_id", app.JobID).Error("Failed to create job application")
+		return err
+	}
+
+	if err := insertStageChange(ctx, tx, app.ID, nil, app.Stage, &changedBy, nil); err != nil {
+		r.log.WithError(err).WithField("application_id", app.ID).Error("Failed to record application stage history")
+		return err
+	}
+
+	const answerQuery = `
+		INSERT INTO job_application_answers (application_id, question_id, answer, knocked_out)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, answer := range answers {
+		if _, err := tx.ExecContext(ctx, answerQuery, app.ID, answer.QuestionID, answer.Answer, answer.KnockedOut); err != nil {
+			r.log.WithError(err).WithField("application_id", app.ID).Error("Failed to save screening answer")
+			return err
+		}
+	}
+
+	if rejection != nil {
+		const rejectQuery = `
+			UPDATE job_applications
+			SET stage = $1, knocked_out = TRUE, rejection_email_due_at = $2, stage_changed_at = NOW()
+			WHERE id = $3
+			RETURNING stage_changed_at, updated_at
+		`
+		err := tx.QueryRowContext(ctx, rejectQuery, entity.StageRejected, rejection.EmailDueAt, app.ID).
+			Scan(&app.StageChangedAt, &app.UpdatedAt)
+		if err != nil {
+			r.log.WithError(err).WithField("application_id", app.ID).Error("Failed to reject knocked out application")
+			return err
+		}
+
+		// Nobody changed the stage by hand, so the history entry has no author
+		from := app.Stage
+		if err := insertStageChange(ctx, tx, app.ID, &from, entity.StageRejected, nil, &rejection.Note); err != nil {
+			r.log.WithError(err).WithField("application_id", app.ID).Error("Failed to record application stage history")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	if rejection != nil {
+		app.Stage = entity.StageRejected
+		app.KnockedOut = true
+	}
+
+	return nil
+}
+
+// GetApplicationByID retrieves an application by its ID
+func (r *JobRepository) GetApplicationByID(ctx context.Context, id uuid.UUID) (*entity.JobApplication, error) {
+	query := `SELECT ` + applicationColumns + ` FROM job_applications a WHERE a.id = $1`
+
+	app := &entity.JobApplication{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&app.ID,
+		&app.JobID,
+		&app.JobSeekerID,
+		&app.Stage,
+		&app.CoverLetter,
+		&app.KnockedOut,
+		&app.StageChangedAt,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApplicationNotFound
+		}
+		r.log.WithError(err).WithField("application_id", id).Error("Failed to get job application by ID")
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// UpdateApplicationStage moves an application from one stage to another and records the change.
+// The update only applies while the application is still in the from stage, so concurrent moves
+// cannot skip the pipeline.
+func (r *JobRepository) UpdateApplicationStage(ctx context.Context, id uuid.UUID, from, to entity.ApplicationStage, changedBy uuid.UUID, note *string) (*entity.JobApplication, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	query := `
+		UPDATE job_applications a
+		SET stage = $1, stage_changed_at = NOW()
+		WHERE a.id = $2 AND a.stage = $3
+		RETURNING ` + applicationColumns
+
+	app := &entity.JobApplication{}
+	err = tx.QueryRowContext(ctx, query, to, id, from).Scan(
+		&app.ID,
+		&app.JobID,
+		&app.JobSeekerID,
+		&app.Stage,
+		&app.CoverLetter,
+		&app.KnockedOut,
+		&app.StageChangedAt,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidStageTransition
+		}
+		r.log.WithError(err).WithField("application_id", id).Error("Failed to update job application stage")
+		return nil, err
+	}
+
+	if err := insertStageChange(ctx, tx, id, &from, to, &changedBy, note); err != nil {
+		r.log.WithError(err).WithField("application_id", id).Error("Failed to record application stage history")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// ListApplicationsByJob retrieves a paginated list of applications for a posting with applicant details
+func (r *JobRepository) ListApplicationsByJob(ctx context.Context, jobID uuid.UUID, stage *entity.ApplicationStage, paging pagination.Pagination) ([]entity.ApplicationWithApplicant, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM job_applications a WHERE a.job_id = $1 AND ($2::varchar IS NULL OR a.stage = $2)`
+	if err := r.db.QueryRowContext(ctx, countQuery, jobID, stage).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to count job applications")
+		return nil, 0, err
+	}
+
+	apps := []entity.ApplicationWithApplicant{}
+	query := `SELECT ` + applicationColumns + `, js.first_name, js.last_name, u.email
+              FROM job_applications a
+              JOIN job_seekers js ON js.id = a.job_seeker_id
+              JOIN users u ON u.id = js.user_id
+              WHERE a.job_id = $1 AND ($2::varchar IS NULL OR a.stage = $2)
+              ORDER BY a.created_at DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &apps, query, jobID, stage); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to list job applications")
+		return nil, 0, err
+	}
+
+	return apps, total, nil
+}
+
+// ListApplicationsByJobSeeker retrieves a paginated list of a job seeker's applications with posting details
+func (r *JobRepository) ListApplicationsByJobSeeker(ctx context.Context, jobSeekerID uuid.UUID, paging pagination.Pagination) ([]entity.ApplicationWithJob, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM job_applications WHERE job_seeker_id = $1`
+	if err := r.db.QueryRowContext(ctx, countQuery, jobSeekerID).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to count job seeker applications")
+		return nil, 0, err
+	}
+
+	apps := []entity.ApplicationWithJob{}
+	query := `SELECT ` + applicationColumns + `, j.title AS job_title, j.status AS job_status, j.company_id
+              FROM job_applications a
+              JOIN job_postings j ON j.id = a.job_id
+              WHERE a.job_seeker_id = $1
+              ORDER BY a.created_at DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &apps, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker applications")
+		return nil, 0, err
+	}
+
+	return apps, total, nil
+}
+
+// GetApplicationHistory retrieves every stage change of an application, oldest first
+func (r *JobRepository) GetApplicationHistory(ctx context.Context, applicationID uuid.UUID) ([]entity.ApplicationStageChange, error) {
+	history := []entity.ApplicationStageChange{}
+	query := `SELECT id, application_id, from_stage, to_stage, changed_by, note, created_at
+              FROM job_application_stage_history
+              WHERE application_id = $1
+              ORDER BY created_at ASC`
+	if err := r.db.SelectContext(ctx, &history, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to get application history")
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func insertStageChange(ctx context.Context, tx *sql.Tx, applicationID uuid.UUID, from *entity.ApplicationStage, to entity.ApplicationStage, changedBy *uuid.UUID, note *string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO job_application_stage_history (id, application_id, from_stage, to_stage, changed_by, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, query, id, applicationID, from, to, changedBy, note)
+	return err
+}
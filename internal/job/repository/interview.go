@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/ics"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"strings"
+	"time"
+)
+
+const interviewColumns = `i.id, i.application_id, i.status, i.duration_minutes, i.timezone, i.location, i.notes,
+	i.starts_at, i.sequence, i.proposed_by, i.cancelled_by, i.cancel_reason, i.cancelled_at, i.created_at, i.updated_at`
+
+// CreateInterview inserts a proposed interview together with its slots
+func (r *JobRepository) CreateInterview(ctx context.Context, interview *entity.Interview) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const query = `
+		INSERT INTO interviews (id, application_id, status, duration_minutes, timezone, location, notes, proposed_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING sequence, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, query,
+		interview.ID,
+		interview.ApplicationID,
+		interview.Status,
+		interview.DurationMinutes,
+		interview.Timezone,
+		interview.Location,
+		interview.Notes,
+		interview.ProposedBy,
+	).Scan(&interview.Sequence, &interview.CreatedAt, &interview.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("application_id", interview.ApplicationID).Error("Failed to create interview")
+		return err
+	}
+
+	const slotQuery = `INSERT INTO interview_slots (id, interview_id, starts_at) VALUES ($1, $2, $3)`
+	for _, slot := range interview.Slots {
+		if _, err := tx.ExecContext(ctx, slotQuery, slot.ID, interview.ID, slot.StartsAt); err != nil {
+			r.log.WithError(err).WithField("interview_id", interview.ID).Error("Failed to create interview slot")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// GetInterview retrieves an interview with its proposed slots
+func (r *JobRepository) GetInterview(ctx context.Context, id uuid.UUID) (*entity.Interview, error) {
+	interviews := []entity.Interview{}
+	query := `SELECT ` + interviewColumns + ` FROM interviews i WHERE i.id = $1`
+	if err := r.db.SelectContext(ctx, &interviews, query, id); err != nil {
+		r.log.WithError(err).WithField("interview_id", id).Error("Failed to get interview")
+		return nil, err
+	}
+
+	if len(interviews) == 0 {
+		return nil, ErrInterviewNotFound
+	}
+
+	if err := r.loadInterviewSlots(ctx, interviews); err != nil {
+		return nil, err
+	}
+
+	return &interviews[0], nil
+}
+
+// ListInterviewsByApplication retrieves the interviews of an application with their proposed slots,
+// most recent first
+func (r *JobRepository) ListInterviewsByApplication(ctx context.Context, applicationID uuid.UUID) ([]entity.Interview, error) {
+	interviews := []entity.Interview{}
+	query := `SELECT ` + interviewColumns + ` FROM interviews i WHERE i.application_id = $1 ORDER BY i.created_at DESC`
+	if err := r.db.SelectContext(ctx, &interviews, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list interviews")
+		return nil, err
+	}
+
+	if err := r.loadInterviewSlots(ctx, interviews); err != nil {
+		return nil, err
+	}
+
+	return interviews, nil
+}
+
+// loadInterviewSlots fills the slots of every interview, earliest first
+func (r *JobRepository) loadInterviewSlots(ctx context.Context, interviews []entity.Interview) error {
+	if len(interviews) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(interviews))
+	byID := make(map[uuid.UUID]*entity.Interview, len(interviews))
+	for i := range interviews {
+		interviews[i].Slots = []entity.InterviewSlot{}
+		ids = append(ids, interviews[i].ID.String())
+		byID[interviews[i].ID] = &interviews[i]
+	}
+
+	slots := []entity.InterviewSlot{}
+	const query = `SELECT id, interview_id, starts_at FROM interview_slots WHERE interview_id = ANY($1::uuid[]) ORDER BY starts_at`
+	if err := r.db.SelectContext(ctx, &slots, query, pq.Array(ids)); err != nil {
+		r.log.WithError(err).Error("Failed to list interview slots")
+		return err
+	}
+
+	for _, slot := range slots {
+		byID[slot.InterviewID].Slots = append(byID[slot.InterviewID].Slots, slot)
+	}
+
+	return nil
+}
+
+// ScheduleInterview sets the start of a proposed interview to one of its slots
+func (r *JobRepository) ScheduleInterview(ctx context.Context, id, slotID uuid.UUID) (*entity.Interview, error) {
+	query := `
+		UPDATE interviews i
+		SET status = 'scheduled', starts_at = s.starts_at
+		FROM interview_slots s
+		WHERE i.id = $1 AND s.id = $2 AND s.interview_id = i.id AND i.status = 'proposed'
+		RETURNING ` + interviewColumns
+
+	return r.updateInterview(ctx, id, query, id, slotID)
+}
+
+// RescheduleInterview moves a scheduled interview to a new start, duration, timezone and location and
+// bumps the revision of its calendar invite
+func (r *JobRepository) RescheduleInterview(ctx context.Context, interview *entity.Interview) (*entity.Interview, error) {
+	query := `
+		UPDATE interviews i
+		SET starts_at = $1, duration_minutes = $2, timezone = $3, location = $4, sequence = i.sequence + 1
+		WHERE i.id = $5 AND i.status = 'scheduled'
+		RETURNING ` + interviewColumns
+
+	return r.updateInterview(ctx, interview.ID, query,
+		interview.StartsAt,
+		interview.DurationMinutes,
+		interview.Timezone,
+		interview.Location,
+		interview.ID,
+	)
+}
+
+// CancelInterview cancels an interview that is not cancelled yet and bumps the revision of its
+// calendar invite
+func (r *JobRepository) CancelInterview(ctx context.Context, id, cancelledBy uuid.UUID, reason *string) (*entity.Interview, error) {
+	query := `
+		UPDATE interviews i
+		SET status = 'cancelled', cancelled_by = $1, cancel_reason = $2, cancelled_at = NOW(), sequence = i.sequence + 1
+		WHERE i.id = $3 AND i.status <> 'cancelled'
+		RETURNING ` + interviewColumns
+
+	return r.updateInterview(ctx, id, query, cancelledBy, reason, id)
+}
+
+// updateInterview runs an UPDATE returning the interview columns. No updated row means the interview
+// was not in the state the update requires.
+func (r *JobRepository) updateInterview(ctx context.Context, id uuid.UUID, query string, args ...any) (*entity.Interview, error) {
+	interviews := []entity.Interview{}
+	if err := r.db.SelectContext(ctx, &interviews, query, args...); err != nil {
+		r.log.WithError(err).WithField("interview_id", id).Error("Failed to update interview")
+		return nil, err
+	}
+
+	if len(interviews) == 0 {
+		return nil, ErrInvalidInterviewStatus
+	}
+
+	if err := r.loadInterviewSlots(ctx, interviews); err != nil {
+		return nil, err
+	}
+
+	return &interviews[0], nil
+}
+
+// GetInterviewParticipants retrieves the candidate and company details of an application, used to
+// address interview emails
+func (r *JobRepository) GetInterviewParticipants(ctx context.Context, applicationID uuid.UUID) (*entity.InterviewParticipants, error) {
+	const query = `
+		SELECT a.id AS application_id, j.title AS job_title, c.name AS company_name, c.email AS company_email, c.user_id AS company_user_id,
+		       u.id AS candidate_user_id, u.email AS candidate_email, js.first_name AS candidate_first_name, js.last_name AS candidate_last_name
+		FROM job_applications a
+		JOIN job_postings j ON j.id = a.job_id
+		JOIN companies c ON c.id = j.company_id
+		JOIN job_seekers js ON js.id = a.job_seeker_id
+		JOIN users u ON u.id = js.user_id
+		WHERE a.id = $1
+	`
+
+	participants := []entity.InterviewParticipants{}
+	if err := r.db.SelectContext(ctx, &participants, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to get interview participants")
+		return nil, err
+	}
+
+	if len(participants) == 0 {
+		return nil, ErrApplicationNotFound
+	}
+
+	return &participants[0], nil
+}
+
+// SendInterviewEmail tells the candidate about a change to an interview, unless emailCandidate is
+// false, and the company too once a time is agreed, unless emailCompany is false. Scheduled and
+// rescheduled emails carry a calendar invite; cancelling a scheduled interview carries the
+// cancellation of that invite.
+func (r *JobRepository) SendInterviewEmail(ctx context.Context, kind string, interview *entity.Interview, participants *entity.InterviewParticipants, emailCandidate, emailCompany bool) error {
+	loc := interview.Zone()
+	candidateName := strings.TrimSpace(participants.CandidateFirstName + " " + participants.CandidateLastName)
+
+	var times []string
+	if interview.StartsAt != nil {
+		times = append(times, formatInterviewTime(*interview.StartsAt, loc))
+	} else if kind == templates.InterviewProposed {
+		for _, slot := range interview.Slots {
+			times = append(times, formatInterviewTime(slot.StartsAt, loc))
+		}
+	}
+
+	data := templates.InterviewData{
+		Kind:          kind,
+		RecipientName: participants.CandidateFirstName,
+		CandidateName: candidateName,
+		JobTitle:      participants.JobTitle,
+		CompanyName:   participants.CompanyName,
+		Times:         times,
+		Timezone:      interview.Timezone,
+		Duration:      formatInterviewDuration(interview.DurationMinutes),
+		ActionURL:     strings.TrimSuffix(r.frontendURL, "/") + "/applications/" + interview.ApplicationID.String(),
+		AppName:       "Career Link",
+		SupportEmail:  "support@careerlink.com",
+	}
+	if interview.Location != nil {
+		data.Location = *interview.Location
+	}
+	if interview.Notes != nil {
+		data.Notes = *interview.Notes
+	}
+	if interview.CancelReason != nil {
+		data.CancelReason = *interview.CancelReason
+	}
+
+	var subject string
+	switch kind {
+	case templates.InterviewProposed:
+		subject = "Pick a time for your interview at " + participants.CompanyName
+	case templates.InterviewScheduled:
+		subject = "Interview scheduled: " + participants.JobTitle
+	case templates.InterviewRescheduled:
+		subject = "Interview rescheduled: " + participants.JobTitle
+	default:
+		subject = "Interview cancelled: " + participants.JobTitle
+	}
+
+	var attachments []mail.Attachment
+	if interview.StartsAt != nil {
+		method := ics.MethodRequest
+		if interview.Status == entity.InterviewCancelled {
+			method = ics.MethodCancel
+		}
+		attachments = append(attachments, mail.Attachment{
+			Filename:    "interview.ics",
+			ContentType: ics.ContentType(method),
+			Content:     ics.Invite(method, interviewEvent(interview, participants, candidateName)),
+		})
+	}
+
+	var errs []error
+	if emailCandidate {
+		candidateBody, err := templates.GetInterviewHTML(data)
+		if err != nil {
+			return err
+		}
+
+		err = r.mail.SendEmail(ctx, mail.EmailMessage{
+			To:          participants.CandidateEmail,
+			Subject:     subject,
+			Body:        candidateBody,
+			IsHTML:      true,
+			Attachments: attachments,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("email candidate: %w", err))
+		}
+	}
+
+	// The company proposed the slots itself, so it only hears back once a time is agreed
+	if kind == templates.InterviewProposed || !emailCompany {
+		return errors.Join(errs...)
+	}
+
+	data.ForCompany = true
+	data.RecipientName = participants.CompanyName
+	companyBody, err := templates.GetInterviewHTML(data)
+	if err != nil {
+		return err
+	}
+
+	err = r.mail.SendEmail(ctx, mail.EmailMessage{
+		To:          participants.CompanyEmail,
+		Subject:     subject + " with " + candidateName,
+		Body:        companyBody,
+		IsHTML:      true,
+		Attachments: attachments,
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("email company: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// interviewEvent builds the calendar event of a scheduled interview. The company organizes it and the
+// candidate attends.
+func interviewEvent(interview *entity.Interview, participants *entity.InterviewParticipants, candidateName string) ics.Event {
+	event := ics.Event{
+		UID:      interview.ID.String() + "@careerlink.com",
+		Sequence: interview.Sequence,
+		Start:    *interview.StartsAt,
+		End:      interview.StartsAt.Add(interview.Duration()),
+		Summary:  "Interview: " + participants.JobTitle + " at " + participants.CompanyName,
+		Organizer: ics.Person{
+			Name:  participants.CompanyName,
+			Email: participants.CompanyEmail,
+		},
+		Attendee: ics.Person{
+			Name:  candidateName,
+			Email: participants.CandidateEmail,
+		},
+	}
+	if interview.Location != nil {
+		event.Location = *interview.Location
+	}
+	if interview.Notes != nil {
+		event.Description = *interview.Notes
+	}
+
+	return event
+}
+
+// formatInterviewTime formats t in the interview zone with its offset at that time, e.g.
+// "Mon, 2 Jan 2006 15:04 (UTC+07:00)"
+func formatInterviewTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("Mon, 2 Jan 2006 15:04 (UTC-07:00)")
+}
+
+// formatInterviewDuration formats a duration in minutes, e.g. "1 hour 30 minutes"
+func formatInterviewDuration(minutes int) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return plural(minutes, "minute")
+	case minutes == 0:
+		return plural(hours, "hour")
+	default:
+		return plural(hours, "hour") + " " + plural(minutes, "minute")
+	}
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+)
+
+const jobColumns = `id, company_id, title, description, employment_type, location, is_remote, salary_min, salary_max,
+	required_skills, status, published_at, closed_at, application_deadline, created_at, updated_at`
+
+// scanJob scans a single job posting row selected with jobColumns
+func scanJob(row interface{ Scan(dest ...any) error }, job *entity.JobPosting) error {
+	return row.Scan(
+		&job.ID,
+		&job.CompanyID,
+		&job.Title,
+		&job.Description,
+		&job.EmploymentType,
+		&job.Location,
+		&job.IsRemote,
+		&job.SalaryMin,
+		&job.SalaryMax,
+		&job.RequiredSkills,
+		&job.Status,
+		&job.PublishedAt,
+		&job.ClosedAt,
+		&job.ApplicationDeadline,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+}
+
+// GetCompanyIDByUserID resolves the company a company user is a member of
+func (r *JobRepository) GetCompanyIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	const query = `SELECT company_id FROM company_members WHERE user_id = $1`
+
+	var companyID uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&companyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrCompanyNotFound
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to get company by user ID")
+		return uuid.Nil, err
+	}
+
+	return companyID, nil
+}
+
+// CreateJob inserts a new job posting
+func (r *JobRepository) CreateJob(ctx context.Context, job *entity.JobPosting) error {
+	const query = `
+		INSERT INTO job_postings (id, company_id, title, description, employment_type, location, is_remote,
+			salary_min, salary_max, required_skills, status, application_deadline)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		job.ID,
+		job.CompanyID,
+		job.Title,
+		job.Description,
+		job.EmploymentType,
+		job.Location,
+		job.IsRemote,
+		job.SalaryMin,
+		job.SalaryMax,
+		job.RequiredSkills,
+		job.Status,
+		job.ApplicationDeadline,
+	).Scan(&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", job.CompanyID).Error("Failed to create job posting")
+		return err
+	}
+
+	return nil
+}
+
+// GetJobByID retrieves a job posting by its ID
+func (r *JobRepository) GetJobByID(ctx context.Context, id uuid.UUID) (*entity.JobPosting, error) {
+	query := `SELECT ` + jobColumns + ` FROM job_postings WHERE id = $1`
+
+	job := &entity.JobPosting{}
+	if err := scanJob(r.db.QueryRowContext(ctx, query, id), job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", id).Error("Failed to get job posting by ID")
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// UpdateJob updates the editable fields of a job posting
+func (r *JobRepository) UpdateJob(ctx context.Context, job *entity.JobPosting) error {
+	const query = `
+		UPDATE job_postings
+		SET title = $1, description = $2, employment_type = $3, location = $4, is_remote = $5,
+			salary_min = $6, salary_max = $7, required_skills = $8, application_deadline = $9
+		WHERE id = $10
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		job.Title,
+		job.Description,
+		job.EmploymentType,
+		job.Location,
+		job.IsRemote,
+		job.SalaryMin,
+		job.SalaryMax,
+		job.RequiredSkills,
+		job.ApplicationDeadline,
+		job.ID,
+	).Scan(&job.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", job.ID).Error("Failed to update job posting")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobStatus moves a job posting to a new status and stamps the matching timestamp
+func (r *JobRepository) UpdateJobStatus(ctx context.Context, id uuid.UUID, status entity.JobStatus) (*entity.JobPosting, error) {
+	query := `
+		UPDATE job_postings
+		SET status = $1,
+			published_at = CASE WHEN $1 = 'published' THEN NOW() ELSE published_at END,
+			closed_at = CASE WHEN $1 = 'closed' THEN NOW() ELSE closed_at END
+		WHERE id = $2
+		RETURNING ` + jobColumns
+
+	job := &entity.JobPosting{}
+	if err := scanJob(r.db.QueryRowContext(ctx, query, status, id), job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", id).Error("Failed to update job posting status")
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// PublishJob publishes a job posting of a company. The company row is locked while its published
+// postings are counted, so concurrent publishes cannot both slip under unverifiedLimit, the number of
+// postings a company that is not verified yet may keep published.
+func (r *JobRepository) PublishJob(ctx context.Context, companyID, jobID uuid.UUID, unverifiedLimit int) (*entity.JobPosting, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	var verified bool
+	if err := tx.QueryRowContext(ctx, `SELECT is_verified FROM companies WHERE id = $1 FOR UPDATE`, companyID).Scan(&verified); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCompanyNotFound
+		}
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to lock company")
+		return nil, err
+	}
+
+	current := &entity.JobPosting{}
+	const statusQuery = `SELECT status FROM job_postings WHERE id = $1 AND company_id = $2 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, statusQuery, jobID, companyID).Scan(&current.Status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to lock job posting")
+		return nil, err
+	}
+
+	if !current.CanTransitionTo(entity.JobStatusPublished) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	if !verified {
+		const countQuery = `SELECT COUNT(*) FROM job_postings WHERE company_id = $1 AND status = 'published'`
+		var published int
+		if err := tx.QueryRowContext(ctx, countQuery, companyID).Scan(&published); err != nil {
+			r.log.WithError(err).WithField("company_id", companyID).Error("Failed to count published job postings")
+			return nil, err
+		}
+		if published >= unverifiedLimit {
+			return nil, ErrPublishLimitReached
+		}
+	}
+
+	query := `
+		UPDATE job_postings
+		SET status = 'published', published_at = NOW()
+		WHERE id = $1
+		RETURNING ` + jobColumns
+
+	job := &entity.JobPosting{}
+	if err := scanJob(tx.QueryRowContext(ctx, query, jobID), job); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to publish job posting")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// DeleteJob permanently removes a draft job posting that never received an application. Deleting
+// cascades to everything attached to the posting, so anything else must be closed instead.
+func (r *JobRepository) DeleteJob(ctx context.Context, id uuid.UUID) error {
+	const query = `
+		DELETE FROM job_postings j
+		WHERE j.id = $1 AND j.status = $2
+		  AND NOT EXISTS (SELECT 1 FROM job_applications a WHERE a.job_id = j.id)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, entity.JobStatusDraft)
+	if err != nil {
+		r.log.WithError(err).WithField("job_id", id).Error("Failed to delete job posting")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM job_postings WHERE id = $1)`, id).Scan(&exists); err != nil {
+			r.log.WithError(err).WithField("job_id", id).Error("Failed to check job posting")
+			return err
+		}
+		if exists {
+			return ErrJobNotDeletable
+		}
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// ListJobsByCompany retrieves a paginated list of every posting owned by a company
+func (r *JobRepository) ListJobsByCompany(ctx context.Context, companyID uuid.UUID, paging pagination.Pagination) ([]entity.JobPosting, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM job_postings WHERE company_id = $1`
+	if err := r.db.QueryRowContext(ctx, countQuery, companyID).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to count company job postings")
+		return nil, 0, err
+	}
+
+	jobs := []entity.JobPosting{}
+	query := `SELECT ` + jobColumns + ` FROM job_postings WHERE company_id = $1
+              ORDER BY created_at DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &jobs, query, companyID); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to list company job postings")
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// ListPublishedJobs retrieves a paginated list of published postings still taking applications, newest first
+func (r *JobRepository) ListPublishedJobs(ctx context.Context, paging pagination.Pagination) ([]entity.JobPosting, int, error) {
+	const openFilter = `status = 'published' AND (application_deadline IS NULL OR application_deadline > NOW())`
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM job_postings WHERE ` + openFilter
+	if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+		r.log.WithError(err).Error("Failed to count published job postings")
+		return nil, 0, err
+	}
+
+	jobs := []entity.JobPosting{}
+	query := `SELECT ` + jobColumns + ` FROM job_postings WHERE ` + openFilter + `
+              ORDER BY published_at DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &jobs, query); err != nil {
+		r.log.WithError(err).Error("Failed to list published job postings")
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
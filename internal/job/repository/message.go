@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"io"
+	"time"
+)
+
+// messageAttachmentURLExpiry is how long a download URL of a message attachment stays valid
+const messageAttachmentURLExpiry = 15 * time.Minute
+
+// CreateMessage inserts a message with its attachments. Sending a message also marks the thread read
+// for the sender.
+func (r *JobRepository) CreateMessage(ctx context.Context, msg *entity.ApplicationMessage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const query = `
+		INSERT INTO application_messages (id, application_id, sender_id, sender_role, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, (SELECT username FROM users WHERE id = $3)
+	`
+	err = tx.QueryRowContext(ctx, query, msg.ID, msg.ApplicationID, msg.SenderID, msg.SenderRole, msg.Body).
+		Scan(&msg.CreatedAt, &msg.SenderName)
+	if err != nil {
+		r.log.WithError(err).WithField("application_id", msg.ApplicationID).Error("Failed to create message")
+		return err
+	}
+
+	const attachmentQuery = `
+		INSERT INTO application_message_attachments (id, message_id, file_name, object_name, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	for i := range msg.Attachments {
+		a := &msg.Attachments[i]
+		err := tx.QueryRowContext(ctx, attachmentQuery, a.ID, msg.ID, a.FileName, a.ObjectName, a.ContentType, a.SizeBytes).
+			Scan(&a.CreatedAt)
+		if err != nil {
+			r.log.WithError(err).WithField("message_id", msg.ID).Error("Failed to create message attachment")
+			return err
+		}
+	}
+
+	if msg.SenderID != nil {
+		if err := upsertThreadRead(ctx, tx, msg.ApplicationID, *msg.SenderID, msg.CreatedAt); err != nil {
+			r.log.WithError(err).WithField("application_id", msg.ApplicationID).Error("Failed to update thread read state")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ListMessages retrieves a paginated list of the messages of an application thread, newest first, with
+// their attachments
+func (r *JobRepository) ListMessages(ctx context.Context, applicationID uuid.UUID, paging pagination.Pagination) ([]entity.ApplicationMessage, int, error) {
+	var total int
+	const countQuery = `SELECT COUNT(*) FROM application_messages WHERE application_id = $1`
+	if err := r.db.QueryRowContext(ctx, countQuery, applicationID).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to count messages")
+		return nil, 0, err
+	}
+
+	messages := []entity.ApplicationMessage{}
+	query := `
+		SELECT m.id, m.application_id, m.sender_id, m.sender_role, COALESCE(u.username, '') AS sender_name,
+		       m.body, m.read_at, m.created_at
+		FROM application_messages m
+		LEFT JOIN users u ON u.id = m.sender_id
+		WHERE m.application_id = $1
+		ORDER BY m.created_at DESC, m.id DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &messages, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list messages")
+		return nil, 0, err
+	}
+
+	if len(messages) == 0 {
+		return messages, total, nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	byID := make(map[uuid.UUID]*entity.ApplicationMessage, len(messages))
+	for i := range messages {
+		messages[i].Attachments = []entity.MessageAttachment{}
+		ids = append(ids, messages[i].ID.String())
+		byID[messages[i].ID] = &messages[i]
+	}
+
+	attachments := []entity.MessageAttachment{}
+	const attachmentQuery = `
+		SELECT id, message_id, file_name, object_name, content_type, size_bytes, created_at
+		FROM application_message_attachments
+		WHERE message_id = ANY($1::uuid[])
+		ORDER BY created_at, id`
+	if err := r.db.SelectContext(ctx, &attachments, attachmentQuery, pq.Array(ids)); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list message attachments")
+		return nil, 0, err
+	}
+
+	for _, a := range attachments {
+		byID[a.MessageID].Attachments = append(byID[a.MessageID].Attachments, a)
+	}
+
+	return messages, total, nil
+}
+
+// MarkThreadRead records that a user read an application thread up to now, and sets the read receipt
+// of the messages the other side sent until then
+func (r *JobRepository) MarkThreadRead(ctx context.Context, applicationID, userID uuid.UUID, side entity.ThreadSide, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := upsertThreadRead(ctx, tx, applicationID, userID, now); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to update thread read state")
+		return err
+	}
+
+	const query = `
+		UPDATE application_messages
+		SET read_at = $1
+		WHERE application_id = $2 AND sender_role <> $3 AND read_at IS NULL AND created_at <= $1
+	`
+	if _, err := tx.ExecContext(ctx, query, now, applicationID, side); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to mark messages read")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// upsertThreadRead moves the read cursor of a user in a thread forward to readAt
+func upsertThreadRead(ctx context.Context, tx *sql.Tx, applicationID, userID uuid.UUID, readAt time.Time) error {
+	const query = `
+		INSERT INTO application_thread_reads (application_id, user_id, last_read_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (application_id, user_id)
+		DO UPDATE SET last_read_at = GREATEST(application_thread_reads.last_read_at, EXCLUDED.last_read_at)
+	`
+	_, err := tx.ExecContext(ctx, query, applicationID, userID, readAt)
+	return err
+}
+
+// ListUnreadThreadsForJobSeeker retrieves the threads of a job seeker's applications with company
+// messages the user has not read, most recent first
+func (r *JobRepository) ListUnreadThreadsForJobSeeker(ctx context.Context, jobSeekerID, userID uuid.UUID) ([]entity.UnreadThread, error) {
+	return r.listUnreadThreads(ctx, `a.job_seeker_id = $1`, entity.SideJobSeeker, jobSeekerID, userID)
+}
+
+// ListUnreadThreadsForCompany retrieves the threads of a company's applications with applicant messages
+// the user has not read, most recent first. Every teammate has their own unread state.
+func (r *JobRepository) ListUnreadThreadsForCompany(ctx context.Context, companyID, userID uuid.UUID) ([]entity.UnreadThread, error) {
+	return r.listUnreadThreads(ctx, `j.company_id = $1`, entity.SideCompany, companyID, userID)
+}
+
+func (r *JobRepository) listUnreadThreads(ctx context.Context, ownerFilter string, side entity.ThreadSide, ownerID, userID uuid.UUID) ([]entity.UnreadThread, error) {
+	query := `
+		SELECT a.id AS application_id, j.title AS job_title, COUNT(*) AS unread, MAX(m.created_at) AS last_message_at
+		FROM application_messages m
+		JOIN job_applications a ON a.id = m.application_id
+		JOIN job_postings j ON j.id = a.job_id
+		LEFT JOIN application_thread_reads tr ON tr.application_id = m.application_id AND tr.user_id = $2
+		WHERE ` + ownerFilter + `
+		  AND m.sender_role <> $3
+		  AND (tr.last_read_at IS NULL OR m.created_at > tr.last_read_at)
+		GROUP BY a.id, j.title
+		ORDER BY last_message_at DESC`
+
+	threads := []entity.UnreadThread{}
+	if err := r.db.SelectContext(ctx, &threads, query, ownerID, userID, side); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to list unread threads")
+		return nil, err
+	}
+
+	return threads, nil
+}
+
+// UploadMessageAttachmentFile uploads a message attachment to storage
+func (r *JobRepository) UploadMessageAttachmentFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error {
+	if err := r.minio.UploadFile(ctx, objectName, fileContent, fileSize, contentType); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to upload message attachment")
+		return fmt.Errorf("failed to upload message attachment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMessageAttachmentFile removes a message attachment from storage
+func (r *JobRepository) DeleteMessageAttachmentFile(ctx context.Context, objectName string) error {
+	if err := r.minio.DeleteFile(ctx, objectName); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to delete message attachment")
+		return fmt.Errorf("failed to delete message attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageAttachmentURL generates a short-lived download URL for a message attachment
+func (r *JobRepository) GetMessageAttachmentURL(ctx context.Context, objectName string) (string, error) {
+	url, err := r.minio.GetFileURL(ctx, objectName, messageAttachmentURLExpiry)
+	if err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to generate URL for message attachment")
+		return "", fmt.Errorf("failed to generate URL for message attachment: %w", err)
+	}
+
+	return url, nil
+}
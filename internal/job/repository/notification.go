@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+)
+
+// GetApplicationAudience resolves the candidate and every member of the company on an application
+func (r *JobRepository) GetApplicationAudience(ctx context.Context, applicationID uuid.UUID) (*entity.ApplicationAudience, error) {
+	const query = `
+		SELECT a.id AS application_id, j.title AS job_title, c.name AS company_name,
+		       TRIM(js.first_name || ' ' || js.last_name) AS candidate_name, js.user_id AS candidate_user_id
+		FROM job_applications a
+		JOIN job_postings j ON j.id = a.job_id
+		JOIN companies c ON c.id = j.company_id
+		JOIN job_seekers js ON js.id = a.job_seeker_id
+		WHERE a.id = $1
+	`
+
+	audiences := []entity.ApplicationAudience{}
+	if err := r.db.SelectContext(ctx, &audiences, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to get application audience")
+		return nil, err
+	}
+
+	if len(audiences) == 0 {
+		return nil, ErrApplicationNotFound
+	}
+	audience := &audiences[0]
+
+	const membersQuery = `
+		SELECT m.user_id
+		FROM company_members m
+		JOIN job_postings j ON j.company_id = m.company_id
+		JOIN job_applications a ON a.job_id = j.id
+		WHERE a.id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, membersQuery, applicationID)
+	if err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list company members")
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to scan company member")
+			return nil, err
+		}
+		audience.CompanyUserIDs = append(audience.CompanyUserIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list company members")
+		return nil, err
+	}
+
+	return audience, nil
+}
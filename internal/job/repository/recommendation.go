@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/cache"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"time"
+)
+
+// recommendationCacheTTL bounds how long a recommendation list is served without being rebuilt
+const recommendationCacheTTL = 6 * time.Hour
+
+// cachedRecommendations is the Redis value of a job seeker's recommendation list
+type cachedRecommendations struct {
+	Version string                  `json:"version"`
+	Jobs    []entity.RecommendedJob `json:"jobs"`
+}
+
+// GetRecommendationProfile loads the skills, preferences and past job titles of a job seeker
+func (r *JobRepository) GetRecommendationProfile(ctx context.Context, jobSeekerID uuid.UUID) (*entity.RecommendationProfile, error) {
+	profile := &entity.RecommendationProfile{}
+
+	const skillsQuery = `SELECT skill_name FROM job_seeker_skills WHERE job_seeker_id = $1`
+	if err := r.db.SelectContext(ctx, &profile.Skills, skillsQuery, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to get job seeker skills")
+		return nil, err
+	}
+
+	const preferencesQuery = `
+		SELECT preferred_job_type, preferred_industry, preferred_location, salary_expectation, remote_preference
+		FROM job_seeker_preferences WHERE job_seeker_id = $1
+	`
+	if err := r.db.SelectContext(ctx, &profile.Preferences, preferencesQuery, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to get job seeker preferences")
+		return nil, err
+	}
+
+	const experiencesQuery = `SELECT job_title FROM job_seeker_experiences WHERE job_seeker_id = $1`
+	if err := r.db.SelectContext(ctx, &profile.ExperienceTitles, experiencesQuery, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to get job seeker experiences")
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// ListRecommendationCandidates retrieves the newest published postings still taking applications that
+// the job seeker has not applied to
+func (r *JobRepository) ListRecommendationCandidates(ctx context.Context, jobSeekerID uuid.UUID, limit int) ([]entity.RecommendedJob, error) {
+	query := `
+		SELECT ` + searchJobColumns + `, c.name AS company_name, c.industry AS company_industry
+		FROM job_postings j
+		JOIN companies c ON c.id = j.company_id
+		WHERE j.status = 'published'
+		  AND (j.application_deadline IS NULL OR j.application_deadline > NOW())
+		  AND NOT EXISTS (SELECT 1 FROM job_applications a WHERE a.job_id = j.id AND a.job_seeker_id = $1)
+		ORDER BY j.published_at DESC
+		LIMIT $2
+	`
+
+	jobs := []entity.RecommendedJob{}
+	if err := r.db.SelectContext(ctx, &jobs, query, jobSeekerID, limit); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list recommendation candidates")
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// GetCachedRecommendations returns the cached recommendations of a job seeker. Lists built before
+// the last posting change are reported as missing. Cache errors are logged and treated as a miss.
+func (r *JobRepository) GetCachedRecommendations(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.RecommendedJob, bool) {
+	raw, err := r.redis.Get(ctx, cache.JobRecommendationsKey(jobSeekerID))
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to get cached recommendations")
+		}
+		return nil, false
+	}
+
+	var cached cachedRecommendations
+	if err := json.Unmarshal([]byte(raw), &cached); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to unmarshal cached recommendations")
+		return nil, false
+	}
+
+	version, err := r.RecommendationsVersion(ctx)
+	if err != nil || cached.Version != version {
+		return nil, false
+	}
+
+	return cached.Jobs, true
+}
+
+// CacheRecommendations stores the recommendations of a job seeker under the posting version that was
+// read before they were built, so a list built while postings changed is never served as current
+func (r *JobRepository) CacheRecommendations(ctx context.Context, jobSeekerID uuid.UUID, version string, jobs []entity.RecommendedJob) error {
+	value, err := json.Marshal(cachedRecommendations{Version: version, Jobs: jobs})
+	if err != nil {
+		r.log.WithError(err).Error("Failed to marshal recommendations")
+		return err
+	}
+
+	key := cache.JobRecommendationsKey(jobSeekerID)
+	if err := r.redis.Set(ctx, key, string(value), recommendationCacheTTL); err != nil {
+		r.log.WithError(err).WithField("key", key).Error("Failed to cache recommendations")
+		return err
+	}
+
+	return nil
+}
+
+// InvalidateJobSeekerRecommendations drops the cached recommendations of one job seeker
+func (r *JobRepository) InvalidateJobSeekerRecommendations(ctx context.Context, jobSeekerID uuid.UUID) error {
+	if err := r.redis.Delete(ctx, cache.JobRecommendationsKey(jobSeekerID)); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to invalidate recommendations")
+		return err
+	}
+	return nil
+}
+
+// InvalidateRecommendations marks every cached recommendation list as stale
+func (r *JobRepository) InvalidateRecommendations(ctx context.Context) error {
+	if err := r.redis.GetClient().Incr(ctx, cache.JobRecommendationsVersionKey).Err(); err != nil {
+		r.log.WithError(err).Error("Failed to invalidate recommendations")
+		return err
+	}
+	return nil
+}
+
+// RecommendationsVersion returns the current posting version, "0" before the first change
+func (r *JobRepository) RecommendationsVersion(ctx context.Context) (string, error) {
+	version, err := r.redis.Get(ctx, cache.JobRecommendationsVersionKey)
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return "0", nil
+		}
+		r.log.WithError(err).Error("Failed to get recommendations version")
+		return "", err
+	}
+	return version, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/database"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/minio"
+	"github.com/agpprastyo/career-link/pkg/redis"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+var (
+	ErrJobNotFound             = errors.New("job posting not found")
+	ErrCompanyNotFound         = errors.New("company not found")
+	ErrInvalidStatusTransition = errors.New("invalid job status transition")
+	ErrJobClosed               = errors.New("job posting is closed")
+	ErrJobNotDeletable         = errors.New("only draft job postings without applications can be deleted")
+	ErrJobSeekerNotFound       = errors.New("job seeker not found")
+	ErrApplicationNotFound     = errors.New("job application not found")
+	ErrAlreadyApplied          = errors.New("already applied to this job")
+	ErrJobNotOpen              = errors.New("job posting is not open for applications")
+	ErrInvalidStageTransition  = errors.New("invalid application stage transition")
+	ErrPublishLimitReached     = errors.New("unverified company reached its published job limit")
+	ErrSavedSearchNotFound     = errors.New("saved search not found")
+	ErrSavedSearchLimitReached = errors.New("job seeker reached the saved search limit")
+	ErrSavedJobNotFound        = errors.New("saved job not found")
+	ErrScreeningLocked         = errors.New("screening questions cannot change after applications were received")
+	ErrInvalidScreeningAnswer  = errors.New("invalid screening answer")
+	ErrInterviewNotFound       = errors.New("interview not found")
+	ErrInterviewSlotNotFound   = errors.New("interview slot not found")
+	ErrInterviewSlotPassed     = errors.New("interview slot has already passed")
+	ErrNotInInterviewStage     = errors.New("application is not in the interview stage")
+	ErrInvalidInterviewStatus  = errors.New("interview is not in a state that allows this change")
+)
+
+// JobRepository implements job repository using PostgreSQL
+type JobRepository struct {
+	db          *database.PostgresDB
+	log         *logger.Logger
+	redis       *redis.Client
+	mail        *mail.Client
+	minio       *minio.Client
+	apiBaseURL  string
+	frontendURL string
+}
+
+// Repository defines the interface for job repository operations
+type Repository interface {
+	GetCompanyIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	CreateJob(ctx context.Context, job *entity.JobPosting) error
+	GetJobByID(ctx context.Context, id uuid.UUID) (*entity.JobPosting, error)
+	UpdateJob(ctx context.Context, job *entity.JobPosting) error
+	UpdateJobStatus(ctx context.Context, id uuid.UUID, status entity.JobStatus) (*entity.JobPosting, error)
+	PublishJob(ctx context.Context, companyID, jobID uuid.UUID, unverifiedLimit int) (*entity.JobPosting, error)
+	DeleteJob(ctx context.Context, id uuid.UUID) error
+	ListJobsByCompany(ctx context.Context, companyID uuid.UUID, paging pagination.Pagination) ([]entity.JobPosting, int, error)
+	ListPublishedJobs(ctx context.Context, paging pagination.Pagination) ([]entity.JobPosting, int, error)
+	SearchJobs(ctx context.Context, filter entity.JobSearchFilter, paging pagination.Pagination) ([]entity.JobSearchResult, int, error)
+	GetJobSearchFacets(ctx context.Context, filter entity.JobSearchFilter) (entity.JobSearchFacets, error)
+	GetRecommendationProfile(ctx context.Context, jobSeekerID uuid.UUID) (*entity.RecommendationProfile, error)
+	ListRecommendationCandidates(ctx context.Context, jobSeekerID uuid.UUID, limit int) ([]entity.RecommendedJob, error)
+	GetCachedRecommendations(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.RecommendedJob, bool)
+	RecommendationsVersion(ctx context.Context) (string, error)
+	CacheRecommendations(ctx context.Context, jobSeekerID uuid.UUID, version string, jobs []entity.RecommendedJob) error
+	InvalidateJobSeekerRecommendations(ctx context.Context, jobSeekerID uuid.UUID) error
+	InvalidateRecommendations(ctx context.Context) error
+	GetJobSeekerIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	CreateApplication(ctx context.Context, app *entity.JobApplication, changedBy uuid.UUID, evaluate func(screening *entity.Screening) ([]entity.ApplicationAnswer, *entity.KnockoutRejection, error)) error
+	GetApplicationByID(ctx context.Context, id uuid.UUID) (*entity.JobApplication, error)
+	UpdateApplicationStage(ctx context.Context, id uuid.UUID, from, to entity.ApplicationStage, changedBy uuid.UUID, note *string) (*entity.JobApplication, error)
+	ListApplicationsByJob(ctx context.Context, jobID uuid.UUID, stage *entity.ApplicationStage, paging pagination.Pagination) ([]entity.ApplicationWithApplicant, int, error)
+	ListApplicationsByJobSeeker(ctx context.Context, jobSeekerID uuid.UUID, paging pagination.Pagination) ([]entity.ApplicationWithJob, int, error)
+	GetApplicationHistory(ctx context.Context, applicationID uuid.UUID) ([]entity.ApplicationStageChange, error)
+	CreateSavedSearch(ctx context.Context, search *entity.SavedSearch) error
+	CountSavedSearches(ctx context.Context, jobSeekerID uuid.UUID) (int, error)
+	ListSavedSearches(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, id, jobSeekerID uuid.UUID) (*entity.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, search *entity.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, id, jobSeekerID uuid.UUID) error
+	GetSavedSearchByUnsubscribeToken(ctx context.Context, token string) (*entity.SavedSearch, error)
+	UnsubscribeSavedSearch(ctx context.Context, token string) (*entity.SavedSearch, error)
+	ClaimDueSavedSearchAlerts(ctx context.Context, now time.Time, limit int) ([]entity.SavedSearchAlert, error)
+	ReleaseSavedSearchAlert(ctx context.Context, id uuid.UUID, since time.Time) error
+	SendJobAlertEmail(ctx context.Context, alert entity.SavedSearchAlert, jobs []entity.JobSearchResult, total int) error
+	SaveJob(ctx context.Context, jobSeekerID, jobID uuid.UUID) error
+	UnsaveJob(ctx context.Context, jobSeekerID, jobID uuid.UUID) error
+	ListSavedJobs(ctx context.Context, jobSeekerID uuid.UUID, now, warnBefore time.Time, paging pagination.Pagination) ([]entity.SavedJob, int, error)
+	ClaimDeadlineReminders(ctx context.Context, now time.Time, window time.Duration, limit int) ([]entity.SavedJobReminder, error)
+	ReleaseDeadlineReminder(ctx context.Context, reminder entity.SavedJobReminder) error
+	SendDeadlineReminderEmail(ctx context.Context, reminders []entity.SavedJobReminder) error
+	GetScreening(ctx context.Context, jobID uuid.UUID) (*entity.Screening, error)
+	ReplaceScreening(ctx context.Context, jobID uuid.UUID, screening *entity.Screening) error
+	ListApplicationAnswers(ctx context.Context, applicationID uuid.UUID) ([]entity.ApplicationAnswer, error)
+	ClaimDueRejectionEmails(ctx context.Context, now time.Time, limit int) ([]entity.RejectionEmail, error)
+	ReleaseRejectionEmail(ctx context.Context, email entity.RejectionEmail) error
+	SendRejectionEmail(ctx context.Context, email entity.RejectionEmail) error
+	CreateInterview(ctx context.Context, interview *entity.Interview) error
+	GetInterview(ctx context.Context, id uuid.UUID) (*entity.Interview, error)
+	ListInterviewsByApplication(ctx context.Context, applicationID uuid.UUID) ([]entity.Interview, error)
+	ScheduleInterview(ctx context.Context, id, slotID uuid.UUID) (*entity.Interview, error)
+	RescheduleInterview(ctx context.Context, interview *entity.Interview) (*entity.Interview, error)
+	CancelInterview(ctx context.Context, id, cancelledBy uuid.UUID, reason *string) (*entity.Interview, error)
+	GetInterviewParticipants(ctx context.Context, applicationID uuid.UUID) (*entity.InterviewParticipants, error)
+	SendInterviewEmail(ctx context.Context, kind string, interview *entity.Interview, participants *entity.InterviewParticipants, emailCandidate, emailCompany bool) error
+	CreateMessage(ctx context.Context, msg *entity.ApplicationMessage) error
+	ListMessages(ctx context.Context, applicationID uuid.UUID, paging pagination.Pagination) ([]entity.ApplicationMessage, int, error)
+	MarkThreadRead(ctx context.Context, applicationID, userID uuid.UUID, side entity.ThreadSide, now time.Time) error
+	ListUnreadThreadsForJobSeeker(ctx context.Context, jobSeekerID, userID uuid.UUID) ([]entity.UnreadThread, error)
+	ListUnreadThreadsForCompany(ctx context.Context, companyID, userID uuid.UUID) ([]entity.UnreadThread, error)
+	UploadMessageAttachmentFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error
+	DeleteMessageAttachmentFile(ctx context.Context, objectName string) error
+	GetMessageAttachmentURL(ctx context.Context, objectName string) (string, error)
+	GetApplicationAudience(ctx context.Context, applicationID uuid.UUID) (*entity.ApplicationAudience, error)
+}
+
+// NewJobRepository creates new JobRepository
+func NewJobRepository(db *database.PostgresDB, log *logger.Logger, redis *redis.Client, mail *mail.Client, minio *minio.Client, apiBaseURL, frontendURL string) *JobRepository {
+	return &JobRepository{
+		db:          db,
+		log:         log,
+		redis:       redis,
+		mail:        mail,
+		minio:       minio,
+		apiBaseURL:  apiBaseURL,
+		frontendURL: frontendURL,
+	}
+}
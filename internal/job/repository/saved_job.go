@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// SaveJob bookmarks a posting for a job seeker. Saving a posting twice is a no-op.
+func (r *JobRepository) SaveJob(ctx context.Context, jobSeekerID, jobID uuid.UUID) error {
+	const query = `
+		INSERT INTO saved_jobs (job_seeker_id, job_id)
+		VALUES ($1, $2)
+		ON CONFLICT (job_seeker_id, job_id) DO NOTHING
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, jobSeekerID, jobID); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to save job")
+		return err
+	}
+
+	return nil
+}
+
+// UnsaveJob removes a bookmarked posting of a job seeker
+func (r *JobRepository) UnsaveJob(ctx context.Context, jobSeekerID, jobID uuid.UUID) error {
+	const query = `DELETE FROM saved_jobs WHERE job_seeker_id = $1 AND job_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, jobSeekerID, jobID)
+	if err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to unsave job")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSavedJobNotFound
+	}
+
+	return nil
+}
+
+// ListSavedJobs retrieves a paginated list of the postings a job seeker saved, most recently saved
+// first. Postings whose deadline falls between now and warnBefore are flagged as closing soon.
+func (r *JobRepository) ListSavedJobs(ctx context.Context, jobSeekerID uuid.UUID, now, warnBefore time.Time, paging pagination.Pagination) ([]entity.SavedJob, int, error) {
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM saved_jobs sj
+		JOIN job_postings j ON j.id = sj.job_id
+		WHERE sj.job_seeker_id = $1 AND j.status <> 'draft'`
+	if err := r.db.QueryRowContext(ctx, countQuery, jobSeekerID).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to count saved jobs")
+		return nil, 0, err
+	}
+
+	// Postings moved back to draft stay saved but are hidden until they are published again
+	jobs := []entity.SavedJob{}
+	query := `
+		SELECT ` + searchJobColumns + `, c.name AS company_name, sj.created_at AS saved_at,
+		       state.is_open,
+		       EXISTS (SELECT 1 FROM job_applications a WHERE a.job_id = j.id AND a.job_seeker_id = sj.job_seeker_id) AS has_applied,
+		       COALESCE(state.is_open AND j.application_deadline <= $3, FALSE) AS deadline_soon
+		FROM saved_jobs sj
+		JOIN job_postings j ON j.id = sj.job_id
+		JOIN companies c ON c.id = j.company_id
+		CROSS JOIN LATERAL (
+			SELECT j.status = 'published' AND (j.application_deadline IS NULL OR j.application_deadline > $2) AS is_open
+		) state
+		WHERE sj.job_seeker_id = $1 AND j.status <> 'draft'
+		ORDER BY sj.created_at DESC, j.id` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &jobs, query, jobSeekerID, now, warnBefore); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list saved jobs")
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// ClaimDeadlineReminders picks up to limit saved postings whose deadline passes within window of now
+// and that the job seeker has neither applied to nor been reminded about for this deadline, and marks
+// them reminded at now
+func (r *JobRepository) ClaimDeadlineReminders(ctx context.Context, now time.Time, window time.Duration, limit int) ([]entity.SavedJobReminder, error) {
+	const query = `
+		WITH due AS (
+			SELECT sj.job_seeker_id, sj.job_id, sj.deadline_reminded_at
+			FROM saved_jobs sj
+			JOIN job_postings j ON j.id = sj.job_id
+			JOIN job_seekers js ON js.id = sj.job_seeker_id
+			JOIN users u ON u.id = js.user_id
+			WHERE j.status = 'published'
+			  AND u.is_active
+			  AND j.application_deadline > $1
+			  AND j.application_deadline <= $1 + make_interval(secs => $2)
+			  -- A reminder sent before an extended deadline entered the window does not count
+			  AND (sj.deadline_reminded_at IS NULL
+			   OR sj.deadline_reminded_at < j.application_deadline - make_interval(secs => $2))
+			  AND NOT EXISTS (
+				SELECT 1 FROM job_applications a
+				WHERE a.job_id = sj.job_id AND a.job_seeker_id = sj.job_seeker_id
+			  )
+			ORDER BY j.application_deadline
+			LIMIT $3
+			FOR UPDATE OF sj SKIP LOCKED
+		)
+		UPDATE saved_jobs sj
+		SET deadline_reminded_at = $1
+		FROM due, job_postings j, companies c, job_seekers js, users u
+		WHERE sj.job_seeker_id = due.job_seeker_id AND sj.job_id = due.job_id
+		  AND j.id = sj.job_id AND c.id = j.company_id AND js.id = sj.job_seeker_id AND u.id = js.user_id
+		RETURNING sj.job_seeker_id, sj.job_id, u.id AS user_id, u.email, js.first_name, j.title, c.name AS company_name,
+		          j.application_deadline, due.deadline_reminded_at AS previous_reminded_at
+	`
+
+	reminders := []entity.SavedJobReminder{}
+	if err := r.db.SelectContext(ctx, &reminders, query, now, window.Seconds(), limit); err != nil {
+		r.log.WithError(err).Error("Failed to claim saved job deadline reminders")
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// ReleaseDeadlineReminder restores the reminder state of a claimed reminder that could not be sent
+func (r *JobRepository) ReleaseDeadlineReminder(ctx context.Context, reminder entity.SavedJobReminder) error {
+	const query = `UPDATE saved_jobs SET deadline_reminded_at = $1 WHERE job_seeker_id = $2 AND job_id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, reminder.PreviousRemindedAt, reminder.JobSeekerID, reminder.JobID); err != nil {
+		r.log.WithError(err).WithField("job_id", reminder.JobID).Error("Failed to release saved job deadline reminder")
+		return err
+	}
+
+	return nil
+}
+
+// SendDeadlineReminderEmail tells a job seeker that the deadlines of saved postings they have not
+// applied to are near. All reminders must belong to the same job seeker.
+func (r *JobRepository) SendDeadlineReminderEmail(ctx context.Context, reminders []entity.SavedJobReminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	frontendURL := strings.TrimSuffix(r.frontendURL, "/")
+
+	items := make([]templates.DeadlineReminderItem, 0, len(reminders))
+	for _, reminder := range reminders {
+		items = append(items, templates.DeadlineReminderItem{
+			Title:       reminder.Title,
+			CompanyName: reminder.CompanyName,
+			Deadline:    reminder.ApplicationDeadline.UTC().Format("Mon, 2 Jan 2006 15:04 MST"),
+			URL:         frontendURL + "/jobs/" + reminder.JobID.String(),
+		})
+	}
+
+	data := templates.DeadlineReminderData{
+		FirstName:    reminders[0].FirstName,
+		Jobs:         items,
+		SavedJobsURL: frontendURL + "/saved-jobs",
+		AppName:      "Career Link",
+		SupportEmail: "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetDeadlineReminderHTML(data)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Applications for \"%s\" close soon", reminders[0].Title)
+	if len(reminders) > 1 {
+		subject = fmt.Sprintf("Applications for %d of your saved jobs close soon", len(reminders))
+	}
+
+	message := mail.EmailMessage{
+		To:      reminders[0].Email,
+		Subject: subject,
+		Body:    htmlContent,
+		IsHTML:  true,
+	}
+
+	return r.mail.SendEmail(ctx, message)
+}
@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/google/uuid"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const savedSearchColumns = `s.id, s.job_seeker_id, s.name, s.query, s.location, s.is_remote, s.employment_types,
+	s.salary_min, s.salary_max, s.industries, s.company_sizes, s.alert_frequency, s.alerts_enabled,
+	s.last_alerted_at, s.unsubscribe_token, s.created_at, s.updated_at`
+
+// CreateSavedSearch inserts a new saved search
+func (r *JobRepository) CreateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	const query = `
+		INSERT INTO saved_searches (id, job_seeker_id, name, query, location, is_remote, employment_types,
+			salary_min, salary_max, industries, company_sizes, alert_frequency, alerts_enabled, unsubscribe_token)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING last_alerted_at, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		search.ID,
+		search.JobSeekerID,
+		search.Name,
+		search.Query,
+		search.Location,
+		search.IsRemote,
+		search.EmploymentTypes,
+		search.SalaryMin,
+		search.SalaryMax,
+		search.Industries,
+		search.CompanySizes,
+		search.AlertFrequency,
+		search.AlertsEnabled,
+		search.UnsubscribeToken,
+	).Scan(&search.LastAlertedAt, &search.CreatedAt, &search.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", search.JobSeekerID).Error("Failed to create saved search")
+		return err
+	}
+
+	return nil
+}
+
+// CountSavedSearches counts the saved searches of a job seeker
+func (r *JobRepository) CountSavedSearches(ctx context.Context, jobSeekerID uuid.UUID) (int, error) {
+	const query = `SELECT COUNT(*) FROM saved_searches WHERE job_seeker_id = $1`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, jobSeekerID).Scan(&count); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to count saved searches")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListSavedSearches retrieves every saved search of a job seeker, newest first
+func (r *JobRepository) ListSavedSearches(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s WHERE s.job_seeker_id = $1 ORDER BY s.created_at DESC`
+
+	searches := []entity.SavedSearch{}
+	if err := r.db.SelectContext(ctx, &searches, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list saved searches")
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// GetSavedSearch retrieves a saved search owned by the job seeker
+func (r *JobRepository) GetSavedSearch(ctx context.Context, id, jobSeekerID uuid.UUID) (*entity.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s WHERE s.id = $1 AND s.job_seeker_id = $2`
+
+	var searches []entity.SavedSearch
+	if err := r.db.SelectContext(ctx, &searches, query, id, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("saved_search_id", id).Error("Failed to get saved search")
+		return nil, err
+	}
+
+	if len(searches) == 0 {
+		return nil, ErrSavedSearchNotFound
+	}
+
+	return &searches[0], nil
+}
+
+// UpdateSavedSearch replaces the filters and alert settings of a saved search
+func (r *JobRepository) UpdateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	const query = `
+		UPDATE saved_searches
+		SET name = $1, query = $2, location = $3, is_remote = $4, employment_types = $5, salary_min = $6,
+			salary_max = $7, industries = $8, company_sizes = $9, alert_frequency = $10, alerts_enabled = $11
+		WHERE id = $12 AND job_seeker_id = $13
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		search.Name,
+		search.Query,
+		search.Location,
+		search.IsRemote,
+		search.EmploymentTypes,
+		search.SalaryMin,
+		search.SalaryMax,
+		search.Industries,
+		search.CompanySizes,
+		search.AlertFrequency,
+		search.AlertsEnabled,
+		search.ID,
+		search.JobSeekerID,
+	).Scan(&search.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSavedSearchNotFound
+		}
+		r.log.WithError(err).WithField("saved_search_id", search.ID).Error("Failed to update saved search")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSavedSearch removes a saved search owned by the job seeker
+func (r *JobRepository) DeleteSavedSearch(ctx context.Context, id, jobSeekerID uuid.UUID) error {
+	const query = `DELETE FROM saved_searches WHERE id = $1 AND job_seeker_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, jobSeekerID)
+	if err != nil {
+		r.log.WithError(err).WithField("saved_search_id", id).Error("Failed to delete saved search")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+// GetSavedSearchByUnsubscribeToken returns the saved search an alert unsubscribe link belongs to
+func (r *JobRepository) GetSavedSearchByUnsubscribeToken(ctx context.Context, token string) (*entity.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s WHERE s.unsubscribe_token = $1`
+
+	var searches []entity.SavedSearch
+	if err := r.db.SelectContext(ctx, &searches, query, token); err != nil {
+		r.log.WithError(err).Error("Failed to get saved search by unsubscribe token")
+		return nil, err
+	}
+
+	if len(searches) == 0 {
+		return nil, ErrSavedSearchNotFound
+	}
+
+	return &searches[0], nil
+}
+
+// UnsubscribeSavedSearch turns off the alerts of the saved search holding the unsubscribe token
+func (r *JobRepository) UnsubscribeSavedSearch(ctx context.Context, token string) (*entity.SavedSearch, error) {
+	query := `
+		UPDATE saved_searches s
+		SET alerts_enabled = FALSE
+		WHERE s.unsubscribe_token = $1
+		RETURNING ` + savedSearchColumns
+
+	var searches []entity.SavedSearch
+	if err := r.db.SelectContext(ctx, &searches, query, token); err != nil {
+		r.log.WithError(err).Error("Failed to unsubscribe saved search")
+		return nil, err
+	}
+
+	if len(searches) == 0 {
+		return nil, ErrSavedSearchNotFound
+	}
+
+	return &searches[0], nil
+}
+
+// ClaimDueSavedSearchAlerts picks up to limit saved searches whose alert is due at now and moves their
+// last_alerted_at to now, so concurrent runs never claim the same search twice. Each claimed alert
+// carries the previous last_alerted_at as Since.
+func (r *JobRepository) ClaimDueSavedSearchAlerts(ctx context.Context, now time.Time, limit int) ([]entity.SavedSearchAlert, error) {
+	query := `
+		WITH due AS (
+			SELECT s.id, s.last_alerted_at
+			FROM saved_searches s
+			JOIN job_seekers js ON js.id = s.job_seeker_id
+			JOIN users u ON u.id = js.user_id
+			WHERE s.alerts_enabled
+			  AND u.is_active
+			  AND ((s.alert_frequency = 'instant' AND s.last_alerted_at < $1)
+			   OR (s.alert_frequency = 'daily' AND s.last_alerted_at <= $1 - INTERVAL '1 day')
+			   OR (s.alert_frequency = 'weekly' AND s.last_alerted_at <= $1 - INTERVAL '7 days'))
+			ORDER BY s.last_alerted_at
+			LIMIT $2
+			FOR UPDATE OF s SKIP LOCKED
+		)
+		UPDATE saved_searches s
+		SET last_alerted_at = $1
+		FROM due, job_seekers js, users u
+		WHERE s.id = due.id AND js.id = s.job_seeker_id AND u.id = js.user_id
+		RETURNING ` + savedSearchColumns + `, u.id AS user_id, u.email, js.first_name, due.last_alerted_at AS since`
+
+	alerts := []entity.SavedSearchAlert{}
+	if err := r.db.SelectContext(ctx, &alerts, query, now, limit); err != nil {
+		r.log.WithError(err).Error("Failed to claim due saved search alerts")
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// ReleaseSavedSearchAlert restores the last_alerted_at of a claimed alert that could not be sent, so
+// its postings are picked up again by the next run
+func (r *JobRepository) ReleaseSavedSearchAlert(ctx context.Context, id uuid.UUID, since time.Time) error {
+	const query = `UPDATE saved_searches SET last_alerted_at = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, since, id); err != nil {
+		r.log.WithError(err).WithField("saved_search_id", id).Error("Failed to release saved search alert")
+		return err
+	}
+
+	return nil
+}
+
+// SendJobAlertEmail sends the digest of new postings matching a saved search. total is the number of
+// matches, which may exceed the postings listed.
+func (r *JobRepository) SendJobAlertEmail(ctx context.Context, alert entity.SavedSearchAlert, jobs []entity.JobSearchResult, total int) error {
+	frontendURL := strings.TrimSuffix(r.frontendURL, "/")
+	unsubscribeURL := fmt.Sprintf("%s/api/v1/saved-searches/unsubscribe?token=%s",
+		strings.TrimSuffix(r.apiBaseURL, "/"),
+		url.QueryEscape(alert.UnsubscribeToken))
+
+	items := make([]templates.JobAlertItem, 0, len(jobs))
+	for _, job := range jobs {
+		items = append(items, templates.JobAlertItem{
+			Title:       job.Title,
+			CompanyName: job.CompanyName,
+			Location:    job.Location,
+			IsRemote:    job.IsRemote,
+			Salary:      formatSalaryRange(job.SalaryMin, job.SalaryMax),
+			URL:         frontendURL + "/jobs/" + job.ID.String(),
+		})
+	}
+
+	data := templates.JobAlertData{
+		FirstName:      alert.FirstName,
+		SearchName:     alert.Name,
+		Jobs:           items,
+		MoreCount:      total - len(items),
+		SearchURL:      frontendURL + "/saved-searches/" + alert.ID.String(),
+		UnsubscribeURL: unsubscribeURL,
+		AppName:        "Career Link",
+		SupportEmail:   "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetJobAlertHTML(data)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%d new jobs for \"%s\"", total, alert.Name)
+	if total == 1 {
+		subject = fmt.Sprintf("1 new job for \"%s\"", alert.Name)
+	}
+
+	message := mail.EmailMessage{
+		To:      alert.Email,
+		Subject: subject,
+		Body:    htmlContent,
+		IsHTML:  true,
+		Headers: map[string]string{
+			// One-click unsubscribe (RFC 8058): mail clients POST to the URL without opening it
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+
+	return r.mail.SendEmail(ctx, message)
+}
+
+// formatSalaryRange formats an optional salary range in rupiah, e.g. "IDR 5.000.000 - 10.000.000"
+func formatSalaryRange(minSalary, maxSalary *int) string {
+	switch {
+	case minSalary != nil && maxSalary != nil:
+		return "IDR " + formatThousands(*minSalary) + " - " + formatThousands(*maxSalary)
+	case minSalary != nil:
+		return "From IDR " + formatThousands(*minSalary)
+	case maxSalary != nil:
+		return "Up to IDR " + formatThousands(*maxSalary)
+	default:
+		return ""
+	}
+}
+
+// formatThousands groups the digits of n with dots, the Indonesian thousands separator
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
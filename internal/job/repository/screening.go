@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// GetScreening retrieves the screening questions of a posting in order, with its knockout settings
+func (r *JobRepository) GetScreening(ctx context.Context, jobID uuid.UUID) (*entity.Screening, error) {
+	screening := &entity.Screening{Questions: []entity.ScreeningQuestion{}}
+
+	const settingsQuery = `SELECT rejection_email_delay_hours FROM job_postings WHERE id = $1`
+	if err := r.db.QueryRowContext(ctx, settingsQuery, jobID).Scan(&screening.RejectionEmailDelayHours); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to get screening settings")
+		return nil, err
+	}
+
+	query := `SELECT id, job_id, position, question, type, options, required, knockout_answers, min_accepted,
+	                 max_accepted, created_at
+              FROM job_screening_questions
+              WHERE job_id = $1
+              ORDER BY position`
+	if err := r.db.SelectContext(ctx, &screening.Questions, query, jobID); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to list screening questions")
+		return nil, err
+	}
+
+	return screening, nil
+}
+
+// lockScreeningTx locks a posting open for applications against changes for the rest of the
+// transaction and retrieves its screening questions in order, with its knockout settings
+func (r *JobRepository) lockScreeningTx(ctx context.Context, tx *sql.Tx, jobID uuid.UUID) (*entity.Screening, error) {
+	screening := &entity.Screening{Questions: []entity.ScreeningQuestion{}}
+
+	const postingQuery = `
+		SELECT status, application_deadline, rejection_email_delay_hours
+		FROM job_postings
+		WHERE id = $1
+		FOR SHARE
+	`
+	var job entity.JobPosting
+	err := tx.QueryRowContext(ctx, postingQuery, jobID).Scan(&job.Status, &job.ApplicationDeadline, &screening.RejectionEmailDelayHours)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to lock job posting")
+		return nil, err
+	}
+
+	if !job.IsOpen(time.Now()) {
+		return nil, ErrJobNotOpen
+	}
+
+	const questionQuery = `
+		SELECT id, job_id, position, question, type, options, required, knockout_answers, min_accepted,
+		       max_accepted, created_at
+		FROM job_screening_questions
+		WHERE job_id = $1
+		ORDER BY position
+	`
+	rows, err := tx.QueryContext(ctx, questionQuery, jobID)
+	if err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to list screening questions")
+		return nil, err
+	}
+	for rows.Next() {
+		var q entity.ScreeningQuestion
+		err := rows.Scan(&q.ID, &q.JobID, &q.Position, &q.Question, &q.Type, &q.Options, &q.Required,
+			&q.KnockoutAnswers, &q.MinAccepted, &q.MaxAccepted, &q.CreatedAt)
+		if err != nil {
+			_ = rows.Close()
+			r.log.WithError(err).WithField("job_id", jobID).Error("Failed to scan screening question")
+			return nil, err
+		}
+		screening.Questions = append(screening.Questions, q)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return screening, nil
+}
+
+// ReplaceScreening replaces every screening question of a posting and its knockout settings. Nothing
+// changes once someone applied, since their answers refer to the questions.
+func (r *JobRepository) ReplaceScreening(ctx context.Context, jobID uuid.UUID, screening *entity.Screening) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	// Updating the posting waits for applications being submitted, which hold it locked
+	const settingsQuery = `UPDATE job_postings SET rejection_email_delay_hours = $1 WHERE id = $2`
+	result, err := tx.ExecContext(ctx, settingsQuery, screening.RejectionEmailDelayHours, jobID)
+	if err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to update screening settings")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrJobNotFound
+	}
+
+	// Checked in a statement of its own so it sees applications committed while the update waited
+	const applicationsQuery = `SELECT EXISTS (SELECT 1 FROM job_applications WHERE job_id = $1)`
+	var hasApplications bool
+	if err := tx.QueryRowContext(ctx, applicationsQuery, jobID).Scan(&hasApplications); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to check job applications")
+		return err
+	}
+
+	if hasApplications {
+		return ErrScreeningLocked
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_screening_questions WHERE job_id = $1`, jobID); err != nil {
+		r.log.WithError(err).WithField("job_id", jobID).Error("Failed to delete screening questions")
+		return err
+	}
+
+	const questionQuery = `
+		INSERT INTO job_screening_questions (id, job_id, position, question, type, options, required,
+			knockout_answers, min_accepted, max_accepted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at
+	`
+	for i := range screening.Questions {
+		q := &screening.Questions[i]
+		err := tx.QueryRowContext(ctx, questionQuery,
+			q.ID,
+			jobID,
+			q.Position,
+			q.Question,
+			q.Type,
+			q.Options,
+			q.Required,
+			q.KnockoutAnswers,
+			q.MinAccepted,
+			q.MaxAccepted,
+		).Scan(&q.CreatedAt)
+		if err != nil {
+			r.log.WithError(err).WithField("job_id", jobID).Error("Failed to create screening question")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ListApplicationAnswers retrieves the screening answers of an application in question order
+func (r *JobRepository) ListApplicationAnswers(ctx context.Context, applicationID uuid.UUID) ([]entity.ApplicationAnswer, error) {
+	answers := []entity.ApplicationAnswer{}
+	query := `SELECT aa.application_id, aa.question_id, q.question, q.type, aa.answer, aa.knocked_out
+              FROM job_application_answers aa
+              JOIN job_screening_questions q ON q.id = aa.question_id
+              WHERE aa.application_id = $1
+              ORDER BY q.position`
+	if err := r.db.SelectContext(ctx, &answers, query, applicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", applicationID).Error("Failed to list application answers")
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// ClaimDueRejectionEmails picks up to limit knockout rejection emails due at now and clears their due
+// time, so concurrent runs never send the same email twice
+func (r *JobRepository) ClaimDueRejectionEmails(ctx context.Context, now time.Time, limit int) ([]entity.RejectionEmail, error) {
+	const query = `
+		WITH due AS (
+			SELECT id, rejection_email_due_at
+			FROM job_applications
+			WHERE rejection_email_due_at <= $1
+			ORDER BY rejection_email_due_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE job_applications a
+		SET rejection_email_due_at = NULL
+		FROM due, job_postings j, companies c, job_seekers js, users u
+		WHERE a.id = due.id AND j.id = a.job_id AND c.id = j.company_id AND js.id = a.job_seeker_id AND u.id = js.user_id
+		RETURNING a.id AS application_id, due.rejection_email_due_at AS due_at, u.id AS user_id, u.email, js.first_name,
+		          j.title AS job_title, c.name AS company_name
+	`
+
+	emails := []entity.RejectionEmail{}
+	if err := r.db.SelectContext(ctx, &emails, query, now, limit); err != nil {
+		r.log.WithError(err).Error("Failed to claim due rejection emails")
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// ReleaseRejectionEmail restores the due time of a claimed rejection email that could not be sent
+func (r *JobRepository) ReleaseRejectionEmail(ctx context.Context, email entity.RejectionEmail) error {
+	const query = `UPDATE job_applications SET rejection_email_due_at = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, email.DueAt, email.ApplicationID); err != nil {
+		r.log.WithError(err).WithField("application_id", email.ApplicationID).Error("Failed to release rejection email")
+		return err
+	}
+
+	return nil
+}
+
+// SendRejectionEmail tells an applicant that their application was not taken further
+func (r *JobRepository) SendRejectionEmail(ctx context.Context, email entity.RejectionEmail) error {
+	data := templates.ApplicationRejectedData{
+		FirstName:       email.FirstName,
+		JobTitle:        email.JobTitle,
+		CompanyName:     email.CompanyName,
+		ApplicationsURL: strings.TrimSuffix(r.frontendURL, "/") + "/applications/" + email.ApplicationID.String(),
+		AppName:         "Career Link",
+		SupportEmail:    "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetApplicationRejectedHTML(data)
+	if err != nil {
+		return err
+	}
+
+	message := mail.EmailMessage{
+		To:      email.Email,
+		Subject: "Your application for " + email.JobTitle + " at " + email.CompanyName,
+		Body:    htmlContent,
+		IsHTML:  true,
+	}
+
+	return r.mail.SendEmail(ctx, message)
+}
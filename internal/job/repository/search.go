@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/lib/pq"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Search parameters are positional and shared by every search query:
+// $1 query, $2 location, $3 remote, $4 employment types, $5 salary min, $6 salary max,
+// $7 industries, $8 company sizes, $9 published after
+const (
+	searchFrom = `
+		FROM job_postings j
+		JOIN companies c ON c.id = j.company_id
+		WHERE j.status = 'published'
+		  AND (j.application_deadline IS NULL OR j.application_deadline > NOW())
+		  AND ($1::text IS NULL OR j.search_vector @@ websearch_to_tsquery('english', $1))
+		  AND ($9::timestamptz IS NULL OR j.published_at > $9)`
+
+	searchMatchLocation = `($2::text IS NULL OR j.location ILIKE '%' || $2 || '%')`
+	searchMatchRemote   = `($3::boolean IS NULL OR j.is_remote = $3)`
+	searchMatchType     = `(COALESCE(cardinality($4::text[]), 0) = 0 OR j.employment_type = ANY($4))`
+	searchMatchSalary   = `(($5::integer IS NULL OR COALESCE(j.salary_max, j.salary_min) >= $5)
+		AND ($6::integer IS NULL OR COALESCE(j.salary_min, j.salary_max) <= $6))`
+	searchMatchIndustry = `(COALESCE(cardinality($7::text[]), 0) = 0 OR c.industry = ANY($7))`
+	searchMatchSize     = `(COALESCE(cardinality($8::text[]), 0) = 0 OR c.size = ANY($8))`
+
+	searchRank = `CASE WHEN $1::text IS NULL THEN 0
+		ELSE ts_rank_cd(j.search_vector, websearch_to_tsquery('english', $1)) END`
+
+	searchJobColumns = `j.id, j.company_id, j.title, j.description, j.employment_type, j.location, j.is_remote,
+		j.salary_min, j.salary_max, j.required_skills, j.status, j.published_at, j.closed_at, j.application_deadline,
+		j.created_at, j.updated_at`
+
+	// searchFacetLocationLimit caps the number of location values returned as facets
+	searchFacetLocationLimit = 10
+)
+
+var searchMatchAll = strings.Join([]string{
+	searchMatchLocation, searchMatchRemote, searchMatchType, searchMatchSalary, searchMatchIndustry, searchMatchSize,
+}, " AND ")
+
+// searchArgs returns the positional parameters of the search queries
+func searchArgs(filter entity.JobSearchFilter) []interface{} {
+	types := make(pq.StringArray, 0, len(filter.EmploymentTypes))
+	for _, t := range filter.EmploymentTypes {
+		types = append(types, string(t))
+	}
+
+	return []interface{}{
+		filter.Query,
+		filter.Location,
+		filter.IsRemote,
+		types,
+		filter.SalaryMin,
+		filter.SalaryMax,
+		pq.StringArray(filter.Industries),
+		pq.StringArray(filter.CompanySizes),
+		filter.PublishedAfter,
+	}
+}
+
+// SearchJobs retrieves published postings still taking applications that match the filter, ranked by
+// relevance to the query and then by recency
+func (r *JobRepository) SearchJobs(ctx context.Context, filter entity.JobSearchFilter, paging pagination.Pagination) ([]entity.JobSearchResult, int, error) {
+	args := searchArgs(filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*)` + searchFrom + ` AND ` + searchMatchAll
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.log.WithError(err).Error("Failed to count job search results")
+		return nil, 0, err
+	}
+
+	results := []entity.JobSearchResult{}
+	query := `SELECT ` + searchJobColumns + `, c.name AS company_name, c.industry AS company_industry,
+              c.size AS company_size, ` + searchRank + ` AS rank` + searchFrom + ` AND ` + searchMatchAll + `
+              ORDER BY rank DESC, j.published_at DESC, j.id` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &results, query, args...); err != nil {
+		r.log.WithError(err).Error("Failed to search job postings")
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// GetJobSearchFacets counts the postings matching the filter per value of each filter. Every facet
+// ignores its own filter, so the counts show what selecting another value would return.
+func (r *JobRepository) GetJobSearchFacets(ctx context.Context, filter entity.JobSearchFilter) (entity.JobSearchFacets, error) {
+	var bucket strings.Builder
+	bucket.WriteString("CASE")
+	for _, s := range entity.SalaryRanges {
+		bucket.WriteString(" WHEN COALESCE(salary_max, salary_min) >= " + strconv.Itoa(s.Min))
+		if s.Max != nil {
+			bucket.WriteString(" AND COALESCE(salary_max, salary_min) < " + strconv.Itoa(*s.Max))
+		}
+		bucket.WriteString(" THEN '" + s.Label() + "'")
+	}
+	bucket.WriteString(" END")
+
+	query := `
+		WITH candidates AS (
+			SELECT j.employment_type, j.is_remote, j.location, j.salary_min, j.salary_max, c.industry, c.size,
+			       ` + searchMatchLocation + ` AS m_location,
+			       ` + searchMatchRemote + ` AS m_remote,
+			       ` + searchMatchType + ` AS m_type,
+			       ` + searchMatchSalary + ` AS m_salary,
+			       ` + searchMatchIndustry + ` AS m_industry,
+			       ` + searchMatchSize + ` AS m_size
+			` + searchFrom + `
+		)
+		SELECT 'employment_type' AS facet, employment_type AS value, COUNT(*) AS count FROM candidates
+		WHERE m_location AND m_remote AND m_salary AND m_industry AND m_size
+		GROUP BY employment_type
+		UNION ALL
+		SELECT 'remote', is_remote::text, COUNT(*) FROM candidates
+		WHERE m_location AND m_type AND m_salary AND m_industry AND m_size
+		GROUP BY is_remote
+		UNION ALL
+		(SELECT 'location', location, COUNT(*) FROM candidates
+		 WHERE m_remote AND m_type AND m_salary AND m_industry AND m_size
+		 GROUP BY location ORDER BY COUNT(*) DESC, location LIMIT ` + strconv.Itoa(searchFacetLocationLimit) + `)
+		UNION ALL
+		SELECT 'salary', bucket, COUNT(*) FROM (
+			SELECT ` + bucket.String() + ` AS bucket FROM candidates
+			WHERE m_location AND m_remote AND m_type AND m_industry AND m_size
+		) salaries WHERE bucket IS NOT NULL
+		GROUP BY bucket
+		UNION ALL
+		SELECT 'industry', industry, COUNT(*) FROM candidates
+		WHERE industry IS NOT NULL AND m_location AND m_remote AND m_type AND m_salary AND m_size
+		GROUP BY industry
+		UNION ALL
+		SELECT 'company_size', size, COUNT(*) FROM candidates
+		WHERE m_location AND m_remote AND m_type AND m_salary AND m_industry
+		GROUP BY size
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, searchArgs(filter)...)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to count job search facets")
+		return entity.JobSearchFacets{}, err
+	}
+	defer rows.Close()
+
+	facets := entity.JobSearchFacets{
+		EmploymentTypes: []entity.FacetCount{},
+		Remote:          []entity.FacetCount{},
+		Locations:       []entity.FacetCount{},
+		SalaryRanges:    []entity.FacetCount{},
+		Industries:      []entity.FacetCount{},
+		CompanySizes:    []entity.FacetCount{},
+	}
+	for rows.Next() {
+		var facet string
+		var count entity.FacetCount
+		if err := rows.Scan(&facet, &count.Value, &count.Count); err != nil {
+			r.log.WithError(err).Error("Failed to scan job search facet")
+			return entity.JobSearchFacets{}, err
+		}
+
+		switch facet {
+		case "employment_type":
+			facets.EmploymentTypes = append(facets.EmploymentTypes, count)
+		case "remote":
+			facets.Remote = append(facets.Remote, count)
+		case "location":
+			facets.Locations = append(facets.Locations, count)
+		case "salary":
+			facets.SalaryRanges = append(facets.SalaryRanges, count)
+		case "industry":
+			facets.Industries = append(facets.Industries, count)
+		case "company_size":
+			facets.CompanySizes = append(facets.CompanySizes, count)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Failed to iterate job search facets")
+		return entity.JobSearchFacets{}, err
+	}
+
+	for _, counts := range [][]entity.FacetCount{facets.EmploymentTypes, facets.Remote, facets.Locations, facets.Industries, facets.CompanySizes} {
+		sortFacetCounts(counts)
+	}
+	// Salary buckets keep their natural order
+	order := make(map[string]int, len(entity.SalaryRanges))
+	for i, s := range entity.SalaryRanges {
+		order[s.Label()] = i
+	}
+	sort.Slice(facets.SalaryRanges, func(i, j int) bool {
+		return order[facets.SalaryRanges[i].Value] < order[facets.SalaryRanges[j].Value]
+	})
+
+	return facets, nil
+}
+
+// sortFacetCounts orders facet values by count, most frequent first
+func sortFacetCounts(counts []entity.FacetCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+}
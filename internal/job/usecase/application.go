@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// Apply submits the job seeker's application to a published job posting
+func (uc *JobUseCase) Apply(ctx context.Context, userID, jobID uuid.UUID, req dto.ApplyRequest) (*entity.JobApplication, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	app := &entity.JobApplication{
+		ID:          id,
+		JobID:       jobID,
+		JobSeekerID: jobSeekerID,
+		Stage:       entity.StageApplied,
+	}
+	if coverLetter := strings.TrimSpace(req.CoverLetter); coverLetter != "" {
+		app.CoverLetter = &coverLetter
+	}
+
+	// The answers are checked against the screening as it is when the application is stored, while
+	// the posting is locked open
+	evaluate := func(screening *entity.Screening) ([]entity.ApplicationAnswer, *entity.KnockoutRejection, error) {
+		answers, knockout, err := evaluateScreeningAnswers(screening.Questions, req.Answers)
+		if err != nil || knockout == nil {
+			return answers, nil, err
+		}
+
+		// Applicants failing a knockout are rejected right away; the email telling them is optional and
+		// delayed so it does not arrive moments after applying
+		rejection := &entity.KnockoutRejection{
+			Note: fmt.Sprintf("Automatically rejected: the answer to %q did not meet the requirement", knockout.Question),
+		}
+		if delay := screening.RejectionEmailDelayHours; delay != nil {
+			dueAt := time.Now().Add(time.Duration(*delay) * time.Hour)
+			rejection.EmailDueAt = &dueAt
+		}
+		return answers, rejection, nil
+	}
+
+	if err := uc.repo.CreateApplication(ctx, app, userID, evaluate); err != nil {
+		return nil, err
+	}
+
+	// Postings the job seeker applied to are no longer recommended
+	if err := uc.repo.InvalidateJobSeekerRecommendations(ctx, jobSeekerID); err != nil {
+		uc.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to invalidate job recommendations")
+	}
+
+	// Knocked out applicants never reach the team's pipeline
+	if !app.KnockedOut {
+		uc.notifyApplicationReceived(ctx, app.ID)
+	}
+
+	return app, nil
+}
+
+// ListJobApplications retrieves the applications of a posting owned by the user's company
+func (uc *JobUseCase) ListJobApplications(ctx context.Context, userID, jobID uuid.UUID, stage *entity.ApplicationStage, paging pagination.Pagination) (pagination.PageResponse, error) {
+	if _, err := uc.getOwnedJob(ctx, userID, jobID); err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	apps, total, err := uc.repo.ListApplicationsByJob(ctx, jobID, stage, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(apps, paging, total), nil
+}
+
+// ListMyApplications retrieves the applications submitted by the job seeker
+func (uc *JobUseCase) ListMyApplications(ctx context.Context, userID uuid.UUID, paging pagination.Pagination) (pagination.PageResponse, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	apps, total, err := uc.repo.ListApplicationsByJobSeeker(ctx, jobSeekerID, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(apps, paging, total), nil
+}
+
+// GetApplicationForCompany returns an application and its history to the company that owns the posting
+func (uc *JobUseCase) GetApplicationForCompany(ctx context.Context, userID, applicationID uuid.UUID) (*dto.ApplicationDetailResponse, error) {
+	app, err := uc.getCompanyApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.applicationDetail(ctx, app)
+}
+
+// GetApplicationForJobSeeker returns an application and its history to the job seeker who submitted it
+func (uc *JobUseCase) GetApplicationForJobSeeker(ctx context.Context, userID, applicationID uuid.UUID) (*dto.ApplicationDetailResponse, error) {
+	app, err := uc.getJobSeekerApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.applicationDetail(ctx, app)
+}
+
+// MoveApplicationStage moves a candidate through the hiring pipeline on behalf of the company.
+// Only transitions allowed by the pipeline are accepted, and withdrawing is reserved for the candidate.
+func (uc *JobUseCase) MoveApplicationStage(ctx context.Context, userID, applicationID uuid.UUID, req dto.MoveStageRequest) (*entity.JobApplication, error) {
+	app, err := uc.getCompanyApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := entity.ApplicationStage(req.Stage)
+	if next == entity.StageWithdrawn || !app.Stage.CanTransitionTo(next) {
+		return nil, repository.ErrInvalidStageTransition
+	}
+
+	var note *string
+	if trimmed := strings.TrimSpace(req.Note); trimmed != "" {
+		note = &trimmed
+	}
+
+	updated, err := uc.repo.UpdateApplicationStage(ctx, applicationID, app.Stage, next, userID, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.notifyStageChanged(ctx, updated)
+
+	return updated, nil
+}
+
+// WithdrawApplication lets the job seeker pull an application out of the pipeline
+func (uc *JobUseCase) WithdrawApplication(ctx context.Context, userID, applicationID uuid.UUID) (*entity.JobApplication, error) {
+	app, err := uc.getJobSeekerApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !app.Stage.CanTransitionTo(entity.StageWithdrawn) {
+		return nil, repository.ErrInvalidStageTransition
+	}
+
+	updated, err := uc.repo.UpdateApplicationStage(ctx, applicationID, app.Stage, entity.StageWithdrawn, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.notifyWithdrawn(ctx, updated)
+
+	return updated, nil
+}
+
+func (uc *JobUseCase) applicationDetail(ctx context.Context, app *entity.JobApplication) (*dto.ApplicationDetailResponse, error) {
+	answers, err := uc.repo.ListApplicationAnswers(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.repo.GetApplicationHistory(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ApplicationDetailResponse{
+		Application: *app,
+		Answers:     answers,
+		History:     history,
+	}, nil
+}
+
+// getCompanyApplication loads an application whose posting belongs to the user's company
+func (uc *JobUseCase) getCompanyApplication(ctx context.Context, userID, applicationID uuid.UUID) (*entity.JobApplication, error) {
+	app, err := uc.repo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.getOwnedJob(ctx, userID, app.JobID); err != nil {
+		if errors.Is(err, repository.ErrJobNotFound) {
+			return nil, repository.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// getJobSeekerApplication loads an application submitted by the user
+func (uc *JobUseCase) getJobSeekerApplication(ctx context.Context, userID, applicationID uuid.UUID) (*entity.JobApplication, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := uc.repo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.JobSeekerID != jobSeekerID {
+		return nil, repository.ErrApplicationNotFound
+	}
+
+	return app, nil
+}
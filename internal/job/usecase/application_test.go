@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/agpprastyo/career-link/internal/job/usecase"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+)
+
+// stageRepo serves a single application owned by one company and one job seeker
+type stageRepo struct {
+	repository.Repository
+	app         entity.JobApplication
+	companyID   uuid.UUID
+	jobSeekerID uuid.UUID
+	updates     int
+}
+
+func (r *stageRepo) GetApplicationByID(ctx context.Context, id uuid.UUID) (*entity.JobApplication, error) {
+	if id != r.app.ID {
+		return nil, repository.ErrApplicationNotFound
+	}
+	app := r.app
+	return &app, nil
+}
+
+func (r *stageRepo) GetCompanyIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	return r.companyID, nil
+}
+
+func (r *stageRepo) GetJobSeekerIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	return r.jobSeekerID, nil
+}
+
+func (r *stageRepo) GetJobByID(ctx context.Context, id uuid.UUID) (*entity.JobPosting, error) {
+	return &entity.JobPosting{ID: id, CompanyID: r.companyID}, nil
+}
+
+func (r *stageRepo) UpdateApplicationStage(ctx context.Context, id uuid.UUID, from, to entity.ApplicationStage, changedBy uuid.UUID, note *string) (*entity.JobApplication, error) {
+	if from != r.app.Stage {
+		return nil, repository.ErrInvalidStageTransition
+	}
+	r.updates++
+	r.app.Stage = to
+	app := r.app
+	return &app, nil
+}
+
+func (r *stageRepo) GetApplicationAudience(ctx context.Context, applicationID uuid.UUID) (*entity.ApplicationAudience, error) {
+	return &entity.ApplicationAudience{}, nil
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(ctx context.Context, userIDs []uuid.UUID, notification notificationEntity.Notification) error {
+	return nil
+}
+
+func (nopNotifier) EmailEnabled(ctx context.Context, userID uuid.UUID, notificationType notificationEntity.NotificationType) (bool, error) {
+	return false, nil
+}
+
+func newStageUseCase(stage entity.ApplicationStage) (*usecase.JobUseCase, *stageRepo) {
+	repo := &stageRepo{
+		app: entity.JobApplication{
+			ID:          uuid.New(),
+			JobID:       uuid.New(),
+			JobSeekerID: uuid.New(),
+			Stage:       stage,
+		},
+		companyID: uuid.New(),
+	}
+	repo.jobSeekerID = repo.app.JobSeekerID
+
+	return usecase.NewJobUseCase(repo, nopNotifier{}, testutil.Logger()), repo
+}
+
+func TestMoveApplicationStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    entity.ApplicationStage
+		to      entity.ApplicationStage
+		wantErr error
+	}{
+		{"applied to screening", entity.StageApplied, entity.StageScreening, nil},
+		{"screening to interview", entity.StageScreening, entity.StageInterview, nil},
+		{"interview to offer", entity.StageInterview, entity.StageOffer, nil},
+		{"offer to hired", entity.StageOffer, entity.StageHired, nil},
+		{"interview to rejected", entity.StageInterview, entity.StageRejected, nil},
+		{"company cannot withdraw", entity.StageApplied, entity.StageWithdrawn, repository.ErrInvalidStageTransition},
+		{"company cannot withdraw from offer", entity.StageOffer, entity.StageWithdrawn, repository.ErrInvalidStageTransition},
+		{"cannot skip stages", entity.StageApplied, entity.StageOffer, repository.ErrInvalidStageTransition},
+		{"cannot move backwards", entity.StageInterview, entity.StageScreening, repository.ErrInvalidStageTransition},
+		{"cannot stay in place", entity.StageScreening, entity.StageScreening, repository.ErrInvalidStageTransition},
+		{"hired is final", entity.StageHired, entity.StageRejected, repository.ErrInvalidStageTransition},
+		{"rejected is final", entity.StageRejected, entity.StageScreening, repository.ErrInvalidStageTransition},
+		{"unknown stage", entity.StageApplied, entity.ApplicationStage("archived"), repository.ErrInvalidStageTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo := newStageUseCase(tt.from)
+
+			app, err := uc.MoveApplicationStage(context.Background(), uuid.New(), repo.app.ID, dto.MoveStageRequest{Stage: string(tt.to)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if repo.updates != 0 {
+					t.Errorf("stage was updated on a rejected transition")
+				}
+				return
+			}
+
+			if app.Stage != tt.to {
+				t.Errorf("got stage %s, want %s", app.Stage, tt.to)
+			}
+		})
+	}
+}
+
+func TestWithdrawApplication(t *testing.T) {
+	tests := []struct {
+		from    entity.ApplicationStage
+		wantErr error
+	}{
+		{entity.StageApplied, nil},
+		{entity.StageScreening, nil},
+		{entity.StageInterview, nil},
+		{entity.StageOffer, nil},
+		{entity.StageHired, repository.ErrInvalidStageTransition},
+		{entity.StageRejected, repository.ErrInvalidStageTransition},
+		{entity.StageWithdrawn, repository.ErrInvalidStageTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			uc, repo := newStageUseCase(tt.from)
+
+			app, err := uc.WithdrawApplication(context.Background(), uuid.New(), repo.app.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if repo.updates != 0 {
+					t.Errorf("stage was updated on a rejected transition")
+				}
+				return
+			}
+
+			if app.Stage != entity.StageWithdrawn {
+				t.Errorf("got stage %s, want %s", app.Stage, entity.StageWithdrawn)
+			}
+		})
+	}
+}
+
+func TestWithdrawApplicationRequiresOwner(t *testing.T) {
+	uc, repo := newStageUseCase(entity.StageApplied)
+	repo.jobSeekerID = uuid.New()
+
+	if _, err := uc.WithdrawApplication(context.Background(), uuid.New(), repo.app.ID); !errors.Is(err, repository.ErrApplicationNotFound) {
+		t.Fatalf("got error %v, want %v", err, repository.ErrApplicationNotFound)
+	}
+	if repo.updates != 0 {
+		t.Errorf("stage was updated by another job seeker")
+	}
+}
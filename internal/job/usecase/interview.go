@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ProposeInterview lets the company propose interview slots to a candidate in the interview stage.
+// The candidate is emailed the slots to pick from.
+func (uc *JobUseCase) ProposeInterview(ctx context.Context, userID, applicationID uuid.UUID, req dto.InterviewProposalRequest) (*entity.Interview, error) {
+	app, err := uc.getCompanyApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.Stage != entity.StageInterview {
+		return nil, repository.ErrNotInInterviewStage
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	interview := &entity.Interview{
+		ID:              id,
+		ApplicationID:   applicationID,
+		Status:          entity.InterviewProposed,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        req.Timezone,
+		Location:        optionalTrimmed(req.Location),
+		Notes:           optionalTrimmed(req.Notes),
+		ProposedBy:      &userID,
+		Slots:           make([]entity.InterviewSlot, 0, len(req.Slots)),
+	}
+	for _, startsAt := range req.Slots {
+		slotID, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			return nil, err
+		}
+		interview.Slots = append(interview.Slots, entity.InterviewSlot{
+			ID:          slotID,
+			InterviewID: id,
+			StartsAt:    startsAt.UTC(),
+		})
+	}
+
+	if err := uc.repo.CreateInterview(ctx, interview); err != nil {
+		return nil, err
+	}
+
+	uc.notifyInterview(ctx, templates.InterviewProposed, interview)
+
+	return interview, nil
+}
+
+// ListInterviewsForCompany returns the interviews of an application to the company that owns the posting
+func (uc *JobUseCase) ListInterviewsForCompany(ctx context.Context, userID, applicationID uuid.UUID) ([]entity.Interview, error) {
+	if _, err := uc.getCompanyApplication(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListInterviewsByApplication(ctx, applicationID)
+}
+
+// ListInterviewsForJobSeeker returns the interviews of an application to the job seeker who submitted it
+func (uc *JobUseCase) ListInterviewsForJobSeeker(ctx context.Context, userID, applicationID uuid.UUID) ([]entity.Interview, error) {
+	if _, err := uc.getJobSeekerApplication(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListInterviewsByApplication(ctx, applicationID)
+}
+
+// SelectInterviewSlot lets the candidate pick one of the proposed slots. Both parties are sent a
+// calendar invite.
+func (uc *JobUseCase) SelectInterviewSlot(ctx context.Context, userID, interviewID uuid.UUID, req dto.SelectInterviewSlotRequest) (*entity.Interview, error) {
+	interview, app, err := uc.getJobSeekerInterview(ctx, userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if interview.Status != entity.InterviewProposed {
+		return nil, repository.ErrInvalidInterviewStatus
+	}
+
+	if app.Stage != entity.StageInterview {
+		return nil, repository.ErrNotInInterviewStage
+	}
+
+	var slot *entity.InterviewSlot
+	for i := range interview.Slots {
+		if interview.Slots[i].ID == req.SlotID {
+			slot = &interview.Slots[i]
+			break
+		}
+	}
+	if slot == nil {
+		return nil, repository.ErrInterviewSlotNotFound
+	}
+
+	if !slot.StartsAt.After(time.Now()) {
+		return nil, repository.ErrInterviewSlotPassed
+	}
+
+	scheduled, err := uc.repo.ScheduleInterview(ctx, interviewID, req.SlotID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.notifyInterview(ctx, templates.InterviewScheduled, scheduled)
+
+	return scheduled, nil
+}
+
+// RescheduleInterview lets the company move a scheduled interview. Both parties are sent an updated
+// calendar invite that replaces the previous one.
+func (uc *JobUseCase) RescheduleInterview(ctx context.Context, userID, interviewID uuid.UUID, req dto.RescheduleInterviewRequest) (*entity.Interview, error) {
+	interview, err := uc.getCompanyInterview(ctx, userID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if interview.Status != entity.InterviewScheduled {
+		return nil, repository.ErrInvalidInterviewStatus
+	}
+
+	startsAt := req.StartsAt.UTC()
+	interview.StartsAt = &startsAt
+	if req.DurationMinutes != nil {
+		interview.DurationMinutes = *req.DurationMinutes
+	}
+	if req.Timezone != nil {
+		interview.Timezone = *req.Timezone
+	}
+	if req.Location != nil {
+		interview.Location = optionalTrimmed(*req.Location)
+	}
+
+	rescheduled, err := uc.repo.RescheduleInterview(ctx, interview)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.notifyInterview(ctx, templates.InterviewRescheduled, rescheduled)
+
+	return rescheduled, nil
+}
+
+// CancelInterviewForCompany lets the company cancel an interview of one of its postings
+func (uc *JobUseCase) CancelInterviewForCompany(ctx context.Context, userID, interviewID uuid.UUID, req dto.CancelInterviewRequest) (*entity.Interview, error) {
+	if _, err := uc.getCompanyInterview(ctx, userID, interviewID); err != nil {
+		return nil, err
+	}
+
+	return uc.cancelInterview(ctx, userID, interviewID, req)
+}
+
+// CancelInterviewForJobSeeker lets the candidate cancel one of their interviews
+func (uc *JobUseCase) CancelInterviewForJobSeeker(ctx context.Context, userID, interviewID uuid.UUID, req dto.CancelInterviewRequest) (*entity.Interview, error) {
+	if _, _, err := uc.getJobSeekerInterview(ctx, userID, interviewID); err != nil {
+		return nil, err
+	}
+
+	return uc.cancelInterview(ctx, userID, interviewID, req)
+}
+
+// cancelInterview cancels an interview on behalf of either party. Scheduled interviews are removed
+// from calendars with a cancelled invite.
+func (uc *JobUseCase) cancelInterview(ctx context.Context, userID, interviewID uuid.UUID, req dto.CancelInterviewRequest) (*entity.Interview, error) {
+	cancelled, err := uc.repo.CancelInterview(ctx, interviewID, userID, optionalTrimmed(req.Reason))
+	if err != nil {
+		return nil, err
+	}
+
+	uc.notifyInterview(ctx, templates.InterviewCancelled, cancelled)
+
+	return cancelled, nil
+}
+
+// notifyInterview emails the participants about an interview change. The change is already saved, so
+// failures are only logged.
+func (uc *JobUseCase) notifyInterview(ctx context.Context, kind string, interview *entity.Interview) {
+	log := uc.log.WithField("interview_id", interview.ID)
+
+	participants, err := uc.repo.GetInterviewParticipants(ctx, interview.ApplicationID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get interview participants")
+		return
+	}
+
+	emailCandidate, err := uc.notifier.EmailEnabled(ctx, participants.CandidateUserID, notificationEntity.NotificationInterviewUpdate)
+	if err != nil {
+		log.WithError(err).Error("Failed to get candidate notification preferences")
+	}
+
+	// The company email goes to the account that owns the company's address
+	emailCompany, err := uc.notifier.EmailEnabled(ctx, participants.CompanyUserID, notificationEntity.NotificationInterviewUpdate)
+	if err != nil {
+		log.WithError(err).Error("Failed to get company notification preferences")
+	}
+
+	if err := uc.repo.SendInterviewEmail(ctx, kind, interview, participants, emailCandidate, emailCompany); err != nil {
+		log.WithError(err).WithField("kind", kind).Error("Failed to send interview email")
+	}
+}
+
+// getCompanyInterview loads an interview of an application whose posting belongs to the user's company
+func (uc *JobUseCase) getCompanyInterview(ctx context.Context, userID, interviewID uuid.UUID) (*entity.Interview, error) {
+	interview, err := uc.repo.GetInterview(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.getCompanyApplication(ctx, userID, interview.ApplicationID); err != nil {
+		if errors.Is(err, repository.ErrApplicationNotFound) {
+			return nil, repository.ErrInterviewNotFound
+		}
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+// getJobSeekerInterview loads an interview of an application submitted by the user, with the application
+func (uc *JobUseCase) getJobSeekerInterview(ctx context.Context, userID, interviewID uuid.UUID) (*entity.Interview, *entity.JobApplication, error) {
+	interview, err := uc.repo.GetInterview(ctx, interviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	app, err := uc.getJobSeekerApplication(ctx, userID, interview.ApplicationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrApplicationNotFound) {
+			return nil, nil, repository.ErrInterviewNotFound
+		}
+		return nil, nil, err
+	}
+
+	return interview, app, nil
+}
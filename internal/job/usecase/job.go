@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// CreateJob creates a new draft job posting for the company owned by the user
+func (uc *JobUseCase) CreateJob(ctx context.Context, userID uuid.UUID, req dto.JobRequest) (*entity.JobPosting, error) {
+	companyID, err := uc.repo.GetCompanyIDByUserID(ctx, userID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to resolve company for job posting")
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	job := &entity.JobPosting{
+		ID:        id,
+		CompanyID: companyID,
+		Status:    entity.JobStatusDraft,
+	}
+	applyJobRequest(job, req)
+
+	if err := uc.repo.CreateJob(ctx, job); err != nil {
+		uc.log.WithError(err).Error("Failed to create job posting")
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// UpdateJob replaces the editable fields of a job posting owned by the user
+func (uc *JobUseCase) UpdateJob(ctx context.Context, userID, jobID uuid.UUID, req dto.JobRequest) (*entity.JobPosting, error) {
+	job, err := uc.getOwnedJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == entity.JobStatusClosed {
+		return nil, repository.ErrJobClosed
+	}
+
+	applyJobRequest(job, req)
+
+	if err := uc.repo.UpdateJob(ctx, job); err != nil {
+		uc.log.WithError(err).WithField("job_id", jobID).Error("Failed to update job posting")
+		return nil, err
+	}
+
+	if job.Status == entity.JobStatusPublished {
+		uc.invalidateRecommendations(ctx)
+	}
+
+	return job, nil
+}
+
+// unverifiedPublishedJobLimit is how many postings a company can have published before it is verified
+const unverifiedPublishedJobLimit = 1
+
+// PublishJob makes a job posting visible to job seekers. Companies that are not verified yet can only
+// keep a limited number of postings published at once.
+func (uc *JobUseCase) PublishJob(ctx context.Context, userID, jobID uuid.UUID) (*entity.JobPosting, error) {
+	job, err := uc.getOwnedJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked first so a posting that cannot be published is not reported as over the limit. Both are
+	// checked again with the company locked.
+	if !job.CanTransitionTo(entity.JobStatusPublished) {
+		return nil, repository.ErrInvalidStatusTransition
+	}
+
+	published, err := uc.repo.PublishJob(ctx, job.CompanyID, jobID, unverifiedPublishedJobLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.invalidateRecommendations(ctx)
+
+	return published, nil
+}
+
+// UnpublishJob moves a published job posting back to draft
+func (uc *JobUseCase) UnpublishJob(ctx context.Context, userID, jobID uuid.UUID) (*entity.JobPosting, error) {
+	return uc.changeJobStatus(ctx, userID, jobID, entity.JobStatusDraft)
+}
+
+// CloseJob stops a job posting from receiving applications, closed postings can no longer change
+func (uc *JobUseCase) CloseJob(ctx context.Context, userID, jobID uuid.UUID) (*entity.JobPosting, error) {
+	return uc.changeJobStatus(ctx, userID, jobID, entity.JobStatusClosed)
+}
+
+// DeleteJob removes a draft job posting owned by the user that never received an application
+func (uc *JobUseCase) DeleteJob(ctx context.Context, userID, jobID uuid.UUID) error {
+	job, err := uc.getOwnedJob(ctx, userID, jobID)
+	if err != nil {
+		return err
+	}
+
+	// Applications, their history and everything attached to them would go with the posting
+	if job.Status != entity.JobStatusDraft {
+		return repository.ErrJobNotDeletable
+	}
+
+	if err := uc.repo.DeleteJob(ctx, jobID); err != nil {
+		if !errors.Is(err, repository.ErrJobNotDeletable) {
+			uc.log.WithError(err).WithField("job_id", jobID).Error("Failed to delete job posting")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetPublishedJob returns a job posting visible to the public, drafts are reported as not found
+func (uc *JobUseCase) GetPublishedJob(ctx context.Context, jobID uuid.UUID) (*entity.JobPosting, error) {
+	job, err := uc.repo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == entity.JobStatusDraft {
+		return nil, repository.ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+// ListPublishedJobs retrieves a paginated list of published job postings
+func (uc *JobUseCase) ListPublishedJobs(ctx context.Context, paging pagination.Pagination) (pagination.PageResponse, error) {
+	jobs, total, err := uc.repo.ListPublishedJobs(ctx, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(jobs, paging, total), nil
+}
+
+// ListCompanyJobs retrieves a paginated list of every job posting owned by the user's company
+func (uc *JobUseCase) ListCompanyJobs(ctx context.Context, userID uuid.UUID, paging pagination.Pagination) (pagination.PageResponse, error) {
+	companyID, err := uc.repo.GetCompanyIDByUserID(ctx, userID)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	jobs, total, err := uc.repo.ListJobsByCompany(ctx, companyID, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(jobs, paging, total), nil
+}
+
+func (uc *JobUseCase) changeJobStatus(ctx context.Context, userID, jobID uuid.UUID, status entity.JobStatus) (*entity.JobPosting, error) {
+	job, err := uc.getOwnedJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !job.CanTransitionTo(status) {
+		return nil, repository.ErrInvalidStatusTransition
+	}
+
+	updated, err := uc.repo.UpdateJobStatus(ctx, jobID, status)
+	if err != nil {
+		uc.log.WithError(err).WithField("job_id", jobID).Error("Failed to update job posting status")
+		return nil, err
+	}
+
+	if job.Status == entity.JobStatusPublished || status == entity.JobStatusPublished {
+		uc.invalidateRecommendations(ctx)
+	}
+
+	return updated, nil
+}
+
+// getOwnedJob loads a job posting and makes sure it belongs to the user's company.
+// Postings of other companies are reported as not found so their existence is not leaked.
+func (uc *JobUseCase) getOwnedJob(ctx context.Context, userID, jobID uuid.UUID) (*entity.JobPosting, error) {
+	companyID, err := uc.repo.GetCompanyIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := uc.repo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.CompanyID != companyID {
+		return nil, repository.ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+func applyJobRequest(job *entity.JobPosting, req dto.JobRequest) {
+	skills := make([]string, 0, len(req.RequiredSkills))
+	for _, skill := range req.RequiredSkills {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+
+	job.Title = strings.TrimSpace(req.Title)
+	job.Description = req.Description
+	job.EmploymentType = entity.EmploymentType(req.EmploymentType)
+	job.Location = strings.TrimSpace(req.Location)
+	job.IsRemote = req.IsRemote
+	job.SalaryMin = req.SalaryMin
+	job.SalaryMax = req.SalaryMax
+	job.RequiredSkills = skills
+	job.ApplicationDeadline = req.ApplicationDeadline
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// SendMessage posts a message with optional attachments to the thread of an application on behalf of
+// the side the user acts for
+func (uc *JobUseCase) SendMessage(ctx context.Context, userID uuid.UUID, side entity.ThreadSide, applicationID uuid.UUID, req dto.SendMessageRequest) (*entity.ApplicationMessage, error) {
+	if _, err := uc.getThreadApplication(ctx, userID, side, applicationID); err != nil {
+		return nil, err
+	}
+
+	messageID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	msg := &entity.ApplicationMessage{
+		ID:            messageID,
+		ApplicationID: applicationID,
+		SenderID:      &userID,
+		SenderRole:    side,
+		Body:          strings.TrimSpace(req.Body),
+		Attachments:   make([]entity.MessageAttachment, 0, len(req.Attachments)),
+	}
+
+	for _, upload := range req.Attachments {
+		attachmentID, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			uc.deleteMessageAttachments(msg.Attachments)
+			return nil, err
+		}
+
+		attachment := entity.MessageAttachment{
+			ID:          attachmentID,
+			MessageID:   messageID,
+			FileName:    upload.FileName,
+			ObjectName:  fmt.Sprintf("messages/%s/%s/%s%s", applicationID, messageID, attachmentID, upload.FileExt),
+			ContentType: upload.ContentType,
+			SizeBytes:   int64(len(upload.FileContent)),
+		}
+
+		if err := uc.repo.UploadMessageAttachmentFile(ctx, attachment.ObjectName, bytes.NewReader(upload.FileContent), attachment.SizeBytes, attachment.ContentType); err != nil {
+			uc.deleteMessageAttachments(msg.Attachments)
+			return nil, err
+		}
+		msg.Attachments = append(msg.Attachments, attachment)
+	}
+
+	if err := uc.repo.CreateMessage(ctx, msg); err != nil {
+		uc.deleteMessageAttachments(msg.Attachments)
+		return nil, err
+	}
+
+	uc.notifyMessageReceived(ctx, msg)
+
+	if err := uc.resolveAttachmentURLs(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// ListMessages retrieves a page of the thread of an application, newest first. Attachments carry a
+// temporary download URL.
+func (uc *JobUseCase) ListMessages(ctx context.Context, userID uuid.UUID, side entity.ThreadSide, applicationID uuid.UUID, paging pagination.Pagination) (pagination.PageResponse, error) {
+	if _, err := uc.getThreadApplication(ctx, userID, side, applicationID); err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	messages, total, err := uc.repo.ListMessages(ctx, applicationID, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	for i := range messages {
+		if err := uc.resolveAttachmentURLs(ctx, &messages[i]); err != nil {
+			return pagination.PageResponse{}, err
+		}
+	}
+
+	return pagination.NewResponse(messages, paging, total), nil
+}
+
+// MarkThreadRead marks the thread of an application read by the user, which clears their unread count
+// and sets the read receipts of the other side's messages
+func (uc *JobUseCase) MarkThreadRead(ctx context.Context, userID uuid.UUID, side entity.ThreadSide, applicationID uuid.UUID) error {
+	if _, err := uc.getThreadApplication(ctx, userID, side, applicationID); err != nil {
+		return err
+	}
+
+	return uc.repo.MarkThreadRead(ctx, applicationID, userID, side, time.Now())
+}
+
+// GetUnreadMessages returns how many messages from the other side the user has not read, per thread
+func (uc *JobUseCase) GetUnreadMessages(ctx context.Context, userID uuid.UUID, side entity.ThreadSide) (*dto.UnreadMessagesResponse, error) {
+	var threads []entity.UnreadThread
+	switch side {
+	case entity.SideCompany:
+		companyID, err := uc.repo.GetCompanyIDByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if threads, err = uc.repo.ListUnreadThreadsForCompany(ctx, companyID, userID); err != nil {
+			return nil, err
+		}
+	default:
+		jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if threads, err = uc.repo.ListUnreadThreadsForJobSeeker(ctx, jobSeekerID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &dto.UnreadMessagesResponse{Threads: threads}
+	for _, thread := range threads {
+		response.Total += thread.Unread
+	}
+
+	return response, nil
+}
+
+// getThreadApplication loads an application whose thread the user may access: the applicant, or any
+// member of the company that owns the posting
+func (uc *JobUseCase) getThreadApplication(ctx context.Context, userID uuid.UUID, side entity.ThreadSide, applicationID uuid.UUID) (*entity.JobApplication, error) {
+	if side == entity.SideCompany {
+		return uc.getCompanyApplication(ctx, userID, applicationID)
+	}
+	return uc.getJobSeekerApplication(ctx, userID, applicationID)
+}
+
+// resolveAttachmentURLs sets the temporary download URL of every attachment of a message
+func (uc *JobUseCase) resolveAttachmentURLs(ctx context.Context, msg *entity.ApplicationMessage) error {
+	for i := range msg.Attachments {
+		url, err := uc.repo.GetMessageAttachmentURL(ctx, msg.Attachments[i].ObjectName)
+		if err != nil {
+			return err
+		}
+		msg.Attachments[i].URL = url
+	}
+
+	return nil
+}
+
+// deleteMessageAttachments removes uploaded attachments of a message that could not be created
+func (uc *JobUseCase) deleteMessageAttachments(attachments []entity.MessageAttachment) {
+	if len(attachments) == 0 {
+		return
+	}
+
+	go func() {
+		bgCtx := context.Background()
+		for _, attachment := range attachments {
+			if err := uc.repo.DeleteMessageAttachmentFile(bgCtx, attachment.ObjectName); err != nil {
+				uc.log.WithError(err).Error("Failed to delete message attachment")
+			}
+		}
+	}()
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/agpprastyo/career-link/internal/job/usecase"
+	"github.com/google/uuid"
+)
+
+// threadRepo serves one application to a posting of one company. Users resolve to the company they
+// are a member of or to their job seeker profile.
+type threadRepo struct {
+	repository.Repository
+	app        entity.JobApplication
+	companyID  uuid.UUID
+	companies  map[uuid.UUID]uuid.UUID // Company of each company member
+	jobSeekers map[uuid.UUID]uuid.UUID // Job seeker profile of each job seeker user
+	readBy     []uuid.UUID
+}
+
+func (r *threadRepo) GetApplicationByID(ctx context.Context, id uuid.UUID) (*entity.JobApplication, error) {
+	if id != r.app.ID {
+		return nil, repository.ErrApplicationNotFound
+	}
+	app := r.app
+	return &app, nil
+}
+
+func (r *threadRepo) GetCompanyIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	companyID, ok := r.companies[userID]
+	if !ok {
+		return uuid.Nil, repository.ErrCompanyNotFound
+	}
+	return companyID, nil
+}
+
+func (r *threadRepo) GetJobSeekerIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	jobSeekerID, ok := r.jobSeekers[userID]
+	if !ok {
+		return uuid.Nil, repository.ErrJobSeekerNotFound
+	}
+	return jobSeekerID, nil
+}
+
+func (r *threadRepo) GetJobByID(ctx context.Context, id uuid.UUID) (*entity.JobPosting, error) {
+	return &entity.JobPosting{ID: id, CompanyID: r.companyID}, nil
+}
+
+func (r *threadRepo) MarkThreadRead(ctx context.Context, applicationID, userID uuid.UUID, side entity.ThreadSide, now time.Time) error {
+	r.readBy = append(r.readBy, userID)
+	return nil
+}
+
+func TestMessageThreadAccess(t *testing.T) {
+	applicant, recruiter, otherSeeker, otherRecruiter := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	companyID, applicantProfile := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		side    entity.ThreadSide
+		wantErr error
+	}{
+		{"applicant", applicant, entity.SideJobSeeker, nil},
+		{"member of the hiring company", recruiter, entity.SideCompany, nil},
+		{"another job seeker", otherSeeker, entity.SideJobSeeker, repository.ErrApplicationNotFound},
+		{"member of another company", otherRecruiter, entity.SideCompany, repository.ErrApplicationNotFound},
+		{"company account without a company", uuid.New(), entity.SideCompany, repository.ErrCompanyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &threadRepo{
+				app:        entity.JobApplication{ID: uuid.New(), JobID: uuid.New(), JobSeekerID: applicantProfile},
+				companyID:  companyID,
+				companies:  map[uuid.UUID]uuid.UUID{recruiter: companyID, otherRecruiter: uuid.New()},
+				jobSeekers: map[uuid.UUID]uuid.UUID{applicant: applicantProfile, otherSeeker: uuid.New()},
+			}
+			uc := usecase.NewJobUseCase(repo, nopNotifier{}, testutil.Logger())
+
+			err := uc.MarkThreadRead(context.Background(), tt.userID, tt.side, repo.app.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if wantRead := tt.wantErr == nil; (len(repo.readBy) > 0) != wantRead {
+				t.Errorf("thread read by %v", repo.readBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+)
+
+// notifyApplicationReceived tells the company team about a new applicant
+func (uc *JobUseCase) notifyApplicationReceived(ctx context.Context, applicationID uuid.UUID) {
+	uc.notifyApplication(ctx, applicationID, func(audience *entity.ApplicationAudience) ([]uuid.UUID, notificationEntity.Notification) {
+		return audience.CompanyUserIDs, notificationEntity.Notification{
+			Type:  notificationEntity.NotificationApplicationReceived,
+			Title: "New applicant",
+			Body:  fmt.Sprintf("%s applied to %s", audience.CandidateName, audience.JobTitle),
+		}
+	})
+}
+
+// notifyStageChanged tells the candidate their application moved through the pipeline
+func (uc *JobUseCase) notifyStageChanged(ctx context.Context, app *entity.JobApplication) {
+	uc.notifyApplication(ctx, app.ID, func(audience *entity.ApplicationAudience) ([]uuid.UUID, notificationEntity.Notification) {
+		return []uuid.UUID{audience.CandidateUserID}, notificationEntity.Notification{
+			Type:  notificationEntity.NotificationApplicationStatus,
+			Title: "Application update",
+			Body:  fmt.Sprintf("Your application for %s at %s moved to %s", audience.JobTitle, audience.CompanyName, app.Stage),
+		}
+	})
+}
+
+// notifyWithdrawn tells the company team a candidate withdrew
+func (uc *JobUseCase) notifyWithdrawn(ctx context.Context, app *entity.JobApplication) {
+	uc.notifyApplication(ctx, app.ID, func(audience *entity.ApplicationAudience) ([]uuid.UUID, notificationEntity.Notification) {
+		return audience.CompanyUserIDs, notificationEntity.Notification{
+			Type:  notificationEntity.NotificationApplicationStatus,
+			Title: "Application withdrawn",
+			Body:  fmt.Sprintf("%s withdrew their application to %s", audience.CandidateName, audience.JobTitle),
+		}
+	})
+}
+
+// notifyMessageReceived tells the other side of a thread about a new message
+func (uc *JobUseCase) notifyMessageReceived(ctx context.Context, msg *entity.ApplicationMessage) {
+	uc.notifyApplication(ctx, msg.ApplicationID, func(audience *entity.ApplicationAudience) ([]uuid.UUID, notificationEntity.Notification) {
+		recipients := audience.CompanyUserIDs
+		from := audience.CandidateName
+		if msg.SenderRole == entity.SideCompany {
+			recipients = []uuid.UUID{audience.CandidateUserID}
+			from = audience.CompanyName
+		}
+
+		return recipients, notificationEntity.Notification{
+			Type:  notificationEntity.NotificationMessageReceived,
+			Title: "New message from " + from,
+			Body:  fmt.Sprintf("About your application for %s", audience.JobTitle),
+		}
+	})
+}
+
+// notifyApplication notifies users on one side of an application. Failures are only logged, so a
+// notification never fails the action it reports.
+func (uc *JobUseCase) notifyApplication(ctx context.Context, applicationID uuid.UUID, build func(audience *entity.ApplicationAudience) ([]uuid.UUID, notificationEntity.Notification)) {
+	audience, err := uc.repo.GetApplicationAudience(ctx, applicationID)
+	if err != nil {
+		uc.log.WithError(err).WithField("application_id", applicationID).Error("Failed to resolve notification recipients")
+		return
+	}
+
+	recipients, notification := build(audience)
+	link := fmt.Sprintf("/applications/%s", applicationID)
+	notification.Link = &link
+
+	if err := uc.notifier.Notify(ctx, recipients, notification); err != nil {
+		uc.log.WithError(err).WithField("application_id", applicationID).Error("Failed to send notification")
+	}
+}
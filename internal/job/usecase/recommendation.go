@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+const (
+	// recommendationCandidateLimit is how many of the newest published postings are scored
+	recommendationCandidateLimit = 500
+	// maxRecommendations is how many of the best scoring postings are kept
+	maxRecommendations = 100
+)
+
+// Points each criterion adds to a recommendation score, summing to 100
+const (
+	skillsWeight     = 40
+	jobTypeWeight    = 15
+	industryWeight   = 10
+	locationWeight   = 10
+	remoteWeight     = 10
+	salaryWeight     = 10
+	experienceWeight = 5
+)
+
+// GetRecommendedJobs returns published postings ranked by how well they fit the user's skills,
+// preferences and experience. Scores are cached until the profile or the postings change.
+func (uc *JobUseCase) GetRecommendedJobs(ctx context.Context, userID uuid.UUID, paging pagination.Pagination) (pagination.PageResponse, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	jobs, ok := uc.repo.GetCachedRecommendations(ctx, jobSeekerID)
+	if !ok {
+		// Read the version first: a posting change during the build bumps it and drops this list
+		version, versionErr := uc.repo.RecommendationsVersion(ctx)
+
+		jobs, err = uc.buildRecommendations(ctx, jobSeekerID)
+		if err != nil {
+			return pagination.PageResponse{}, err
+		}
+
+		if versionErr == nil {
+			if err := uc.repo.CacheRecommendations(ctx, jobSeekerID, version, jobs); err != nil {
+				uc.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to cache recommendations")
+			}
+		}
+	}
+
+	total := len(jobs)
+	start := min(paging.CalculateOffset(), total)
+	end := min(start+paging.PageSize, total)
+
+	return pagination.NewResponse(jobs[start:end], paging, total), nil
+}
+
+// buildRecommendations scores the candidate postings against the job seeker's profile and keeps the best ones
+func (uc *JobUseCase) buildRecommendations(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.RecommendedJob, error) {
+	profile, err := uc.repo.GetRecommendationProfile(ctx, jobSeekerID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := uc.repo.ListRecommendationCandidates(ctx, jobSeekerID, recommendationCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer := newRecommendationScorer(profile)
+	jobs := make([]entity.RecommendedJob, 0, len(candidates))
+	for _, job := range candidates {
+		job.Matches = scorer.score(&job)
+		for _, match := range job.Matches {
+			job.Score += match.Points
+		}
+		if job.Score > 0 {
+			jobs = append(jobs, job)
+		}
+	}
+
+	// Candidates come newest first, so equal scores keep the newest posting on top
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Score > jobs[j].Score
+	})
+	if len(jobs) > maxRecommendations {
+		jobs = jobs[:maxRecommendations]
+	}
+
+	return jobs, nil
+}
+
+// invalidateRecommendations marks every cached recommendation list as stale after the set of
+// published postings changed. Failures only delay fresh results, so they are logged.
+func (uc *JobUseCase) invalidateRecommendations(ctx context.Context) {
+	if err := uc.repo.InvalidateRecommendations(ctx); err != nil {
+		uc.log.WithError(err).Error("Failed to invalidate job recommendations")
+	}
+}
+
+// recommendationScorer holds a job seeker profile normalised for matching
+type recommendationScorer struct {
+	skills          map[string]bool
+	preferences     []entity.RecommendationPreference
+	experienceWords map[string]bool
+}
+
+func newRecommendationScorer(profile *entity.RecommendationProfile) *recommendationScorer {
+	s := &recommendationScorer{
+		skills:          make(map[string]bool, len(profile.Skills)),
+		preferences:     profile.Preferences,
+		experienceWords: make(map[string]bool),
+	}
+	for _, skill := range profile.Skills {
+		s.skills[normalize(skill)] = true
+	}
+	for _, title := range profile.ExperienceTitles {
+		for _, word := range titleWords(title) {
+			s.experienceWords[word] = true
+		}
+	}
+	return s
+}
+
+// score returns the criteria the posting matches. Every criterion counts once, using the
+// preference that fits best.
+func (s *recommendationScorer) score(job *entity.RecommendedJob) []entity.RecommendationMatch {
+	matches := []entity.RecommendationMatch{}
+
+	if len(job.RequiredSkills) > 0 && len(s.skills) > 0 {
+		var matched []string
+		for _, skill := range job.RequiredSkills {
+			if s.skills[normalize(skill)] {
+				matched = append(matched, skill)
+			}
+		}
+		if len(matched) > 0 {
+			matches = append(matches, entity.RecommendationMatch{
+				Criterion: entity.CriterionSkills,
+				Points:    skillsWeight * len(matched) / len(job.RequiredSkills),
+				Detail:    fmt.Sprintf("You have %d of %d required skills: %s", len(matched), len(job.RequiredSkills), strings.Join(matched, ", ")),
+			})
+		}
+	}
+
+	var jobType, industry, location, remote, salary *entity.RecommendationMatch
+	for _, pref := range s.preferences {
+		if jobType == nil && pref.JobType != "" && normalize(pref.JobType) == string(job.EmploymentType) {
+			jobType = &entity.RecommendationMatch{Criterion: entity.CriterionJobType, Points: jobTypeWeight,
+				Detail: "Matches your preferred job type " + string(job.EmploymentType)}
+		}
+		if industry == nil && pref.Industry != "" && job.CompanyIndustry != nil && normalize(pref.Industry) == normalize(*job.CompanyIndustry) {
+			industry = &entity.RecommendationMatch{Criterion: entity.CriterionIndustry, Points: industryWeight,
+				Detail: "Matches your preferred industry " + *job.CompanyIndustry}
+		}
+		if location == nil && pref.Location != "" && locationMatches(pref.Location, job.Location) {
+			location = &entity.RecommendationMatch{Criterion: entity.CriterionLocation, Points: locationWeight,
+				Detail: "Located in your preferred location " + pref.Location}
+		}
+		if remote == nil && pref.Remote && job.IsRemote {
+			remote = &entity.RecommendationMatch{Criterion: entity.CriterionRemote, Points: remoteWeight,
+				Detail: "Remote, as you prefer"}
+		}
+		if salary == nil && pref.SalaryExpectation != nil {
+			if top := topOfSalaryRange(&job.JobPosting); top != nil && *top >= *pref.SalaryExpectation {
+				salary = &entity.RecommendationMatch{Criterion: entity.CriterionSalary, Points: salaryWeight,
+					Detail: fmt.Sprintf("Pays up to %d, meeting your expectation of %d", *top, *pref.SalaryExpectation)}
+			}
+		}
+	}
+	for _, match := range []*entity.RecommendationMatch{jobType, industry, location, remote, salary} {
+		if match != nil {
+			matches = append(matches, *match)
+		}
+	}
+
+	for _, word := range titleWords(job.Title) {
+		if s.experienceWords[word] {
+			matches = append(matches, entity.RecommendationMatch{
+				Criterion: entity.CriterionExperience,
+				Points:    experienceWeight,
+				Detail:    "Similar to a role in your work experience",
+			})
+			break
+		}
+	}
+
+	return matches
+}
+
+// topOfSalaryRange returns the highest salary a posting offers, or nil when it has no salary
+func topOfSalaryRange(job *entity.JobPosting) *int {
+	if job.SalaryMax != nil {
+		return job.SalaryMax
+	}
+	return job.SalaryMin
+}
+
+// locationMatches reports whether either location contains the other, ignoring case
+func locationMatches(preferred, location string) bool {
+	preferred, location = normalize(preferred), normalize(location)
+	return location != "" && (strings.Contains(location, preferred) || strings.Contains(preferred, location))
+}
+
+// titleWords splits a job title into lowercase words long enough to be meaningful
+func titleWords(title string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(normalize(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '+' || r == '#')
+	}) {
+		if len(word) >= 3 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/lib/pq"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+// pointsByCriterion sums the points of each criterion and fails when one appears twice
+func pointsByCriterion(t *testing.T, matches []entity.RecommendationMatch) map[entity.RecommendationCriterion]int {
+	t.Helper()
+
+	points := make(map[entity.RecommendationCriterion]int, len(matches))
+	for _, m := range matches {
+		if _, ok := points[m.Criterion]; ok {
+			t.Errorf("criterion %s counted more than once", m.Criterion)
+		}
+		points[m.Criterion] = m.Points
+	}
+	return points
+}
+
+func total(points map[entity.RecommendationCriterion]int) int {
+	sum := 0
+	for _, p := range points {
+		sum += p
+	}
+	return sum
+}
+
+func backendJob() *entity.RecommendedJob {
+	return &entity.RecommendedJob{
+		JobPosting: entity.JobPosting{
+			Title:          "Senior Backend Engineer",
+			EmploymentType: entity.FullTime,
+			Location:       "Jakarta Selatan",
+			IsRemote:       true,
+			SalaryMin:      intPtr(15000000),
+			SalaryMax:      intPtr(25000000),
+			RequiredSkills: pq.StringArray{"Go", "PostgreSQL", "Redis"},
+		},
+		CompanyIndustry: strPtr("Technology"),
+	}
+}
+
+func TestRecommendationWeightsSumTo100(t *testing.T) {
+	sum := skillsWeight + jobTypeWeight + industryWeight + locationWeight + remoteWeight + salaryWeight + experienceWeight
+	if sum != 100 {
+		t.Fatalf("weights sum to %d, want 100", sum)
+	}
+}
+
+func TestRecommendationScorerFullMatch(t *testing.T) {
+	scorer := newRecommendationScorer(&entity.RecommendationProfile{
+		Skills: []string{"go", " PostgreSQL ", "REDIS"},
+		Preferences: []entity.RecommendationPreference{{
+			JobType:           "Full_Time",
+			Industry:          "technology",
+			Location:          "jakarta",
+			SalaryExpectation: intPtr(20000000),
+			Remote:            true,
+		}},
+		ExperienceTitles: []string{"Backend Developer"},
+	})
+
+	points := pointsByCriterion(t, scorer.score(backendJob()))
+	want := map[entity.RecommendationCriterion]int{
+		entity.CriterionSkills:     skillsWeight,
+		entity.CriterionJobType:    jobTypeWeight,
+		entity.CriterionIndustry:   industryWeight,
+		entity.CriterionLocation:   locationWeight,
+		entity.CriterionRemote:     remoteWeight,
+		entity.CriterionSalary:     salaryWeight,
+		entity.CriterionExperience: experienceWeight,
+	}
+	for criterion, p := range want {
+		if points[criterion] != p {
+			t.Errorf("%s: got %d points, want %d", criterion, points[criterion], p)
+		}
+	}
+	if total(points) != 100 {
+		t.Errorf("got total %d, want 100", total(points))
+	}
+}
+
+func TestRecommendationScorerSkills(t *testing.T) {
+	tests := []struct {
+		name   string
+		skills []string
+		want   int
+	}{
+		{"no skills", nil, 0},
+		{"no overlap", []string{"Java"}, 0},
+		{"one of three", []string{"go"}, skillsWeight * 1 / 3},
+		{"two of three", []string{"Go", "redis", "Java"}, skillsWeight * 2 / 3},
+		{"all three", []string{"Go", "PostgreSQL", "Redis"}, skillsWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := newRecommendationScorer(&entity.RecommendationProfile{Skills: tt.skills})
+			points := pointsByCriterion(t, scorer.score(backendJob()))
+
+			got, ok := points[entity.CriterionSkills]
+			if ok != (tt.want > 0) {
+				t.Fatalf("skills match present: %v, want %v", ok, tt.want > 0)
+			}
+			if got != tt.want {
+				t.Errorf("got %d points, want %d", got, tt.want)
+			}
+		})
+	}
+
+	job := backendJob()
+	job.RequiredSkills = nil
+	scorer := newRecommendationScorer(&entity.RecommendationProfile{Skills: []string{"Go"}})
+	if points := pointsByCriterion(t, scorer.score(job)); points[entity.CriterionSkills] != 0 {
+		t.Errorf("posting without required skills scored %d skill points", points[entity.CriterionSkills])
+	}
+}
+
+func TestRecommendationScorerBestPreferencePerCriterion(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []entity.RecommendationPreference
+		want        map[entity.RecommendationCriterion]int
+	}{
+		{
+			name: "criteria are combined across preferences",
+			preferences: []entity.RecommendationPreference{
+				{JobType: "full_time"},
+				{Industry: "Technology"},
+				{Remote: true},
+			},
+			want: map[entity.RecommendationCriterion]int{
+				entity.CriterionJobType:  jobTypeWeight,
+				entity.CriterionIndustry: industryWeight,
+				entity.CriterionRemote:   remoteWeight,
+			},
+		},
+		{
+			name: "a criterion matched by several preferences counts once",
+			preferences: []entity.RecommendationPreference{
+				{Location: "Jakarta", Remote: true},
+				{Location: "jakarta selatan", Remote: true},
+				{Location: "Selatan"},
+			},
+			want: map[entity.RecommendationCriterion]int{
+				entity.CriterionLocation: locationWeight,
+				entity.CriterionRemote:   remoteWeight,
+			},
+		},
+		{
+			name: "a later preference fits when an earlier one does not",
+			preferences: []entity.RecommendationPreference{
+				{JobType: "contract", Location: "Bandung", SalaryExpectation: intPtr(30000000)},
+				{JobType: "full_time", Location: "Jakarta", SalaryExpectation: intPtr(25000000)},
+			},
+			want: map[entity.RecommendationCriterion]int{
+				entity.CriterionJobType:  jobTypeWeight,
+				entity.CriterionLocation: locationWeight,
+				entity.CriterionSalary:   salaryWeight,
+			},
+		},
+		{
+			name: "nothing fits",
+			preferences: []entity.RecommendationPreference{
+				{JobType: "internship", Industry: "Finance", Location: "Surabaya", SalaryExpectation: intPtr(30000000)},
+			},
+			want: map[entity.RecommendationCriterion]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := newRecommendationScorer(&entity.RecommendationProfile{Preferences: tt.preferences})
+			points := pointsByCriterion(t, scorer.score(backendJob()))
+
+			if len(points) != len(tt.want) {
+				t.Errorf("got criteria %v, want %v", points, tt.want)
+			}
+			for criterion, p := range tt.want {
+				if points[criterion] != p {
+					t.Errorf("%s: got %d points, want %d", criterion, points[criterion], p)
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendationScorerSalary(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max *int
+		expected int
+		want     bool
+	}{
+		{"maximum meets expectation", intPtr(10), intPtr(20), 20, true},
+		{"maximum below expectation", intPtr(10), intPtr(20), 21, false},
+		{"only minimum, meets", intPtr(20), nil, 15, true},
+		{"only minimum, below", intPtr(20), nil, 25, false},
+		{"no salary", nil, nil, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := backendJob()
+			job.SalaryMin, job.SalaryMax = tt.min, tt.max
+
+			scorer := newRecommendationScorer(&entity.RecommendationProfile{
+				Preferences: []entity.RecommendationPreference{{SalaryExpectation: intPtr(tt.expected)}},
+			})
+			_, ok := pointsByCriterion(t, scorer.score(job))[entity.CriterionSalary]
+			if ok != tt.want {
+				t.Errorf("got salary match %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendationScorerExperienceCountsOnce(t *testing.T) {
+	scorer := newRecommendationScorer(&entity.RecommendationProfile{
+		ExperienceTitles: []string{"Senior Engineer", "Backend Lead"},
+	})
+
+	points := pointsByCriterion(t, scorer.score(backendJob()))
+	if points[entity.CriterionExperience] != experienceWeight {
+		t.Errorf("got %d experience points, want %d", points[entity.CriterionExperience], experienceWeight)
+	}
+
+	// Words shorter than three letters are ignored
+	scorer = newRecommendationScorer(&entity.RecommendationProfile{ExperienceTitles: []string{"QA"}})
+	job := backendJob()
+	job.Title = "QA Analyst"
+	if _, ok := pointsByCriterion(t, scorer.score(job))[entity.CriterionExperience]; ok {
+		t.Error("short title word matched experience")
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// deadlineWarningWindow is how long before an application deadline saved postings are flagged and
+	// their job seekers reminded
+	deadlineWarningWindow = 48 * time.Hour
+	// deadlineReminderBatch is how many saved postings a single reminder run claims at once
+	deadlineReminderBatch = 200
+)
+
+// SaveJob bookmarks a published posting for the user
+func (uc *JobUseCase) SaveJob(ctx context.Context, userID, jobID uuid.UUID) error {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	job, err := uc.GetPublishedJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.Status != entity.JobStatusPublished {
+		return repository.ErrJobNotOpen
+	}
+
+	return uc.repo.SaveJob(ctx, jobSeekerID, jobID)
+}
+
+// UnsaveJob removes a bookmarked posting of the user
+func (uc *JobUseCase) UnsaveJob(ctx context.Context, userID, jobID uuid.UUID) error {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.UnsaveJob(ctx, jobSeekerID, jobID)
+}
+
+// ListSavedJobs retrieves the postings the user saved, flagging the ones that closed or close soon
+func (uc *JobUseCase) ListSavedJobs(ctx context.Context, userID uuid.UUID, paging pagination.Pagination) (pagination.PageResponse, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	now := time.Now()
+	jobs, total, err := uc.repo.ListSavedJobs(ctx, jobSeekerID, now, now.Add(deadlineWarningWindow), paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(jobs, paging, total), nil
+}
+
+// SendDeadlineReminders emails job seekers about saved postings they have not applied to whose
+// application deadline is near, one email per job seeker. It runs as a scheduled job.
+func (uc *JobUseCase) SendDeadlineReminders(ctx context.Context) error {
+	for {
+		reminders, err := uc.repo.ClaimDeadlineReminders(ctx, time.Now(), deadlineWarningWindow, deadlineReminderBatch)
+		if err != nil {
+			return err
+		}
+
+		// Group the claimed postings by job seeker, keeping the claim order
+		var order []uuid.UUID
+		byJobSeeker := make(map[uuid.UUID][]entity.SavedJobReminder)
+		for _, reminder := range reminders {
+			if _, ok := byJobSeeker[reminder.JobSeekerID]; !ok {
+				order = append(order, reminder.JobSeekerID)
+			}
+			byJobSeeker[reminder.JobSeekerID] = append(byJobSeeker[reminder.JobSeekerID], reminder)
+		}
+
+		failed := false
+		for _, jobSeekerID := range order {
+			if !uc.sendDeadlineReminder(ctx, byJobSeeker[jobSeekerID]) {
+				failed = true
+			}
+		}
+
+		// Released reminders are due again at once, so leave them and the rest of the backlog to the next run
+		if failed || len(reminders) < deadlineReminderBatch || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// sendDeadlineReminder sends the reminders of one job seeker, releasing them when the email cannot be
+// sent. It reports whether the email was sent.
+func (uc *JobUseCase) sendDeadlineReminder(ctx context.Context, reminders []entity.SavedJobReminder) bool {
+	enabled, err := uc.notifier.EmailEnabled(ctx, reminders[0].UserID, notificationEntity.NotificationDeadlineReminder)
+	if err == nil && enabled {
+		err = uc.repo.SendDeadlineReminderEmail(ctx, reminders)
+	}
+	if err == nil {
+		return true
+	}
+
+	log := uc.log.WithField("job_seeker_id", reminders[0].JobSeekerID)
+	log.WithError(err).Error("Failed to send saved job deadline reminder")
+	for _, reminder := range reminders {
+		if err := uc.repo.ReleaseDeadlineReminder(context.WithoutCancel(ctx), reminder); err != nil {
+			log.WithError(err).Error("Failed to release saved job deadline reminder")
+		}
+	}
+	return false
+}
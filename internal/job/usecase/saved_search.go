@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSavedSearches is how many saved searches a job seeker can keep
+	maxSavedSearches = 20
+	// savedSearchAlertBatch is how many due alerts a single run claims at once
+	savedSearchAlertBatch = 100
+	// savedSearchAlertJobs is how many postings an alert email lists
+	savedSearchAlertJobs = 20
+)
+
+// CreateSavedSearch saves a job search of the user with its alert settings
+func (uc *JobUseCase) CreateSavedSearch(ctx context.Context, userID uuid.UUID, req dto.SavedSearchRequest) (*entity.SavedSearch, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.repo.CountSavedSearches(ctx, jobSeekerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedSearches {
+		return nil, repository.ErrSavedSearchLimitReached
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate unsubscribe token")
+		return nil, err
+	}
+
+	search := &entity.SavedSearch{
+		ID:               id,
+		JobSeekerID:      jobSeekerID,
+		UnsubscribeToken: token,
+	}
+	applySavedSearchRequest(search, req)
+
+	if err := uc.repo.CreateSavedSearch(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// ListSavedSearches retrieves the saved searches of the user
+func (uc *JobUseCase) ListSavedSearches(ctx context.Context, userID uuid.UUID) ([]entity.SavedSearch, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListSavedSearches(ctx, jobSeekerID)
+}
+
+// UpdateSavedSearch replaces the filters and alert settings of a saved search owned by the user
+func (uc *JobUseCase) UpdateSavedSearch(ctx context.Context, userID, searchID uuid.UUID, req dto.SavedSearchRequest) (*entity.SavedSearch, error) {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := uc.repo.GetSavedSearch(ctx, searchID, jobSeekerID)
+	if err != nil {
+		return nil, err
+	}
+
+	applySavedSearchRequest(search, req)
+
+	if err := uc.repo.UpdateSavedSearch(ctx, search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch removes a saved search owned by the user
+func (uc *JobUseCase) DeleteSavedSearch(ctx context.Context, userID, searchID uuid.UUID) error {
+	jobSeekerID, err := uc.repo.GetJobSeekerIDByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.DeleteSavedSearch(ctx, searchID, jobSeekerID)
+}
+
+// GetSavedSearchByUnsubscribeToken returns the saved search an alert email was sent for, without
+// changing it
+func (uc *JobUseCase) GetSavedSearchByUnsubscribeToken(ctx context.Context, token string) (*entity.SavedSearch, error) {
+	return uc.repo.GetSavedSearchByUnsubscribeToken(ctx, token)
+}
+
+// UnsubscribeSavedSearch turns off the alerts of the saved search an alert email was sent for
+func (uc *JobUseCase) UnsubscribeSavedSearch(ctx context.Context, token string) (*entity.SavedSearch, error) {
+	return uc.repo.UnsubscribeSavedSearch(ctx, token)
+}
+
+// SendSavedSearchAlerts emails every saved search that is due the postings published since its last
+// alert. Searches without new postings are skipped silently. It runs as a scheduled job.
+func (uc *JobUseCase) SendSavedSearchAlerts(ctx context.Context) error {
+	for {
+		alerts, err := uc.repo.ClaimDueSavedSearchAlerts(ctx, time.Now(), savedSearchAlertBatch)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for _, alert := range alerts {
+			if !uc.sendSavedSearchAlert(ctx, alert) {
+				failed = true
+			}
+		}
+
+		// Released alerts are due again at once, so leave them and the rest of the backlog to the next run
+		if failed || len(alerts) < savedSearchAlertBatch || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// sendSavedSearchAlert sends one claimed alert. On failure the claim is released so the postings are
+// sent by a later run instead of being lost. It reports whether the alert was handled.
+func (uc *JobUseCase) sendSavedSearchAlert(ctx context.Context, alert entity.SavedSearchAlert) bool {
+	log := uc.log.WithField("saved_search_id", alert.ID)
+
+	filter := alert.Filter()
+	filter.PublishedAfter = &alert.Since
+	paging := pagination.Pagination{Page: 1, PageSize: savedSearchAlertJobs}
+
+	enabled, err := uc.notifier.EmailEnabled(ctx, alert.UserID, notificationEntity.NotificationJobAlert)
+	if err == nil && enabled {
+		var jobs []entity.JobSearchResult
+		var total int
+		jobs, total, err = uc.repo.SearchJobs(ctx, filter, paging)
+		if err == nil && total > 0 {
+			err = uc.repo.SendJobAlertEmail(ctx, alert, jobs, total)
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	log.WithError(err).Error("Failed to send saved search alert")
+	if err := uc.repo.ReleaseSavedSearchAlert(context.WithoutCancel(ctx), alert.ID, alert.Since); err != nil {
+		log.WithError(err).Error("Failed to release saved search alert")
+	}
+	return false
+}
+
+func applySavedSearchRequest(search *entity.SavedSearch, req dto.SavedSearchRequest) {
+	search.Name = strings.TrimSpace(req.Name)
+	search.Query = optionalTrimmed(req.Query)
+	search.Location = optionalTrimmed(req.Location)
+	search.IsRemote = req.IsRemote
+	search.EmploymentTypes = trimmedList(req.EmploymentTypes)
+	search.SalaryMin = req.SalaryMin
+	search.SalaryMax = req.SalaryMax
+	search.Industries = trimmedList(req.Industries)
+	search.CompanySizes = trimmedList(req.CompanySizes)
+	search.AlertFrequency = entity.AlertFrequency(req.AlertFrequency)
+	search.AlertsEnabled = req.AlertsEnabled == nil || *req.AlertsEnabled
+}
+
+// optionalTrimmed returns nil for a blank value
+func optionalTrimmed(value string) *string {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return &value
+}
+
+// trimmedList trims every item and drops blank ones
+func trimmedList(values []string) []string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			items = append(items, value)
+		}
+	}
+	return items
+}
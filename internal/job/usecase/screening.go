@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// rejectionEmailBatch is how many rejection emails a single run claims at once
+const rejectionEmailBatch = 100
+
+// GetScreening returns the screening questions and knockout settings of a posting owned by the user's company
+func (uc *JobUseCase) GetScreening(ctx context.Context, userID, jobID uuid.UUID) (*entity.Screening, error) {
+	if _, err := uc.getOwnedJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetScreening(ctx, jobID)
+}
+
+// ReplaceScreening replaces the screening questions and knockout settings of a posting owned by the
+// user's company. Questions are locked once the posting received an application.
+func (uc *JobUseCase) ReplaceScreening(ctx context.Context, userID, jobID uuid.UUID, req dto.ScreeningRequest) (*entity.Screening, error) {
+	job, err := uc.getOwnedJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == entity.JobStatusClosed {
+		return nil, repository.ErrJobClosed
+	}
+
+	screening := &entity.Screening{
+		Questions:                make([]entity.ScreeningQuestion, 0, len(req.Questions)),
+		RejectionEmailDelayHours: req.RejectionEmailDelayHours,
+	}
+	for i, q := range req.Questions {
+		id, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			return nil, err
+		}
+
+		screening.Questions = append(screening.Questions, entity.ScreeningQuestion{
+			ID:              id,
+			JobID:           jobID,
+			Position:        i + 1,
+			Question:        strings.TrimSpace(q.Question),
+			Type:            entity.ScreeningQuestionType(q.Type),
+			Options:         trimmedList(q.Options),
+			Required:        q.Required == nil || *q.Required,
+			KnockoutAnswers: trimmedList(q.KnockoutAnswers),
+			MinAccepted:     q.MinAccepted,
+			MaxAccepted:     q.MaxAccepted,
+		})
+	}
+
+	if err := uc.repo.ReplaceScreening(ctx, jobID, screening); err != nil {
+		return nil, err
+	}
+
+	return screening, nil
+}
+
+// GetPublicScreeningQuestions returns the screening questions of a visible posting without the
+// knockout rules, so applicants know what to answer
+func (uc *JobUseCase) GetPublicScreeningQuestions(ctx context.Context, jobID uuid.UUID) ([]dto.PublicScreeningQuestion, error) {
+	if _, err := uc.GetPublishedJob(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	screening, err := uc.repo.GetScreening(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]dto.PublicScreeningQuestion, 0, len(screening.Questions))
+	for _, q := range screening.Questions {
+		questions = append(questions, dto.PublicScreeningQuestion{
+			ID:       q.ID,
+			Question: q.Question,
+			Type:     q.Type,
+			Options:  q.Options,
+			Required: q.Required,
+		})
+	}
+
+	return questions, nil
+}
+
+// SendRejectionEmails emails applicants rejected by a knockout question once their delay passed.
+// It runs as a scheduled job.
+func (uc *JobUseCase) SendRejectionEmails(ctx context.Context) error {
+	for {
+		emails, err := uc.repo.ClaimDueRejectionEmails(ctx, time.Now(), rejectionEmailBatch)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for _, email := range emails {
+			// Applicants who turned these emails off are skipped, their claim is simply dropped
+			enabled, err := uc.notifier.EmailEnabled(ctx, email.UserID, notificationEntity.NotificationApplicationStatus)
+			if err == nil && enabled {
+				err = uc.repo.SendRejectionEmail(ctx, email)
+			}
+			if err != nil {
+				failed = true
+				log := uc.log.WithField("application_id", email.ApplicationID)
+				log.WithError(err).Error("Failed to send rejection email")
+				if err := uc.repo.ReleaseRejectionEmail(context.WithoutCancel(ctx), email); err != nil {
+					log.WithError(err).Error("Failed to release rejection email")
+				}
+			}
+		}
+
+		// Released emails are due again at once, so leave them and the rest of the backlog to the next run
+		if failed || len(emails) < rejectionEmailBatch || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// evaluateScreeningAnswers checks the answers of an application against the screening questions of
+// the posting. It returns the answers to store and the first question whose knockout rule failed.
+func evaluateScreeningAnswers(questions []entity.ScreeningQuestion, answers []dto.ScreeningAnswerRequest) ([]entity.ApplicationAnswer, *entity.ScreeningQuestion, error) {
+	known := make(map[uuid.UUID]bool, len(questions))
+	for _, q := range questions {
+		known[q.ID] = true
+	}
+
+	given := make(map[uuid.UUID]string, len(answers))
+	for _, a := range answers {
+		if !known[a.QuestionID] {
+			return nil, nil, fmt.Errorf("%w: question %s does not belong to this job", repository.ErrInvalidScreeningAnswer, a.QuestionID)
+		}
+		if _, ok := given[a.QuestionID]; ok {
+			return nil, nil, fmt.Errorf("%w: question %s is answered more than once", repository.ErrInvalidScreeningAnswer, a.QuestionID)
+		}
+		given[a.QuestionID] = strings.TrimSpace(a.Answer)
+	}
+
+	var stored []entity.ApplicationAnswer
+	var knockout *entity.ScreeningQuestion
+	for i := range questions {
+		q := &questions[i]
+
+		answer, ok := given[q.ID]
+		if !ok || answer == "" {
+			if q.Required {
+				return nil, nil, fmt.Errorf("%w: %q must be answered", repository.ErrInvalidScreeningAnswer, q.Question)
+			}
+			continue
+		}
+
+		if !q.ValidAnswer(answer) {
+			return nil, nil, fmt.Errorf("%w: %q has an invalid answer", repository.ErrInvalidScreeningAnswer, q.Question)
+		}
+
+		knockedOut := q.KnocksOut(answer)
+		if knockedOut && knockout == nil {
+			knockout = q
+		}
+
+		stored = append(stored, entity.ApplicationAnswer{
+			QuestionID: q.ID,
+			Question:   q.Question,
+			Type:       q.Type,
+			Answer:     answer,
+			KnockedOut: knockedOut,
+		})
+	}
+
+	return stored, knockout, nil
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestEvaluateScreeningAnswers(t *testing.T) {
+	minYears := 3.0
+	relocate := entity.ScreeningQuestion{ID: uuid.New(), Question: "Can you relocate?", Type: entity.QuestionYesNo, Required: true, KnockoutAnswers: pq.StringArray{"no"}}
+	setup := entity.ScreeningQuestion{ID: uuid.New(), Question: "Preferred setup", Type: entity.QuestionMultipleChoice, Options: pq.StringArray{"Remote", "Hybrid"}, Required: true, KnockoutAnswers: pq.StringArray{"Hybrid"}}
+	years := entity.ScreeningQuestion{ID: uuid.New(), Question: "Years of Go", Type: entity.QuestionNumeric, Required: true, MinAccepted: &minYears}
+	notes := entity.ScreeningQuestion{ID: uuid.New(), Question: "Anything else?", Type: entity.QuestionFreeText}
+	questions := []entity.ScreeningQuestion{relocate, setup, years, notes}
+
+	answer := func(q entity.ScreeningQuestion, a string) dto.ScreeningAnswerRequest {
+		return dto.ScreeningAnswerRequest{QuestionID: q.ID, Answer: a}
+	}
+
+	tests := []struct {
+		name         string
+		answers      []dto.ScreeningAnswerRequest
+		wantErr      error
+		wantStored   int
+		wantKnockout *uuid.UUID
+		// Answers flagged as knocked out; every failing answer is flagged, not only the first
+		wantKnockedOut int
+	}{
+		{
+			name:       "all answered and passing",
+			answers:    []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "5"), answer(notes, "Thanks")},
+			wantStored: 4,
+		},
+		{
+			name:       "optional question left out",
+			answers:    []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "3")},
+			wantStored: 3,
+		},
+		{
+			name:       "optional question left blank",
+			answers:    []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "3"), answer(notes, "   ")},
+			wantStored: 3,
+		},
+		{
+			name:           "yes/no knockout",
+			answers:        []dto.ScreeningAnswerRequest{answer(relocate, "no"), answer(setup, "Remote"), answer(years, "5")},
+			wantStored:     3,
+			wantKnockout:   &relocate.ID,
+			wantKnockedOut: 1,
+		},
+		{
+			name:           "multiple choice knockout",
+			answers:        []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Hybrid"), answer(years, "5")},
+			wantStored:     3,
+			wantKnockout:   &setup.ID,
+			wantKnockedOut: 1,
+		},
+		{
+			name:           "numeric below minimum",
+			answers:        []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "2.5")},
+			wantStored:     3,
+			wantKnockout:   &years.ID,
+			wantKnockedOut: 1,
+		},
+		{
+			name:           "first failing question is reported",
+			answers:        []dto.ScreeningAnswerRequest{answer(years, "1"), answer(setup, "Hybrid"), answer(relocate, "yes")},
+			wantStored:     3,
+			wantKnockout:   &setup.ID,
+			wantKnockedOut: 2,
+		},
+		{
+			name:       "answers are trimmed",
+			answers:    []dto.ScreeningAnswerRequest{answer(relocate, " yes "), answer(setup, "Remote\n"), answer(years, " 4 ")},
+			wantStored: 3,
+		},
+		{
+			name:    "required question missing",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "required question blank",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, ""), answer(setup, "Remote"), answer(years, "5")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "invalid yes/no answer",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "sure"), answer(setup, "Remote"), answer(years, "5")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "unlisted option",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "On site"), answer(years, "5")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "non numeric answer",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "five")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "duplicate answer",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(relocate, "no"), answer(setup, "Remote"), answer(years, "5")},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+		{
+			name:    "unknown question",
+			answers: []dto.ScreeningAnswerRequest{answer(relocate, "yes"), answer(setup, "Remote"), answer(years, "5"), {QuestionID: uuid.New(), Answer: "yes"}},
+			wantErr: repository.ErrInvalidScreeningAnswer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, knockout, err := evaluateScreeningAnswers(questions, tt.answers)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(stored) != tt.wantStored {
+				t.Errorf("got %d stored answers, want %d", len(stored), tt.wantStored)
+			}
+
+			switch {
+			case tt.wantKnockout == nil && knockout != nil:
+				t.Errorf("got knockout on %q, want none", knockout.Question)
+			case tt.wantKnockout != nil && (knockout == nil || knockout.ID != *tt.wantKnockout):
+				t.Errorf("got knockout %v, want question %s", knockout, *tt.wantKnockout)
+			}
+
+			knockedOut := 0
+			for _, a := range stored {
+				if a.KnockedOut {
+					knockedOut++
+				}
+			}
+			if knockedOut != tt.wantKnockedOut {
+				t.Errorf("got %d knocked out answers, want %d", knockedOut, tt.wantKnockedOut)
+			}
+		})
+	}
+}
+
+func TestEvaluateScreeningAnswersWithoutQuestions(t *testing.T) {
+	stored, knockout, err := evaluateScreeningAnswers(nil, nil)
+	if err != nil || len(stored) != 0 || knockout != nil {
+		t.Fatalf("got %v, %v, %v, want no answers and no error", stored, knockout, err)
+	}
+
+	_, _, err = evaluateScreeningAnswers(nil, []dto.ScreeningAnswerRequest{{QuestionID: uuid.New(), Answer: "yes"}})
+	if !errors.Is(err, repository.ErrInvalidScreeningAnswer) {
+		t.Fatalf("got error %v, want %v", err, repository.ErrInvalidScreeningAnswer)
+	}
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/job/dto"
+	"github.com/agpprastyo/career-link/internal/job/entity"
+)
+
+// SearchJobs retrieves a relevance-ranked page of published postings with facet counts
+func (uc *JobUseCase) SearchJobs(ctx context.Context, filter entity.JobSearchFilter, paging pagination.Pagination) (dto.JobSearchResponse, error) {
+	jobs, total, err := uc.repo.SearchJobs(ctx, filter, paging)
+	if err != nil {
+		return dto.JobSearchResponse{}, err
+	}
+
+	facets, err := uc.repo.GetJobSearchFacets(ctx, filter)
+	if err != nil {
+		return dto.JobSearchResponse{}, err
+	}
+
+	return dto.JobSearchResponse{
+		PageResponse: pagination.NewResponse(jobs, paging, total),
+		Facets:       facets,
+	}, nil
+}
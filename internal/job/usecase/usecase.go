@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/job/repository"
+	notificationEntity "github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// Notifier delivers notifications to users on the channels they chose
+type Notifier interface {
+	Notify(ctx context.Context, userIDs []uuid.UUID, notification notificationEntity.Notification) error
+	EmailEnabled(ctx context.Context, userID uuid.UUID, notificationType notificationEntity.NotificationType) (bool, error)
+}
+
+// JobUseCase implements job postings business logic
+type JobUseCase struct {
+	repo     repository.Repository
+	notifier Notifier
+	log      *logger.Logger
+}
+
+// NewJobUseCase creates a new JobUseCase instance
+func NewJobUseCase(repo repository.Repository, notifier Notifier, log *logger.Logger) *JobUseCase {
+	return &JobUseCase{
+		repo:     repo,
+		notifier: notifier,
+		log:      log,
+	}
+}
@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/notification/usecase"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationHandler handles HTTP requests for in-app notifications
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+	userRepo            *repository.UserRepository
+	log                 *logger.Logger
+	tokenMaker          token.Maker
+}
+
+// NewNotificationHandler creates a new notification HTTP handler
+func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase, log *logger.Logger, tokenMaker token.Maker, userRepo *repository.UserRepository) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+		userRepo:            userRepo,
+		log:                 log,
+		tokenMaker:          tokenMaker,
+	}
+}
+
+// RegisterNotificationRoutes registers the notification list and read state routes. The router must
+// already require authentication.
+func (h *NotificationHandler) RegisterNotificationRoutes(router fiber.Router) {
+	router.Get("/", h.ListNotifications)
+	router.Patch("/:id/read", h.MarkNotificationRead)
+	router.Post("/read-all", h.MarkAllNotificationsRead)
+}
+
+// RegisterNotificationSettingsRoutes registers the notification preference routes. The router must
+// already require authentication.
+func (h *NotificationHandler) RegisterNotificationSettingsRoutes(router fiber.Router) {
+	router.Get("/", h.GetNotificationPreferences)
+	router.Put("/", h.UpdateNotificationPreferences)
+}
+
+// RegisterNotificationStreamRoutes registers the live notification stream. Browsers cannot set headers
+// on an EventSource, so the access token may also be passed as the access_token query parameter.
+func (h *NotificationHandler) RegisterNotificationStreamRoutes(router fiber.Router) {
+	router.Get("/stream", queryTokenAuth(), middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log), h.StreamNotifications)
+}
+
+// queryTokenAuth moves the access_token query parameter into the Authorization header when the header
+// is missing, so the regular auth middleware verifies it
+func queryTokenAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			if accessToken := c.Query("access_token"); accessToken != "" {
+				c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+accessToken)
+			}
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/notification/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListNotifications handles fetching the user's notifications, newest first. Pass unread=true to list
+// only unread ones.
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+	unreadOnly := c.QueryBool("unread", false)
+
+	notifications, err := h.notificationUseCase.ListNotifications(ctx, userID, unreadOnly, paging)
+	if err != nil {
+		h.log.WithError(err).Error("List notifications failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+// MarkNotificationRead handles marking one of the user's notifications read
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	notificationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid notification ID format")
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	notification, err := h.notificationUseCase.MarkRead(ctx, userID, notificationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			return responseError.RespondWithError(c, fiber.StatusNotFound, "Notification not found")
+		}
+		h.log.WithError(err).Error("Mark notification read failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Notification marked as read",
+		"data":    notification,
+	})
+}
+
+// MarkAllNotificationsRead handles marking every unread notification of the user read
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	updated, err := h.notificationUseCase.MarkAllRead(ctx, userID)
+	if err != nil {
+		h.log.WithError(err).Error("Mark all notifications read failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "All notifications marked as read",
+		"data": fiber.Map{
+			"updated": updated,
+		},
+	})
+}
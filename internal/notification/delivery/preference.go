@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/notification/dto"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"strconv"
+)
+
+// GetNotificationPreferences handles fetching the channels the user receives every notification type on
+func (h *NotificationHandler) GetNotificationPreferences(c *fiber.Ctx) error {
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	preferences, err := h.notificationUseCase.GetPreferences(ctx, userID)
+	if err != nil {
+		h.log.WithError(err).Error("Get notification preferences failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": preferences,
+	})
+}
+
+// UpdateNotificationPreferences handles changing the channels of some notification types
+func (h *NotificationHandler) UpdateNotificationPreferences(c *fiber.Ctx) error {
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode notification preferences request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateNotificationPreferencesRequest(&req)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	preferences, err := h.notificationUseCase.UpdatePreferences(ctx, userID, req)
+	if err != nil {
+		h.log.WithError(err).Error("Update notification preferences failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Notification preferences updated",
+		"data":    preferences,
+	})
+}
+
+func validateNotificationPreferencesRequest(req *dto.UpdateNotificationPreferencesRequest) {
+	req.Validator.CheckField(len(req.Preferences) > 0, "Preferences", "At least one preference is required")
+
+	types := make([]string, 0, len(req.Preferences))
+	for i, item := range req.Preferences {
+		key := "Preferences[" + strconv.Itoa(i) + "]"
+		notificationType := entity.NotificationType(item.Type)
+		types = append(types, item.Type)
+
+		if !validator.In(notificationType, entity.NotificationTypes...) {
+			req.Validator.AddFieldError(key, "Unknown notification type: "+item.Type)
+			continue
+		}
+
+		req.Validator.CheckField(isOff(item.Email) || notificationType.Supports(entity.ChannelEmail), key, item.Type+" notifications cannot be sent by email")
+		req.Validator.CheckField(isOff(item.InApp) || notificationType.Supports(entity.ChannelInApp), key, item.Type+" notifications cannot be shown in the app")
+		req.Validator.CheckField(isOff(item.Digest) || notificationType.Supports(entity.ChannelDigest), key, item.Type+" notifications cannot be included in the digest")
+	}
+
+	req.Validator.CheckField(validator.NoDuplicates(types), "Preferences", "Each notification type may only appear once")
+}
+
+// isOff reports whether an optional channel setting leaves the channel off or unchanged
+func isOff(enabled *bool) bool {
+	return enabled == nil || !*enabled
+}
@@ -0,0 +1,100 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/notification/dto"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestValidateNotificationPreferencesRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []dto.NotificationPreferenceRequest
+		wantErrors  []string
+	}{
+		{
+			name: "supported channels",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationMessageReceived), Email: boolPtr(true), InApp: boolPtr(false), Digest: boolPtr(true)},
+				{Type: string(entity.NotificationJobAlert), Email: boolPtr(false)},
+			},
+		},
+		{
+			name: "unsupported channels may be turned off",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationInterviewUpdate), InApp: boolPtr(false), Digest: boolPtr(false)},
+			},
+		},
+		{
+			name: "channels left unchanged",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationDeadlineReminder)},
+			},
+		},
+		{
+			name:        "empty request",
+			preferences: nil,
+			wantErrors:  []string{"Preferences"},
+		},
+		{
+			name: "unknown type",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: "newsletter", Email: boolPtr(true)},
+			},
+			wantErrors: []string{"Preferences[0]"},
+		},
+		{
+			name: "in-app on for an email only type",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationApplicationStatus), InApp: boolPtr(true)},
+				{Type: string(entity.NotificationJobAlert), InApp: boolPtr(true)},
+			},
+			wantErrors: []string{"Preferences[1]"},
+		},
+		{
+			name: "digest on for an email only type",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationInterviewUpdate), Digest: boolPtr(true)},
+			},
+			wantErrors: []string{"Preferences[0]"},
+		},
+		{
+			name: "duplicate type",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: string(entity.NotificationJobAlert), Email: boolPtr(true)},
+				{Type: string(entity.NotificationApplicationStatus), Email: boolPtr(true)},
+				{Type: string(entity.NotificationJobAlert), Email: boolPtr(false)},
+			},
+			wantErrors: []string{"Preferences"},
+		},
+		{
+			name: "duplicate unknown type",
+			preferences: []dto.NotificationPreferenceRequest{
+				{Type: "newsletter"},
+				{Type: "newsletter"},
+			},
+			wantErrors: []string{"Preferences", "Preferences[0]", "Preferences[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.UpdateNotificationPreferencesRequest{Preferences: tt.preferences}
+			validateNotificationPreferencesRequest(&req)
+
+			if len(req.Validator.FieldErrors) != len(tt.wantErrors) {
+				t.Errorf("got errors %v, want errors on %v", req.Validator.FieldErrors, tt.wantErrors)
+			}
+			for _, key := range tt.wantErrors {
+				if _, ok := req.Validator.FieldErrors[key]; !ok {
+					t.Errorf("missing error on %s, got %v", key, req.Validator.FieldErrors)
+				}
+			}
+		})
+	}
+}
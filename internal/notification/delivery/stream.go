@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// streamRetry is how long a disconnected EventSource waits before reconnecting
+	streamRetry = 3 * time.Second
+	// streamHeartbeat keeps idle streams open through proxies that drop silent connections
+	streamHeartbeat = 25 * time.Second
+	// streamReplayTimeout bounds loading the notifications a reconnecting stream missed
+	streamReplayTimeout = 5 * time.Second
+	// streamRevocationTimeout bounds the revocation check made on every heartbeat
+	streamRevocationTimeout = 2 * time.Second
+	// streamSeenIDs is how many recently sent notification IDs a stream remembers to skip duplicates
+	streamSeenIDs = 256
+)
+
+// StreamNotifications handles the live notification stream as Server-Sent Events. Every notification
+// is a "notification" event whose id is the notification ID, so a reconnecting client that sends
+// Last-Event-ID receives what it missed. The stream ends when the access token expires or, checked on
+// every heartbeat, is revoked.
+func (h *NotificationHandler) StreamNotifications(c *fiber.Ctx) error {
+	var lastID uuid.UUID
+	if lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id")); lastEventID != "" {
+		id, err := uuid.Parse(lastEventID)
+		if err != nil {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid Last-Event-ID format")
+		}
+		lastID = id
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	expiredAt := c.Locals("token_expired_at").(time.Time)
+	payload := c.Locals("token_payload").(*token.Payload)
+
+	// Subscribe before replaying so nothing published in between is lost; duplicates are skipped by ID.
+	// IDs are not compared by order: a notification created earlier on another instance may be
+	// published after a later one.
+	sub := h.notificationUseCase.Subscribe(userID)
+	seen := newSeenIDs(streamSeenIDs)
+	if lastID != uuid.Nil {
+		seen.add(lastID)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+			return
+		}
+
+		if lastID != uuid.Nil {
+			ctx, cancel := context.WithTimeout(context.Background(), streamReplayTimeout)
+			missed, err := h.notificationUseCase.ListMissedNotifications(ctx, userID, lastID)
+			cancel()
+			if err != nil {
+				return
+			}
+
+			for _, notification := range missed {
+				if err := h.writeNotificationEvent(w, notification); err != nil {
+					return
+				}
+				seen.add(notification.ID)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+		expiry := time.NewTimer(time.Until(expiredAt))
+		defer expiry.Stop()
+
+		for {
+			select {
+			case notification, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				if seen.has(notification.ID) {
+					continue
+				}
+				if err := h.writeNotificationEvent(w, notification); err != nil {
+					return
+				}
+				seen.add(notification.ID)
+			case <-heartbeat.C:
+				if h.streamTokenRevoked(payload) {
+					return
+				}
+				if _, err := w.WriteString(": ping\n\n"); err != nil {
+					return
+				}
+			case <-expiry.C:
+				return
+			}
+
+			if err := w.Flush(); err != nil {
+				// The client went away
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// streamTokenRevoked reports whether the access token a stream was opened with has been revoked since.
+// A failed check also ends the stream; the reconnect goes through the auth middleware again.
+func (h *NotificationHandler) streamTokenRevoked(payload *token.Payload) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), streamRevocationTimeout)
+	defer cancel()
+
+	revoked, err := h.userRepo.IsAccessTokenRevoked(ctx, payload)
+	if err != nil {
+		h.log.WithError(err).WithField("user_id", payload.UserID).Error("Failed to check stream token revocation")
+		return true
+	}
+	return revoked
+}
+
+// writeNotificationEvent writes a notification as a server-sent event
+func (h *NotificationHandler) writeNotificationEvent(w *bufio.Writer, notification entity.Notification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to encode notification event")
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: notification\ndata: %s\n\n", notification.ID, data)
+	return err
+}
+
+// seenIDs remembers the most recent IDs added to it, forgetting the oldest once full
+type seenIDs struct {
+	ids   map[uuid.UUID]struct{}
+	order []uuid.UUID
+	next  int
+}
+
+// newSeenIDs creates a seenIDs remembering up to size IDs
+func newSeenIDs(size int) *seenIDs {
+	return &seenIDs{
+		ids:   make(map[uuid.UUID]struct{}, size),
+		order: make([]uuid.UUID, 0, size),
+	}
+}
+
+// has reports whether id is among the remembered IDs
+func (s *seenIDs) has(id uuid.UUID) bool {
+	_, ok := s.ids[id]
+	return ok
+}
+
+// add remembers id, forgetting the oldest remembered ID when full
+func (s *seenIDs) add(id uuid.UUID) {
+	if s.has(id) {
+		return
+	}
+	if len(s.order) < cap(s.order) {
+		s.order = append(s.order, id)
+	} else {
+		delete(s.ids, s.order[s.next])
+		s.order[s.next] = id
+		s.next = (s.next + 1) % len(s.order)
+	}
+	s.ids[id] = struct{}{}
+}
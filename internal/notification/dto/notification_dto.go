@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/pkg/validator"
+)
+
+// NotificationListResponse is a page of notifications with the user's total unread count
+type NotificationListResponse struct {
+	pagination.PageResponse
+	Unread int `json:"unread"`
+}
+
+// NotificationPreferenceRequest changes the channels of one notification type. Omitted channels keep
+// their current setting.
+type NotificationPreferenceRequest struct {
+	Type   string `json:"type"`
+	Email  *bool  `json:"email"`
+	InApp  *bool  `json:"in_app"`
+	Digest *bool  `json:"digest"`
+}
+
+// UpdateNotificationPreferencesRequest changes the channels of any number of notification types
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences"`
+	Validator   validator.Validator             `json:"-"`
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// NotificationType is an enum-like type for the event a notification is about
+type NotificationType string
+
+const (
+	NotificationApplicationReceived NotificationType = "application_received"       // A company got a new applicant
+	NotificationApplicationStatus   NotificationType = "application_status_changed" // An application moved stage
+	NotificationMessageReceived     NotificationType = "message_received"           // A new message in an application thread
+	NotificationInterviewUpdate     NotificationType = "interview_updated"          // An interview was proposed, scheduled or cancelled
+	NotificationJobAlert            NotificationType = "job_alert"                  // New postings matching a saved search
+	NotificationDeadlineReminder    NotificationType = "deadline_reminder"          // A saved posting closes soon
+)
+
+// Notification represents the notifications table
+type Notification struct {
+	ID            uuid.UUID        `json:"id" db:"id"`
+	UserID        uuid.UUID        `json:"-" db:"user_id"`
+	Type          NotificationType `json:"type" db:"type"`
+	Title         string           `json:"title" db:"title"`
+	Body          string           `json:"body" db:"body"`
+	Link          *string          `json:"link,omitempty" db:"link"` // Frontend path the notification opens
+	InApp         bool             `json:"-" db:"in_app"`            // Listed and pushed to the user
+	DigestPending bool             `json:"-" db:"digest_pending"`    // Waiting for the user's next digest email
+	ReadAt        *time.Time       `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+}
+
+// Recipient is a user a notification email goes to
+type Recipient struct {
+	UserID   uuid.UUID `db:"user_id"`
+	Email    string    `db:"email"`
+	Username string    `db:"username"`
+}
+
+// NotificationEmail is a notification to email to one recipient
+type NotificationEmail struct {
+	Recipient    Recipient
+	Notification Notification
+}
+
+// DigestNotification is a notification claimed for a digest email, with the user it goes to
+type DigestNotification struct {
+	Notification
+	Email    string `db:"email"`
+	Username string `db:"username"`
+}
@@ -0,0 +1,91 @@
+package entity
+
+import "github.com/google/uuid"
+
+// Channel is an enum-like type for the ways a notification reaches a user
+type Channel string
+
+const (
+	ChannelEmail  Channel = "email"  // An email as soon as it happens
+	ChannelInApp  Channel = "in_app" // The notification list and live stream
+	ChannelDigest Channel = "digest" // A periodic summary email
+)
+
+// NotificationPreference represents the notification_preferences table: the channels a user receives
+// a type of notification on
+type NotificationPreference struct {
+	UserID   uuid.UUID        `json:"-" db:"user_id"`
+	Type     NotificationType `json:"type" db:"type"`
+	Email    bool             `json:"email" db:"email"`
+	InApp    bool             `json:"in_app" db:"in_app"`
+	Digest   bool             `json:"digest" db:"digest"`
+	Channels []Channel        `json:"channels" db:"-"` // Channels the type supports
+}
+
+// NotificationTypes lists every notification type users can configure
+var NotificationTypes = []NotificationType{
+	NotificationApplicationReceived,
+	NotificationApplicationStatus,
+	NotificationMessageReceived,
+	NotificationInterviewUpdate,
+	NotificationJobAlert,
+	NotificationDeadlineReminder,
+}
+
+// typeChannels are the channels each notification type supports. Types delivered only by email have
+// no in-app or digest channel.
+var typeChannels = map[NotificationType][]Channel{
+	NotificationApplicationReceived: {ChannelEmail, ChannelInApp, ChannelDigest},
+	NotificationApplicationStatus:   {ChannelEmail, ChannelInApp, ChannelDigest},
+	NotificationMessageReceived:     {ChannelEmail, ChannelInApp, ChannelDigest},
+	NotificationInterviewUpdate:     {ChannelEmail},
+	NotificationJobAlert:            {ChannelEmail},
+	NotificationDeadlineReminder:    {ChannelEmail},
+}
+
+// Supports reports whether the type can be delivered on a channel
+func (t NotificationType) Supports(channel Channel) bool {
+	for _, c := range typeChannels[t] {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPreference returns the channels a user receives the type on until they change them. Every
+// supported in-app channel is on, and so are the emails sent before preferences existed.
+func (t NotificationType) DefaultPreference(userID uuid.UUID) NotificationPreference {
+	preference := NotificationPreference{
+		UserID:   userID,
+		Type:     t,
+		InApp:    t.Supports(ChannelInApp),
+		Channels: typeChannels[t],
+	}
+
+	switch t {
+	case NotificationApplicationStatus, NotificationInterviewUpdate, NotificationJobAlert, NotificationDeadlineReminder:
+		preference.Email = true
+	}
+
+	return preference
+}
+
+// Enables reports whether the preference turns a channel on. Channels the type does not support are
+// always off.
+func (p NotificationPreference) Enables(channel Channel) bool {
+	if !p.Type.Supports(channel) {
+		return false
+	}
+
+	switch channel {
+	case ChannelEmail:
+		return p.Email
+	case ChannelInApp:
+		return p.InApp
+	case ChannelDigest:
+		return p.Digest
+	default:
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+)
+
+var allChannels = []entity.Channel{entity.ChannelEmail, entity.ChannelInApp, entity.ChannelDigest}
+
+func TestNotificationTypeSupports(t *testing.T) {
+	tests := []struct {
+		notificationType entity.NotificationType
+		email            bool
+		inApp            bool
+		digest           bool
+	}{
+		{entity.NotificationApplicationReceived, true, true, true},
+		{entity.NotificationApplicationStatus, true, true, true},
+		{entity.NotificationMessageReceived, true, true, true},
+		{entity.NotificationInterviewUpdate, true, false, false},
+		{entity.NotificationJobAlert, true, false, false},
+		{entity.NotificationDeadlineReminder, true, false, false},
+		{entity.NotificationType("unknown"), false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.notificationType), func(t *testing.T) {
+			want := map[entity.Channel]bool{entity.ChannelEmail: tt.email, entity.ChannelInApp: tt.inApp, entity.ChannelDigest: tt.digest}
+			for _, channel := range allChannels {
+				if got := tt.notificationType.Supports(channel); got != want[channel] {
+					t.Errorf("%s: got %v, want %v", channel, got, want[channel])
+				}
+			}
+			if tt.notificationType.Supports(entity.Channel("sms")) {
+				t.Error("unknown channel is supported")
+			}
+		})
+	}
+}
+
+// Without a saved preference row a user gets the default preference of the type
+func TestDefaultPreferenceEnables(t *testing.T) {
+	tests := []struct {
+		notificationType entity.NotificationType
+		email            bool
+		inApp            bool
+	}{
+		{entity.NotificationApplicationReceived, false, true},
+		{entity.NotificationApplicationStatus, true, true},
+		{entity.NotificationMessageReceived, false, true},
+		{entity.NotificationInterviewUpdate, true, false},
+		{entity.NotificationJobAlert, true, false},
+		{entity.NotificationDeadlineReminder, true, false},
+	}
+
+	userID := uuid.New()
+	for _, tt := range tests {
+		t.Run(string(tt.notificationType), func(t *testing.T) {
+			preference := tt.notificationType.DefaultPreference(userID)
+
+			if preference.UserID != userID || preference.Type != tt.notificationType {
+				t.Errorf("got user %s and type %s", preference.UserID, preference.Type)
+			}
+			want := map[entity.Channel]bool{entity.ChannelEmail: tt.email, entity.ChannelInApp: tt.inApp, entity.ChannelDigest: false}
+			for _, channel := range allChannels {
+				if got := preference.Enables(channel); got != want[channel] {
+					t.Errorf("%s: got %v, want %v", channel, got, want[channel])
+				}
+			}
+			for _, channel := range preference.Channels {
+				if !tt.notificationType.Supports(channel) {
+					t.Errorf("default lists unsupported channel %s", channel)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultPreferenceCoversEveryType(t *testing.T) {
+	for _, notificationType := range entity.NotificationTypes {
+		preference := notificationType.DefaultPreference(uuid.New())
+		if len(preference.Channels) == 0 {
+			t.Errorf("%s supports no channel", notificationType)
+		}
+		if !preference.Enables(entity.ChannelEmail) && !preference.Enables(entity.ChannelInApp) {
+			t.Errorf("%s is off on every channel by default", notificationType)
+		}
+	}
+}
+
+func TestPreferenceEnablesIgnoresUnsupportedChannels(t *testing.T) {
+	preference := entity.NotificationPreference{
+		Type:   entity.NotificationJobAlert,
+		Email:  true,
+		InApp:  true,
+		Digest: true,
+	}
+
+	if !preference.Enables(entity.ChannelEmail) {
+		t.Error("email is off")
+	}
+	if preference.Enables(entity.ChannelInApp) {
+		t.Error("in-app is on for a type without an in-app channel")
+	}
+	if preference.Enables(entity.ChannelDigest) {
+		t.Error("digest is on for a type without a digest channel")
+	}
+	if preference.Enables(entity.Channel("sms")) {
+		t.Error("unknown channel is on")
+	}
+
+	preference = entity.NotificationPreference{Type: entity.NotificationMessageReceived}
+	for _, channel := range allChannels {
+		if preference.Enables(channel) {
+			t.Errorf("%s is on for a preference that turns everything off", channel)
+		}
+	}
+}
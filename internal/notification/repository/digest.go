@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// ClaimDigestNotifications takes the notifications waiting for a digest of up to limit users whose
+// oldest waiting notification was created at or before cutoff, oldest users first. Claimed
+// notifications leave the digest queue, so concurrent runs never send them twice.
+func (r *NotificationRepository) ClaimDigestNotifications(ctx context.Context, cutoff time.Time, limit int) ([]entity.DigestNotification, error) {
+	const query = `
+		WITH due_users AS (
+			SELECT n.user_id
+			FROM notifications n
+			JOIN users u ON u.id = n.user_id
+			WHERE n.digest_pending AND u.is_active
+			GROUP BY n.user_id
+			HAVING MIN(n.created_at) <= $1
+			ORDER BY MIN(n.created_at)
+			LIMIT $2
+		), due AS (
+			SELECT n.id
+			FROM notifications n
+			JOIN due_users d ON d.user_id = n.user_id
+			WHERE n.digest_pending
+			FOR UPDATE OF n SKIP LOCKED
+		)
+		UPDATE notifications n
+		SET digest_pending = FALSE
+		FROM due, users u
+		WHERE n.id = due.id AND u.id = n.user_id
+		RETURNING n.id, n.user_id, n.type, n.title, n.body, n.link, n.in_app, n.digest_pending, n.read_at,
+		          n.created_at, u.email, u.username
+	`
+
+	notifications := []entity.DigestNotification{}
+	if err := r.db.SelectContext(ctx, &notifications, query, cutoff, limit); err != nil {
+		r.log.WithError(err).Error("Failed to claim digest notifications")
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ReleaseDigestNotifications puts claimed notifications whose digest could not be sent back in the
+// digest queue
+func (r *NotificationRepository) ReleaseDigestNotifications(ctx context.Context, ids []uuid.UUID) error {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+
+	const query = `UPDATE notifications SET digest_pending = TRUE WHERE id = ANY($1::uuid[])`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(values)); err != nil {
+		r.log.WithError(err).Error("Failed to release digest notifications")
+		return err
+	}
+
+	return nil
+}
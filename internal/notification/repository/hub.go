@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/redis"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"strings"
+	"sync"
+)
+
+// notificationChannelPrefix prefixes the Redis channel of every user's notifications
+const notificationChannelPrefix = "notifications:"
+
+// subscriptionBuffer is how many notifications wait for a slow stream before newer ones are dropped
+const subscriptionBuffer = 32
+
+func notificationChannel(userID uuid.UUID) string {
+	return notificationChannelPrefix + userID.String()
+}
+
+// Hub receives the notifications published by every API instance over a single Redis subscription and
+// hands them to the streams open on this instance
+type Hub struct {
+	redis  *redis.Client
+	log    *logger.Logger
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[*Subscription]struct{}
+	closed bool
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Subscription delivers the live notifications of one user. C is closed when the hub stops.
+type Subscription struct {
+	C      <-chan entity.Notification
+	c      chan entity.Notification
+	userID uuid.UUID
+	hub    *Hub
+}
+
+func NewHub(redis *redis.Client, log *logger.Logger) *Hub {
+	return &Hub{
+		redis: redis,
+		log:   log,
+		subs:  make(map[uuid.UUID]map[*Subscription]struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+func (h *Hub) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	pubsub := h.redis.PSubscribe(ctx, notificationChannelPrefix+"*")
+	go h.run(ctx, pubsub.Channel(), func() {
+		if err := pubsub.Close(); err != nil {
+			h.log.WithError(err).Error("Failed to close notification subscription")
+		}
+	})
+}
+
+// Stop ends the Redis subscription and closes every open subscription, so streams can finish
+func (h *Hub) Stop() {
+	if h.cancel != nil {
+		h.cancel()
+		<-h.done
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, subs := range h.subs {
+		for sub := range subs {
+			close(sub.c)
+		}
+	}
+	h.subs = make(map[uuid.UUID]map[*Subscription]struct{})
+}
+
+// Subscribe starts delivering the notifications of a user. The subscription must be closed.
+func (h *Hub) Subscribe(userID uuid.UUID) *Subscription {
+	c := make(chan entity.Notification, subscriptionBuffer)
+	sub := &Subscription{C: c, c: c, userID: userID, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(c)
+		return sub
+	}
+
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*Subscription]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+
+	return sub
+}
+
+// Close stops the subscription. Closing it twice, or after the hub stopped, is a no-op.
+func (s *Subscription) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subs[s.userID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[s]; !ok {
+		return
+	}
+
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(h.subs, s.userID)
+	}
+	close(s.c)
+}
+
+func (h *Hub) run(ctx context.Context, messages <-chan *goredis.Message, closePubSub func()) {
+	defer close(h.done)
+	defer closePubSub()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			h.dispatch(msg)
+		}
+	}
+}
+
+// dispatch hands a published notification to the subscriptions of its recipient
+func (h *Hub) dispatch(msg *goredis.Message) {
+	userID, err := uuid.Parse(strings.TrimPrefix(msg.Channel, notificationChannelPrefix))
+	if err != nil {
+		h.log.WithError(err).WithField("channel", msg.Channel).Warn("Ignoring message on unexpected notification channel")
+		return
+	}
+
+	var notification entity.Notification
+	if err := json.Unmarshal([]byte(msg.Payload), &notification); err != nil {
+		h.log.WithError(err).WithField("channel", msg.Channel).Warn("Ignoring malformed notification")
+		return
+	}
+	notification.UserID = userID
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs[userID] {
+		select {
+		case sub.c <- notification:
+		default:
+			// The stream is not keeping up; it can catch up from the notification list
+			h.log.WithField("user_id", userID).Warn("Dropping live notification for slow stream")
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/mail/templates"
+	"strings"
+)
+
+// notificationEmail renders the email of a single notification to a user
+func (r *NotificationRepository) notificationEmail(recipient entity.Recipient, notification entity.Notification) (mail.EmailMessage, error) {
+	frontendURL := strings.TrimSuffix(r.frontendURL, "/")
+
+	data := templates.NotificationData{
+		Username:     recipient.Username,
+		Title:        notification.Title,
+		Body:         notification.Body,
+		SettingsURL:  frontendURL + "/settings/notifications",
+		AppName:      "Career Link",
+		SupportEmail: "support@careerlink.com",
+	}
+	if notification.Link != nil {
+		data.ActionURL = frontendURL + *notification.Link
+	}
+
+	htmlContent, err := templates.GetNotificationHTML(data)
+	if err != nil {
+		return mail.EmailMessage{}, err
+	}
+
+	return mail.EmailMessage{
+		To:      recipient.Email,
+		Subject: notification.Title,
+		Body:    htmlContent,
+		IsHTML:  true,
+	}, nil
+}
+
+// SendDigestEmail emails a user the summary of their notifications since the last digest
+func (r *NotificationRepository) SendDigestEmail(ctx context.Context, recipient entity.Recipient, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	frontendURL := strings.TrimSuffix(r.frontendURL, "/")
+
+	items := make([]templates.NotificationDigestItem, 0, len(notifications))
+	for _, notification := range notifications {
+		item := templates.NotificationDigestItem{
+			Title: notification.Title,
+			Body:  notification.Body,
+			Time:  notification.CreatedAt.UTC().Format("Mon, 2 Jan 2006 15:04 MST"),
+		}
+		if notification.Link != nil {
+			item.URL = frontendURL + *notification.Link
+		}
+		items = append(items, item)
+	}
+
+	data := templates.NotificationDigestData{
+		Username:         recipient.Username,
+		Items:            items,
+		NotificationsURL: frontendURL + "/notifications",
+		SettingsURL:      frontendURL + "/settings/notifications",
+		AppName:          "Career Link",
+		SupportEmail:     "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetNotificationDigestHTML(data)
+	if err != nil {
+		return err
+	}
+
+	subject := "1 new notification on Career Link"
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("%d new notifications on Career Link", len(notifications))
+	}
+
+	message := mail.EmailMessage{
+		To:      recipient.Email,
+		Subject: subject,
+		Body:    htmlContent,
+		IsHTML:  true,
+	}
+
+	return r.mail.SendEmail(ctx, message)
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	outboxEntity "github.com/agpprastyo/career-link/internal/outbox/entity"
+	outboxRepository "github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/google/uuid"
+	"time"
+)
+
+const notificationColumns = `id, user_id, type, title, body, link, in_app, digest_pending, read_at, created_at`
+
+// CreateNotifications inserts notifications and queues their emails in a single transaction
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, notifications []entity.Notification, emails []entity.NotificationEmail) error {
+	messages := make([]mail.EmailMessage, 0, len(emails))
+	for _, email := range emails {
+		message, err := r.notificationEmail(email.Recipient, email.Notification)
+		if err != nil {
+			r.log.WithError(err).WithField("user_id", email.Recipient.UserID).Error("Failed to render notification email")
+			return err
+		}
+		messages = append(messages, message)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const query = `
+		INSERT INTO notifications (id, user_id, type, title, body, link, in_app, digest_pending)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`
+	for i := range notifications {
+		n := &notifications[i]
+		if err := tx.QueryRowContext(ctx, query, n.ID, n.UserID, n.Type, n.Title, n.Body, n.Link, n.InApp, n.DigestPending).Scan(&n.CreatedAt); err != nil {
+			r.log.WithError(err).WithField("user_id", n.UserID).Error("Failed to create notification")
+			return err
+		}
+	}
+
+	for _, message := range messages {
+		if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindNotification, message); err != nil {
+			r.log.WithError(err).Error("Failed to queue notification email")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// PublishNotification pushes a stored notification to every API instance, which deliver it to the
+// recipient's open streams
+func (r *NotificationRepository) PublishNotification(ctx context.Context, notification entity.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	if err := r.redis.Publish(ctx, notificationChannel(notification.UserID), payload); err != nil {
+		r.log.WithError(err).WithField("user_id", notification.UserID).Error("Failed to publish notification")
+		return err
+	}
+
+	return nil
+}
+
+// ListNotifications retrieves a paginated list of a user's notifications, newest first
+func (r *NotificationRepository) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, paging pagination.Pagination) ([]entity.Notification, int, error) {
+	where := `WHERE user_id = $1 AND in_app`
+	if unreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications `+where, userID).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to count notifications")
+		return nil, 0, err
+	}
+
+	notifications := []entity.Notification{}
+	query := `SELECT ` + notificationColumns + ` FROM notifications ` + where + ` ORDER BY created_at DESC, id DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &notifications, query, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to list notifications")
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+// ListNotificationsAfter retrieves up to limit notifications of a user created after the notification
+// afterID, oldest first. IDs are time ordered, so this replays what a reconnecting stream missed.
+func (r *NotificationRepository) ListNotificationsAfter(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]entity.Notification, error) {
+	notifications := []entity.Notification{}
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = $1 AND in_app AND id > $2 ORDER BY id LIMIT $3`
+	if err := r.db.SelectContext(ctx, &notifications, query, userID, afterID, limit); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to list missed notifications")
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns how many notifications of a user are unread
+func (r *NotificationRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	const query = `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND in_app AND read_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to count unread notifications")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks a notification of a user read. Reading it again keeps the first read time.
+func (r *NotificationRepository) MarkNotificationRead(ctx context.Context, id, userID uuid.UUID, now time.Time) (*entity.Notification, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, $1)
+		WHERE id = $2 AND user_id = $3 AND in_app
+		RETURNING ` + notificationColumns
+
+	notifications := []entity.Notification{}
+	if err := r.db.SelectContext(ctx, &notifications, query, now, id, userID); err != nil {
+		r.log.WithError(err).WithField("notification_id", id).Error("Failed to mark notification read")
+		return nil, err
+	}
+
+	if len(notifications) == 0 {
+		return nil, ErrNotificationNotFound
+	}
+
+	return &notifications[0], nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of a user read and returns how many changed
+func (r *NotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	const query = `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND in_app AND read_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, now, userID)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to mark all notifications read")
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ListPreferences retrieves the notification preferences a user saved. Types without a saved
+// preference use their defaults.
+func (r *NotificationRepository) ListPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error) {
+	const query = `SELECT user_id, type, email, in_app, digest FROM notification_preferences WHERE user_id = $1`
+
+	preferences := []entity.NotificationPreference{}
+	if err := r.db.SelectContext(ctx, &preferences, query, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to list notification preferences")
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// ListPreferencesForType retrieves the preferences users saved for one notification type
+func (r *NotificationRepository) ListPreferencesForType(ctx context.Context, userIDs []uuid.UUID, notificationType entity.NotificationType) ([]entity.NotificationPreference, error) {
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+
+	const query = `
+		SELECT user_id, type, email, in_app, digest
+		FROM notification_preferences
+		WHERE user_id = ANY($1::uuid[]) AND type = $2
+	`
+
+	preferences := []entity.NotificationPreference{}
+	if err := r.db.SelectContext(ctx, &preferences, query, pq.Array(ids), notificationType); err != nil {
+		r.log.WithError(err).WithField("type", notificationType).Error("Failed to list notification preferences")
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// SavePreferences stores notification preferences of a user. Notifications waiting for a digest the
+// user turned off are dropped from it.
+func (r *NotificationRepository) SavePreferences(ctx context.Context, userID uuid.UUID, preferences []entity.NotificationPreference) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const query = `
+		INSERT INTO notification_preferences (user_id, type, email, in_app, digest)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, type)
+		DO UPDATE SET email = EXCLUDED.email, in_app = EXCLUDED.in_app, digest = EXCLUDED.digest
+	`
+	const dropDigestQuery = `
+		UPDATE notifications SET digest_pending = FALSE
+		WHERE user_id = $1 AND type = $2 AND digest_pending
+	`
+	for _, p := range preferences {
+		if _, err := tx.ExecContext(ctx, query, userID, p.Type, p.Email, p.InApp, p.Digest); err != nil {
+			r.log.WithError(err).WithField("user_id", userID).Error("Failed to save notification preference")
+			return err
+		}
+
+		if !p.Digest {
+			if _, err := tx.ExecContext(ctx, dropDigestQuery, userID, p.Type); err != nil {
+				r.log.WithError(err).WithField("user_id", userID).Error("Failed to drop pending digest notifications")
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ListRecipients retrieves the email addresses of the active users among userIDs
+func (r *NotificationRepository) ListRecipients(ctx context.Context, userIDs []uuid.UUID) ([]entity.Recipient, error) {
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+
+	const query = `
+		SELECT id AS user_id, email, username
+		FROM users
+		WHERE id = ANY($1::uuid[]) AND is_active
+	`
+
+	recipients := []entity.Recipient{}
+	if err := r.db.SelectContext(ctx, &recipients, query, pq.Array(ids)); err != nil {
+		r.log.WithError(err).Error("Failed to list notification recipients")
+		return nil, err
+	}
+
+	return recipients, nil
+}
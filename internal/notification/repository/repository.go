@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/pkg/database"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/agpprastyo/career-link/pkg/redis"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationRepository implements notification repository using PostgreSQL, publishing new
+// notifications through Redis
+type NotificationRepository struct {
+	db          *database.PostgresDB
+	log         *logger.Logger
+	redis       *redis.Client
+	mail        *mail.Client
+	frontendURL string
+}
+
+// Repository defines the interface for notification repository operations
+type Repository interface {
+	CreateNotifications(ctx context.Context, notifications []entity.Notification, emails []entity.NotificationEmail) error
+	PublishNotification(ctx context.Context, notification entity.Notification) error
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, paging pagination.Pagination) ([]entity.Notification, int, error)
+	ListNotificationsAfter(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]entity.Notification, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkNotificationRead(ctx context.Context, id, userID uuid.UUID, now time.Time) (*entity.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error)
+	ListPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error)
+	ListPreferencesForType(ctx context.Context, userIDs []uuid.UUID, notificationType entity.NotificationType) ([]entity.NotificationPreference, error)
+	SavePreferences(ctx context.Context, userID uuid.UUID, preferences []entity.NotificationPreference) error
+	ListRecipients(ctx context.Context, userIDs []uuid.UUID) ([]entity.Recipient, error)
+	ClaimDigestNotifications(ctx context.Context, cutoff time.Time, limit int) ([]entity.DigestNotification, error)
+	ReleaseDigestNotifications(ctx context.Context, ids []uuid.UUID) error
+	SendDigestEmail(ctx context.Context, recipient entity.Recipient, notifications []entity.Notification) error
+}
+
+// NewNotificationRepository creates new NotificationRepository
+func NewNotificationRepository(db *database.PostgresDB, log *logger.Logger, redis *redis.Client, mail *mail.Client, frontendURL string) *NotificationRepository {
+	return &NotificationRepository{
+		db:          db,
+		log:         log,
+		redis:       redis,
+		mail:        mail,
+		frontendURL: frontendURL,
+	}
+}
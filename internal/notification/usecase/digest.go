@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// digestPeriod is how long notifications collect before a user's digest is sent
+	digestPeriod = 24 * time.Hour
+	// digestBatch is how many users' digests are claimed at once
+	digestBatch = 100
+)
+
+// SendNotificationDigests emails every user whose oldest notification waiting for a digest is a
+// digest period old the summary of their waiting notifications. Notifications read in the app in the
+// meantime are left out. It runs as a scheduled job.
+func (uc *NotificationUseCase) SendNotificationDigests(ctx context.Context) error {
+	for {
+		claimed, err := uc.repo.ClaimDigestNotifications(ctx, time.Now().Add(-digestPeriod), digestBatch)
+		if err != nil {
+			return err
+		}
+
+		// Group the claimed notifications by user, keeping the claim order
+		var order []uuid.UUID
+		byUser := make(map[uuid.UUID][]entity.DigestNotification)
+		for _, n := range claimed {
+			if _, ok := byUser[n.UserID]; !ok {
+				order = append(order, n.UserID)
+			}
+			byUser[n.UserID] = append(byUser[n.UserID], n)
+		}
+
+		failed := false
+		for _, userID := range order {
+			if !uc.sendDigest(ctx, byUser[userID]) {
+				failed = true
+			}
+		}
+
+		// Released notifications are due again at once, so leave them and the rest of the backlog to the next run
+		if failed || len(order) < digestBatch || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// sendDigest sends the digest of one user, releasing the notifications when the email cannot be sent.
+// It reports whether the digest was handled.
+func (uc *NotificationUseCase) sendDigest(ctx context.Context, claimed []entity.DigestNotification) bool {
+	recipient := entity.Recipient{
+		UserID:   claimed[0].UserID,
+		Email:    claimed[0].Email,
+		Username: claimed[0].Username,
+	}
+
+	var unread []entity.Notification
+	for _, n := range claimed {
+		if n.ReadAt == nil {
+			unread = append(unread, n.Notification)
+		}
+	}
+
+	err := uc.repo.SendDigestEmail(ctx, recipient, unread)
+	if err == nil {
+		return true
+	}
+
+	log := uc.log.WithField("user_id", recipient.UserID)
+	log.WithError(err).Error("Failed to send notification digest")
+
+	ids := make([]uuid.UUID, 0, len(claimed))
+	for _, n := range claimed {
+		ids = append(ids, n.ID)
+	}
+	if err := uc.repo.ReleaseDigestNotifications(context.WithoutCancel(ctx), ids); err != nil {
+		log.WithError(err).Error("Failed to release notification digest")
+	}
+	return false
+}
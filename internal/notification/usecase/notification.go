@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/notification/dto"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/internal/notification/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+// maxMissedNotifications caps how many notifications a reconnecting stream replays
+const maxMissedNotifications = 100
+
+// Notify delivers a notification to every recipient on the channels they chose for its type: it is
+// stored and pushed to their open streams, kept for their digest, and emailed through the outbox. A
+// failed push is only logged, the notification is still listed.
+func (uc *NotificationUseCase) Notify(ctx context.Context, userIDs []uuid.UUID, notification entity.Notification) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	preferences, err := uc.preferencesForType(ctx, userIDs, notification.Type)
+	if err != nil {
+		return err
+	}
+
+	notifications := make([]entity.Notification, 0, len(userIDs))
+	var emailTo []uuid.UUID
+	for _, userID := range userIDs {
+		preference := preferences[userID]
+		if preference.Enables(entity.ChannelEmail) {
+			emailTo = append(emailTo, userID)
+		}
+
+		inApp, digest := preference.Enables(entity.ChannelInApp), preference.Enables(entity.ChannelDigest)
+		if !inApp && !digest {
+			continue
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			return err
+		}
+
+		n := notification
+		n.ID = id
+		n.UserID = userID
+		n.InApp = inApp
+		n.DigestPending = digest
+		notifications = append(notifications, n)
+	}
+
+	var emails []entity.NotificationEmail
+	if len(emailTo) > 0 {
+		recipients, err := uc.repo.ListRecipients(ctx, emailTo)
+		if err != nil {
+			return err
+		}
+		for _, recipient := range recipients {
+			emails = append(emails, entity.NotificationEmail{Recipient: recipient, Notification: notification})
+		}
+	}
+
+	if len(notifications) > 0 || len(emails) > 0 {
+		if err := uc.repo.CreateNotifications(ctx, notifications, emails); err != nil {
+			return err
+		}
+	}
+
+	for _, n := range notifications {
+		if n.InApp {
+			_ = uc.repo.PublishNotification(ctx, n)
+		}
+	}
+
+	return nil
+}
+
+// ListNotifications retrieves a page of the user's notifications, newest first, with the unread count
+func (uc *NotificationUseCase) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, paging pagination.Pagination) (*dto.NotificationListResponse, error) {
+	notifications, total, err := uc.repo.ListNotifications(ctx, userID, unreadOnly, paging)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := uc.repo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationListResponse{
+		PageResponse: pagination.NewResponse(notifications, paging, total),
+		Unread:       unread,
+	}, nil
+}
+
+// MarkRead marks one of the user's notifications read
+func (uc *NotificationUseCase) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) (*entity.Notification, error) {
+	return uc.repo.MarkNotificationRead(ctx, notificationID, userID, time.Now())
+}
+
+// MarkAllRead marks every unread notification of the user read and returns how many changed
+func (uc *NotificationUseCase) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return uc.repo.MarkAllNotificationsRead(ctx, userID, time.Now())
+}
+
+// Subscribe starts delivering the user's new notifications as they are published by any instance
+func (uc *NotificationUseCase) Subscribe(userID uuid.UUID) *repository.Subscription {
+	return uc.hub.Subscribe(userID)
+}
+
+// ListMissedNotifications retrieves the notifications a reconnecting stream missed after the last one
+// it received, oldest first
+func (uc *NotificationUseCase) ListMissedNotifications(ctx context.Context, userID, lastID uuid.UUID) ([]entity.Notification, error) {
+	return uc.repo.ListNotificationsAfter(ctx, userID, lastID, maxMissedNotifications)
+}
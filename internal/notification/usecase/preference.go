@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/notification/dto"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/google/uuid"
+)
+
+// GetPreferences returns the channels the user receives every notification type on
+func (uc *NotificationUseCase) GetPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error) {
+	saved, err := uc.repo.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[entity.NotificationType]entity.NotificationPreference, len(saved))
+	for _, preference := range saved {
+		byType[preference.Type] = preference
+	}
+
+	preferences := make([]entity.NotificationPreference, 0, len(entity.NotificationTypes))
+	for _, notificationType := range entity.NotificationTypes {
+		preference := notificationType.DefaultPreference(userID)
+		if s, ok := byType[notificationType]; ok {
+			preference.Email, preference.InApp, preference.Digest = s.Email, s.InApp, s.Digest
+		}
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences changes the channels of the notification types in the request and returns every
+// preference of the user
+func (uc *NotificationUseCase) UpdatePreferences(ctx context.Context, userID uuid.UUID, req dto.UpdateNotificationPreferencesRequest) ([]entity.NotificationPreference, error) {
+	current, err := uc.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[entity.NotificationType]entity.NotificationPreference, len(current))
+	for _, preference := range current {
+		byType[preference.Type] = preference
+	}
+
+	changed := make([]entity.NotificationPreference, 0, len(req.Preferences))
+	for _, item := range req.Preferences {
+		preference := byType[entity.NotificationType(item.Type)]
+		if item.Email != nil {
+			preference.Email = *item.Email
+		}
+		if item.InApp != nil {
+			preference.InApp = *item.InApp
+		}
+		if item.Digest != nil {
+			preference.Digest = *item.Digest
+		}
+		changed = append(changed, preference)
+	}
+
+	if err := uc.repo.SavePreferences(ctx, userID, changed); err != nil {
+		return nil, err
+	}
+
+	return uc.GetPreferences(ctx, userID)
+}
+
+// EmailEnabled reports whether the user wants emails about a notification type
+func (uc *NotificationUseCase) EmailEnabled(ctx context.Context, userID uuid.UUID, notificationType entity.NotificationType) (bool, error) {
+	preferences, err := uc.preferencesForType(ctx, []uuid.UUID{userID}, notificationType)
+	if err != nil {
+		return false, err
+	}
+
+	return preferences[userID].Enables(entity.ChannelEmail), nil
+}
+
+// preferencesForType resolves the preference of every user for one notification type
+func (uc *NotificationUseCase) preferencesForType(ctx context.Context, userIDs []uuid.UUID, notificationType entity.NotificationType) (map[uuid.UUID]entity.NotificationPreference, error) {
+	saved, err := uc.repo.ListPreferencesForType(ctx, userIDs, notificationType)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[uuid.UUID]entity.NotificationPreference, len(userIDs))
+	for _, userID := range userIDs {
+		preferences[userID] = notificationType.DefaultPreference(userID)
+	}
+	for _, preference := range saved {
+		preferences[preference.UserID] = preference
+	}
+
+	return preferences, nil
+}
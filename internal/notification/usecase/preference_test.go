@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/notification/entity"
+	"github.com/agpprastyo/career-link/internal/notification/repository"
+	"github.com/agpprastyo/career-link/internal/notification/usecase"
+	"github.com/google/uuid"
+)
+
+// preferenceRepo serves the saved preference rows, as if read from the database
+type preferenceRepo struct {
+	repository.Repository
+	saved []entity.NotificationPreference
+}
+
+func (r *preferenceRepo) ListPreferences(ctx context.Context, userID uuid.UUID) ([]entity.NotificationPreference, error) {
+	var preferences []entity.NotificationPreference
+	for _, p := range r.saved {
+		if p.UserID == userID {
+			preferences = append(preferences, p)
+		}
+	}
+	return preferences, nil
+}
+
+func (r *preferenceRepo) ListPreferencesForType(ctx context.Context, userIDs []uuid.UUID, notificationType entity.NotificationType) ([]entity.NotificationPreference, error) {
+	var preferences []entity.NotificationPreference
+	for _, p := range r.saved {
+		for _, userID := range userIDs {
+			if p.UserID == userID && p.Type == notificationType {
+				preferences = append(preferences, p)
+			}
+		}
+	}
+	return preferences, nil
+}
+
+func newPreferenceUseCase(saved ...entity.NotificationPreference) *usecase.NotificationUseCase {
+	return usecase.NewNotificationUseCase(&preferenceRepo{saved: saved}, nil, testutil.Logger())
+}
+
+func TestEmailEnabledWithoutPreferenceRow(t *testing.T) {
+	uc := newPreferenceUseCase()
+	userID := uuid.New()
+
+	for _, notificationType := range entity.NotificationTypes {
+		got, err := uc.EmailEnabled(context.Background(), userID, notificationType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := notificationType.DefaultPreference(userID).Email; got != want {
+			t.Errorf("%s: got %v, want default %v", notificationType, got, want)
+		}
+	}
+}
+
+func TestEmailEnabledUsesSavedRow(t *testing.T) {
+	userID := uuid.New()
+	uc := newPreferenceUseCase(entity.NotificationPreference{UserID: userID, Type: entity.NotificationApplicationStatus, Email: false, InApp: true})
+
+	got, err := uc.EmailEnabled(context.Background(), userID, entity.NotificationApplicationStatus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Error("saved preference turning email off was ignored")
+	}
+
+	// Other users and types still fall back to the default
+	if got, _ := uc.EmailEnabled(context.Background(), uuid.New(), entity.NotificationApplicationStatus); !got {
+		t.Error("another user lost the default")
+	}
+	if got, _ := uc.EmailEnabled(context.Background(), userID, entity.NotificationJobAlert); !got {
+		t.Error("another type lost the default")
+	}
+}
+
+func TestGetPreferencesFillsMissingRows(t *testing.T) {
+	userID := uuid.New()
+	uc := newPreferenceUseCase(entity.NotificationPreference{UserID: userID, Type: entity.NotificationMessageReceived, Email: true, Digest: true})
+
+	preferences, err := uc.GetPreferences(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preferences) != len(entity.NotificationTypes) {
+		t.Fatalf("got %d preferences, want %d", len(preferences), len(entity.NotificationTypes))
+	}
+
+	for i, p := range preferences {
+		if p.Type != entity.NotificationTypes[i] {
+			t.Errorf("preference %d has type %s, want %s", i, p.Type, entity.NotificationTypes[i])
+		}
+
+		want := p.Type.DefaultPreference(userID)
+		if p.Type == entity.NotificationMessageReceived {
+			want.Email, want.InApp, want.Digest = true, false, true
+		}
+		if p.Email != want.Email || p.InApp != want.InApp || p.Digest != want.Digest {
+			t.Errorf("%s: got email %v, in-app %v, digest %v", p.Type, p.Email, p.InApp, p.Digest)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"github.com/agpprastyo/career-link/internal/notification/repository"
+	"github.com/agpprastyo/career-link/pkg/logger"
+)
+
+// NotificationUseCase implements in-app notification business logic
+type NotificationUseCase struct {
+	repo repository.Repository
+	hub  *repository.Hub
+	log  *logger.Logger
+}
+
+// NewNotificationUseCase creates a new NotificationUseCase instance
+func NewNotificationUseCase(repo repository.Repository, hub *repository.Hub, log *logger.Logger) *NotificationUseCase {
+	return &NotificationUseCase{
+		repo: repo,
+		hub:  hub,
+		log:  log,
+	}
+}
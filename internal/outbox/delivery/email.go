@@ -0,0 +1,60 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/outbox/entity"
+	"github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListEmails handles fetching outbox emails, newest first. Pass status=dead to review the emails that
+// could not be delivered. Bodies are left out because they carry tokens.
+func (h *OutboxHandler) ListEmails(c *fiber.Ctx) error {
+	var status *entity.EmailStatus
+	if s := c.Query("status"); s != "" {
+		filter := entity.EmailStatus(s)
+		if !validator.In(filter, entity.EmailStatuses...) {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Status must be one of pending, sent or dead")
+		}
+		status = &filter
+	}
+
+	paging := pagination.ExtractFromRequest(c)
+
+	emails, err := h.outboxUseCase.ListEmails(c.Context(), status, paging)
+	if err != nil {
+		h.log.WithError(err).Error("List outbox emails failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(emails)
+}
+
+// RetryEmail handles putting a dead email back in the outbox
+func (h *OutboxHandler) RetryEmail(c *fiber.Ctx) error {
+	emailID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid email ID format")
+	}
+
+	email, err := h.outboxUseCase.RetryEmail(c.Context(), emailID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEmailNotFound):
+			return responseError.RespondWithError(c, fiber.StatusNotFound, "Email not found")
+		case errors.Is(err, repository.ErrEmailNotDead):
+			return responseError.RespondWithError(c, fiber.StatusConflict, "Only dead emails can be retried")
+		}
+		h.log.WithError(err).Error("Retry outbox email failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Email queued for delivery",
+		"data":    email,
+	})
+}
@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/outbox/usecase"
+	userEntity "github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutboxHandler handles HTTP requests for administering the email outbox
+type OutboxHandler struct {
+	outboxUseCase *usecase.OutboxUseCase
+	userRepo      *repository.UserRepository
+	log           *logger.Logger
+	tokenMaker    token.Maker
+}
+
+// NewOutboxHandler creates a new email outbox HTTP handler
+func NewOutboxHandler(outboxUseCase *usecase.OutboxUseCase, log *logger.Logger, tokenMaker token.Maker, userRepo *repository.UserRepository) *OutboxHandler {
+	return &OutboxHandler{
+		outboxUseCase: outboxUseCase,
+		userRepo:      userRepo,
+		log:           log,
+		tokenMaker:    tokenMaker,
+	}
+}
+
+// RegisterAdminRoutes registers the outbox review routes, which the emails:manage permission allows
+func (h *OutboxHandler) RegisterAdminRoutes(router fiber.Router) {
+	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
+	router.Use(middleware.RequireAdminMiddleware())
+	router.Use(middleware.RequireAdminMFAMiddleware(h.userRepo, h.log))
+
+	emailsManage := middleware.RequirePermission(userEntity.PermissionEmailsManage)
+
+	router.Get("/", emailsManage, h.ListEmails)
+	router.Post("/:id/retry", emailsManage, h.RetryEmail)
+}
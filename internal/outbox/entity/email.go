@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/google/uuid"
+	"time"
+)
+
+// EmailStatus is the delivery state of an outbox email
+type EmailStatus string
+
+const (
+	EmailPending EmailStatus = "pending" // Waiting for its next delivery attempt
+	EmailSent    EmailStatus = "sent"
+	EmailDead    EmailStatus = "dead" // Every attempt failed, only an admin retry sends it again
+)
+
+// EmailStatuses lists every outbox email status
+var EmailStatuses = []EmailStatus{EmailPending, EmailSent, EmailDead}
+
+// EmailKind says which flow wrote an outbox email
+type EmailKind string
+
+const (
+	EmailKindVerification  EmailKind = "email_verification"
+	EmailKindWelcome       EmailKind = "welcome"
+	EmailKindPasswordReset EmailKind = "password_reset"
+	// Company account emails
+	EmailKindCompanyInvitation   EmailKind = "company_invitation"
+	EmailKindCompanyVerification EmailKind = "company_verification"
+	// Notifications users chose to receive by email
+	EmailKindNotification EmailKind = "notification"
+)
+
+// Email is an email waiting in, or delivered from, the outbox
+type Email struct {
+	ID            uuid.UUID   `json:"id" db:"id"`
+	Kind          EmailKind   `json:"kind" db:"kind"`
+	Recipient     string      `json:"recipient" db:"recipient"`
+	Subject       string      `json:"subject" db:"subject"`
+	Body          string      `json:"-" db:"body"` // Carries verification and reset tokens, so it is never shown
+	IsHTML        bool        `json:"-" db:"is_html"`
+	Status        EmailStatus `json:"status" db:"status"`
+	Attempts      int         `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time   `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     *string     `json:"last_error" db:"last_error"`
+	SentAt        *time.Time  `json:"sent_at" db:"sent_at"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// Message returns the email as it is handed to the mail client
+func (e Email) Message() mail.EmailMessage {
+	return mail.EmailMessage{
+		To:      e.Recipient,
+		Subject: e.Subject,
+		Body:    e.Body,
+		IsHTML:  e.IsHTML,
+	}
+}
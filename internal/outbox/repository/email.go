@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/outbox/entity"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/google/uuid"
+	"time"
+)
+
+const emailColumns = `id, kind, recipient, subject, body, is_html, status, attempts, next_attempt_at, last_error,
+	sent_at, created_at, updated_at`
+
+// EnqueueEmail writes an email to the outbox inside the caller's transaction, so it is sent exactly
+// when the change that caused it commits. Headers and attachments are not kept, so messages carrying
+// them are refused.
+func EnqueueEmail(ctx context.Context, tx *sql.Tx, kind entity.EmailKind, message mail.EmailMessage) error {
+	if len(message.Headers) > 0 || len(message.Attachments) > 0 {
+		return ErrUnsupportedMessage
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		INSERT INTO email_outbox (id, kind, recipient, subject, body, is_html)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, query, id, kind, message.To, message.Subject, message.Body, message.IsHTML)
+	return err
+}
+
+// ClaimDueEmails takes up to limit pending emails due at now, oldest first. Claiming counts an attempt
+// and moves the next attempt to leaseUntil, so concurrent workers skip them and an email whose worker
+// died is retried once the lease runs out.
+func (r *OutboxRepository) ClaimDueEmails(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.Email, error) {
+	const query = `
+		WITH due AS (
+			SELECT id
+			FROM email_outbox
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE email_outbox o
+		SET attempts = o.attempts + 1, next_attempt_at = $2
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.kind, o.recipient, o.subject, o.body, o.is_html, o.status, o.attempts,
+		          o.next_attempt_at, o.last_error, o.sent_at, o.created_at, o.updated_at
+	`
+
+	emails := []entity.Email{}
+	if err := r.db.SelectContext(ctx, &emails, query, now, leaseUntil, limit); err != nil {
+		r.log.WithError(err).Error("Failed to claim outbox emails")
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// SendEmail hands an outbox email to the mail client
+func (r *OutboxRepository) SendEmail(ctx context.Context, email entity.Email) error {
+	return r.mail.SendEmail(ctx, email.Message())
+}
+
+// MarkEmailSent records that an email was delivered
+func (r *OutboxRepository) MarkEmailSent(ctx context.Context, id uuid.UUID, now time.Time) error {
+	const query = `UPDATE email_outbox SET status = 'sent', sent_at = $1, last_error = NULL WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, now, id); err != nil {
+		r.log.WithError(err).WithField("email_id", id).Error("Failed to mark outbox email sent")
+		return err
+	}
+
+	return nil
+}
+
+// RescheduleEmail records a failed attempt and when the email is tried next
+func (r *OutboxRepository) RescheduleEmail(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	const query = `UPDATE email_outbox SET next_attempt_at = $1, last_error = $2 WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, nextAttemptAt, lastError, id); err != nil {
+		r.log.WithError(err).WithField("email_id", id).Error("Failed to reschedule outbox email")
+		return err
+	}
+
+	return nil
+}
+
+// MarkEmailDead records the last failed attempt of an email that is no longer retried
+func (r *OutboxRepository) MarkEmailDead(ctx context.Context, id uuid.UUID, lastError string) error {
+	const query = `UPDATE email_outbox SET status = 'dead', last_error = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, id); err != nil {
+		r.log.WithError(err).WithField("email_id", id).Error("Failed to mark outbox email dead")
+		return err
+	}
+
+	return nil
+}
+
+// GetEmail retrieves an outbox email by ID
+func (r *OutboxRepository) GetEmail(ctx context.Context, id uuid.UUID) (*entity.Email, error) {
+	emails := []entity.Email{}
+	query := `SELECT ` + emailColumns + ` FROM email_outbox WHERE id = $1`
+	if err := r.db.SelectContext(ctx, &emails, query, id); err != nil {
+		r.log.WithError(err).WithField("email_id", id).Error("Failed to get outbox email")
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return nil, ErrEmailNotFound
+	}
+
+	return &emails[0], nil
+}
+
+// ListEmails retrieves a page of outbox emails, newest first, optionally only those with a status
+func (r *OutboxRepository) ListEmails(ctx context.Context, status *entity.EmailStatus, paging pagination.Pagination) ([]entity.Email, int, error) {
+	where := ``
+	var args []interface{}
+	if status != nil {
+		where = ` WHERE status = $1`
+		args = append(args, *status)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM email_outbox`+where, args...).Scan(&total); err != nil {
+		r.log.WithError(err).Error("Failed to count outbox emails")
+		return nil, 0, err
+	}
+
+	emails := []entity.Email{}
+	query := `SELECT ` + emailColumns + ` FROM email_outbox` + where + ` ORDER BY created_at DESC, id DESC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &emails, query, args...); err != nil {
+		r.log.WithError(err).Error("Failed to list outbox emails")
+		return nil, 0, err
+	}
+
+	return emails, total, nil
+}
+
+// RequeueEmail makes a dead email pending again with a fresh set of attempts, due at now
+func (r *OutboxRepository) RequeueEmail(ctx context.Context, id uuid.UUID, now time.Time) (*entity.Email, error) {
+	emails := []entity.Email{}
+	query := `
+		UPDATE email_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = $1
+		WHERE id = $2 AND status = 'dead'
+		RETURNING ` + emailColumns
+	if err := r.db.SelectContext(ctx, &emails, query, now, id); err != nil {
+		r.log.WithError(err).WithField("email_id", id).Error("Failed to requeue outbox email")
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		if _, err := r.GetEmail(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrEmailNotDead
+	}
+
+	return &emails[0], nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/outbox/entity"
+	"github.com/agpprastyo/career-link/pkg/database"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"github.com/agpprastyo/career-link/pkg/mail"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrEmailNotFound      = errors.New("email not found")
+	ErrEmailNotDead       = errors.New("email is not dead")
+	ErrUnsupportedMessage = errors.New("outbox emails cannot carry headers or attachments")
+)
+
+// OutboxRepository implements the email outbox using PostgreSQL, delivering through SendGrid
+type OutboxRepository struct {
+	db   *database.PostgresDB
+	log  *logger.Logger
+	mail *mail.Client
+}
+
+// Repository defines the interface for email outbox operations
+type Repository interface {
+	ClaimDueEmails(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.Email, error)
+	SendEmail(ctx context.Context, email entity.Email) error
+	MarkEmailSent(ctx context.Context, id uuid.UUID, now time.Time) error
+	RescheduleEmail(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	MarkEmailDead(ctx context.Context, id uuid.UUID, lastError string) error
+	GetEmail(ctx context.Context, id uuid.UUID) (*entity.Email, error)
+	ListEmails(ctx context.Context, status *entity.EmailStatus, paging pagination.Pagination) ([]entity.Email, int, error)
+	RequeueEmail(ctx context.Context, id uuid.UUID, now time.Time) (*entity.Email, error)
+}
+
+// NewOutboxRepository creates new OutboxRepository
+func NewOutboxRepository(db *database.PostgresDB, log *logger.Logger, mail *mail.Client) *OutboxRepository {
+	return &OutboxRepository{
+		db:   db,
+		log:  log,
+		mail: mail,
+	}
+}
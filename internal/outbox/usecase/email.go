@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/outbox/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	// emailBatch is how many outbox emails are claimed at once
+	emailBatch = 50
+	// emailLease is how long a claimed email is left to its worker before it is tried again. It must
+	// outlast a delivery run.
+	emailLease = 5 * time.Minute
+	// maxEmailAttempts is how many times an email is tried before it is dead
+	maxEmailAttempts = 8
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff between attempts
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = 6 * time.Hour
+)
+
+// DeliverEmails sends every due outbox email. A failed email is retried with exponential backoff and
+// is dead after its last attempt, waiting for an admin retry. It runs as a scheduled job.
+func (uc *OutboxUseCase) DeliverEmails(ctx context.Context) error {
+	for {
+		now := time.Now()
+		emails, err := uc.repo.ClaimDueEmails(ctx, now, now.Add(emailLease), emailBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, email := range emails {
+			if ctx.Err() != nil {
+				// Emails left unsent are retried once their lease runs out
+				return ctx.Err()
+			}
+			uc.deliverEmail(ctx, email)
+		}
+
+		if len(emails) < emailBatch || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// deliverEmail sends one claimed email and records the outcome
+func (uc *OutboxUseCase) deliverEmail(ctx context.Context, email entity.Email) {
+	log := uc.log.WithFields(logrus.Fields{
+		"email_id": email.ID,
+		"kind":     email.Kind,
+		"attempt":  email.Attempts,
+	})
+
+	// The outcome is recorded even when the run times out during the send
+	recordCtx := context.WithoutCancel(ctx)
+
+	sendErr := uc.repo.SendEmail(ctx, email)
+	if sendErr == nil {
+		if err := uc.repo.MarkEmailSent(recordCtx, email.ID, time.Now()); err != nil {
+			log.WithError(err).Error("Failed to record outbox email delivery")
+		}
+		return
+	}
+
+	if email.Attempts >= maxEmailAttempts {
+		log.WithError(sendErr).Error("Outbox email is dead after its last attempt")
+		if err := uc.repo.MarkEmailDead(recordCtx, email.ID, sendErr.Error()); err != nil {
+			log.WithError(err).Error("Failed to mark outbox email dead")
+		}
+		return
+	}
+
+	log.WithError(sendErr).Warn("Failed to send outbox email, retrying later")
+	if err := uc.repo.RescheduleEmail(recordCtx, email.ID, time.Now().Add(retryDelay(email.Attempts)), sendErr.Error()); err != nil {
+		log.WithError(err).Error("Failed to reschedule outbox email")
+	}
+}
+
+// retryDelay is the backoff after the given failed attempt: the base delay, doubled for every earlier
+// attempt, up to the maximum delay
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// ListEmails retrieves a page of outbox emails, newest first, optionally only those with a status
+func (uc *OutboxUseCase) ListEmails(ctx context.Context, status *entity.EmailStatus, paging pagination.Pagination) (*pagination.PageResponse, error) {
+	emails, total, err := uc.repo.ListEmails(ctx, status, paging)
+	if err != nil {
+		return nil, err
+	}
+
+	response := pagination.NewResponse(emails, paging, total)
+	return &response, nil
+}
+
+// RetryEmail puts a dead email back in the outbox to be sent on the next delivery run
+func (uc *OutboxUseCase) RetryEmail(ctx context.Context, id uuid.UUID) (*entity.Email, error) {
+	email, err := uc.repo.RequeueEmail(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.WithField("email_id", id).Info("Dead outbox email requeued")
+	return email, nil
+}
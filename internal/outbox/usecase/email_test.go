@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/outbox/entity"
+	"github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/google/uuid"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 16 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, 64 * time.Minute},
+		{8, 128 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 6 * time.Hour},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("attempt %d: got %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelayNeverExceedsCap(t *testing.T) {
+	prev := time.Duration(0)
+	for attempts := 1; attempts <= 1000; attempts++ {
+		delay := retryDelay(attempts)
+		if delay > retryMaxDelay {
+			t.Fatalf("attempt %d: delay %v exceeds %v", attempts, delay, retryMaxDelay)
+		}
+		if delay < prev {
+			t.Fatalf("attempt %d: delay %v shorter than the previous %v", attempts, delay, prev)
+		}
+		prev = delay
+	}
+}
+
+// deliveryRepo records the outcome deliverEmail writes for a single email
+type deliveryRepo struct {
+	repository.Repository
+	sendErr       error
+	sent          bool
+	dead          bool
+	rescheduledAt *time.Time
+	lastError     string
+}
+
+func (r *deliveryRepo) SendEmail(ctx context.Context, email entity.Email) error {
+	return r.sendErr
+}
+
+func (r *deliveryRepo) MarkEmailSent(ctx context.Context, id uuid.UUID, now time.Time) error {
+	r.sent = true
+	return nil
+}
+
+func (r *deliveryRepo) RescheduleEmail(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	r.rescheduledAt = &nextAttemptAt
+	r.lastError = lastError
+	return nil
+}
+
+func (r *deliveryRepo) MarkEmailDead(ctx context.Context, id uuid.UUID, lastError string) error {
+	r.dead = true
+	r.lastError = lastError
+	return nil
+}
+
+func TestDeliverEmail(t *testing.T) {
+	errSend := errors.New("smtp unavailable")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		sendErr   error
+		wantSent  bool
+		wantDead  bool
+		wantRetry bool
+	}{
+		{"sent on first attempt", 1, nil, true, false, false},
+		{"sent on last attempt", maxEmailAttempts, nil, true, false, false},
+		{"first attempt fails", 1, errSend, false, false, true},
+		{"attempt before the last fails", maxEmailAttempts - 1, errSend, false, false, true},
+		{"last attempt fails", maxEmailAttempts, errSend, false, true, false},
+		{"attempt past the last fails", maxEmailAttempts + 1, errSend, false, true, false},
+	}
+
+	log := testutil.Logger()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &deliveryRepo{sendErr: tt.sendErr}
+			uc := NewOutboxUseCase(repo, log)
+
+			before := time.Now()
+			uc.deliverEmail(context.Background(), entity.Email{ID: uuid.New(), Attempts: tt.attempts})
+			after := time.Now()
+
+			if repo.sent != tt.wantSent {
+				t.Errorf("sent: got %v, want %v", repo.sent, tt.wantSent)
+			}
+			if repo.dead != tt.wantDead {
+				t.Errorf("dead: got %v, want %v", repo.dead, tt.wantDead)
+			}
+			if (repo.rescheduledAt != nil) != tt.wantRetry {
+				t.Fatalf("rescheduled: got %v, want %v", repo.rescheduledAt != nil, tt.wantRetry)
+			}
+
+			if tt.sendErr != nil && repo.lastError != tt.sendErr.Error() {
+				t.Errorf("got last error %q, want %q", repo.lastError, tt.sendErr.Error())
+			}
+
+			if repo.rescheduledAt != nil {
+				delay := retryDelay(tt.attempts)
+				if repo.rescheduledAt.Before(before.Add(delay)) || repo.rescheduledAt.After(after.Add(delay)) {
+					t.Errorf("rescheduled at %v, want %v after now", repo.rescheduledAt, delay)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/pkg/logger"
+)
+
+// OutboxUseCase implements delivery and administration of the email outbox
+type OutboxUseCase struct {
+	repo repository.Repository
+	log  *logger.Logger
+}
+
+// NewOutboxUseCase creates a new OutboxUseCase instance
+func NewOutboxUseCase(repo repository.Repository, log *logger.Logger) *OutboxUseCase {
+	return &OutboxUseCase{
+		repo: repo,
+		log:  log,
+	}
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run by the Scheduler at a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration // Deadline of a single run, defaults to the interval
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in the background, each on its own ticker. A run that is still
+// going when the next tick arrives is not overlapped; the tick is skipped.
+type Scheduler struct {
+	jobs []Job
+	log  *logger.Logger
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewScheduler(log *logger.Logger) *Scheduler {
+	return &Scheduler{
+		log:  log,
+		quit: make(chan struct{}),
+	}
+}
+
+// Add registers a job. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job) {
+	if job.Timeout == 0 {
+		job.Timeout = job.Interval
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop signals every job to stop and waits for runs in progress to finish
+func (s *Scheduler) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.run(job)
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(job Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), job.Timeout)
+	defer cancel()
+
+	// Let Stop cut a long run short instead of waiting for its timeout
+	go func() {
+		select {
+		case <-s.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.WithField("job", job.Name).Errorf("Scheduled job panicked: %v", r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.log.WithError(err).WithField("job", job.Name).Error("Scheduled job failed")
+		return
+	}
+	s.log.WithField("job", job.Name).WithField("duration", time.Since(start).String()).Debug("Scheduled job finished")
+}
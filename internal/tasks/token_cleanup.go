@@ -13,13 +13,15 @@ type TokenRepository interface {
 type TokenCleanupService struct {
 	repo TokenRepository
 	log  *logger.Logger
+	loc  *time.Location // Zone whose midnight triggers the cleanup
 	quit chan struct{}
 }
 
-func NewTokenCleanupService(repo TokenRepository, log *logger.Logger) *TokenCleanupService {
+func NewTokenCleanupService(repo TokenRepository, log *logger.Logger, loc *time.Location) *TokenCleanupService {
 	return &TokenCleanupService{
 		repo: repo,
 		log:  log,
+		loc:  loc,
 		quit: make(chan struct{}),
 	}
 }
@@ -33,15 +35,13 @@ func (s *TokenCleanupService) Stop() {
 }
 
 func (s *TokenCleanupService) scheduleDailyCleanup() {
-	// Calculate time until next midnight UTC+7
-	now := time.Now()
-	loc, err := time.LoadLocation("Asia/Jakarta") // UTC+7
-	if err != nil {
-		s.log.WithError(err).Error("Failed to load timezone, using local timezone")
+	// Calculate time until next midnight in the configured zone
+	loc := s.loc
+	if loc == nil {
 		loc = time.Local
 	}
 
-	now = now.In(loc)
+	now := time.Now().In(loc)
 	nextMidnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
 	duration := nextMidnight.Sub(now)
 
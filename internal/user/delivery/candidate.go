@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+)
+
+// SearchCandidates godoc
+// @Summary Search candidates
+// @Description Search the job seekers visible to the current user's company. Public profiles are always visible, applied_only profiles only once they applied to one of the company's postings. List filters take comma separated values.
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Param skills query string false "Required skills, comma separated; candidates must have all of them"
+// @Param min_proficiency query string false "Minimum proficiency of the required skills: beginner, intermediate, advanced or expert"
+// @Param field_of_study query string false "Fields of study, comma separated; candidates must match one"
+// @Param min_years query number false "Minimum years of experience"
+// @Param max_years query number false "Maximum years of experience"
+// @Param location query string false "Preferred location"
+// @Param remote query bool false "Remote preference"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Items per page"
+// @Success 200 {object} pagination.PageResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/candidates [get]
+func (h *UserHandler) SearchCandidates(c *fiber.Ctx) error {
+	var v validator.Validator
+	var filter entity.CandidateSearchFilter
+
+	filter.Skills = splitQueryList(c.Query("skills"))
+	v.CheckField(len(filter.Skills) <= 20, "skills", "Too many skills (max 20)")
+	if level := c.Query("min_proficiency"); level != "" {
+		v.CheckField(validator.In(level, entity.ProficiencyLevels...), "min_proficiency", "Minimum proficiency must be one of beginner, intermediate, advanced or expert")
+		v.CheckField(len(filter.Skills) > 0, "min_proficiency", "Minimum proficiency requires at least one skill")
+		filter.MinProficiency = &level
+	}
+	filter.FieldsOfStudy = splitQueryList(c.Query("field_of_study"))
+	filter.MinYearsExperience = parseYearsQuery(c, &v, "min_years")
+	filter.MaxYearsExperience = parseYearsQuery(c, &v, "max_years")
+	if filter.MinYearsExperience != nil && filter.MaxYearsExperience != nil {
+		v.CheckField(*filter.MinYearsExperience <= *filter.MaxYearsExperience, "max_years", "Maximum years must not be less than minimum years")
+	}
+	if location := strings.TrimSpace(c.Query("location")); location != "" {
+		filter.Location = &location
+	}
+	if remote := c.Query("remote"); remote != "" {
+		isRemote, err := strconv.ParseBool(remote)
+		v.CheckField(err == nil, "remote", "Remote must be true or false")
+		filter.Remote = &isRemote
+	}
+
+	if v.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, v.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	paging := pagination.ExtractFromRequest(c)
+
+	candidates, err := h.userUseCase.SearchCandidates(c.Context(), userID, filter, paging)
+	if err != nil {
+		return h.respondCompanyMemberError(c, err, "Search candidates failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(candidates)
+}
+
+// splitQueryList splits a comma separated query value, dropping blank items
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseYearsQuery parses an optional non-negative number of years
+func parseYearsQuery(c *fiber.Ctx, v *validator.Validator, key string) *float64 {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	years, err := strconv.ParseFloat(value, 64)
+	v.CheckField(err == nil && years >= 0, key, "Years must be a non-negative number")
+	return &years
+}
@@ -0,0 +1,180 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// maxCompanyLogoSize is the largest company logo accepted, in bytes
+const maxCompanyLogoSize = 2 * 1024 * 1024
+
+// GetCompany godoc
+// @Summary Get company profile
+// @Description Get the company owned by the current user, including its address
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.Company
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company [get]
+func (h *UserHandler) GetCompany(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	company, err := h.userUseCase.GetCompany(c.Context(), userID)
+	if err != nil {
+		return h.respondCompanyError(c, err, "Get company failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(company)
+}
+
+// UpdateCompany godoc
+// @Summary Update company profile
+// @Description Update the profile, size and address of the company owned by the current user
+// @Tags company
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateCompanyRequest true "Company profile"
+// @Success 200 {object} entity.Company
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company [put]
+func (h *UserHandler) UpdateCompany(c *fiber.Ctx) error {
+	var req dto.UpdateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update company request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateCompanyProfile(&req.Validator, &req.CompanyProfileDTO)
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	company, err := h.userUseCase.UpdateCompany(c.Context(), userID, req)
+	if err != nil {
+		return h.respondCompanyError(c, err, "Update company failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Company updated successfully",
+		"data":    company,
+	})
+}
+
+// UpdateCompanyLogo godoc
+// @Summary Upload company logo
+// @Description Replace the logo of the company owned by the current user
+// @Tags company
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param logo formData file true "Logo image (jpg, jpeg or png, max 2MB)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/logo [put]
+func (h *UserHandler) UpdateCompanyLogo(c *fiber.Ctx) error {
+	file, err := c.FormFile("logo")
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Logo file is required")
+	}
+
+	if file.Size > maxCompanyLogoSize {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "File too large (max 2MB)")
+	}
+
+	fileExt := strings.ToLower(filepath.Ext(file.Filename))
+	allowedExts := map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
+	if !allowedExts[fileExt] {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid file type. Only jpg, jpeg and png allowed")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.log.WithError(err).Error("Failed to open uploaded file")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+	defer func(src multipart.File) {
+		err := src.Close()
+		if err != nil {
+			h.log.WithError(err).Error("Failed to close file")
+		}
+	}(src)
+
+	fileBytes, err := io.ReadAll(src)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to read file content")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	contentType := http.DetectContentType(fileBytes)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "File must be a JPEG or PNG image")
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	logoURL, err := h.userUseCase.UpdateCompanyLogo(c.Context(), userID, dto.UpdateCompanyLogoRequest{
+		FileExt:     fileExt,
+		FileContent: fileBytes,
+		FileSize:    int64(len(fileBytes)),
+		ContentType: contentType,
+	})
+	if err != nil {
+		return h.respondCompanyError(c, err, "Update company logo failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Company logo updated successfully",
+		"logo_url": logoURL,
+	})
+}
+
+// DeleteCompanyLogo godoc
+// @Summary Remove company logo
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/logo [delete]
+func (h *UserHandler) DeleteCompanyLogo(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.DeleteCompanyLogo(c.Context(), userID); err != nil {
+		return h.respondCompanyError(c, err, "Delete company logo failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Company logo removed"})
+}
+
+// respondCompanyError maps company profile errors to HTTP responses
+func (h *UserHandler) respondCompanyError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrCompanyNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company not found")
+	case errors.Is(err, repository.ErrInvalidInput):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid company data")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,281 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListCompanyMembers godoc
+// @Summary List company members
+// @Description List the team of the current user's company
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.CompanyMemberDetail
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/members [get]
+func (h *UserHandler) ListCompanyMembers(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	members, err := h.userUseCase.ListCompanyMembers(c.Context(), userID)
+	if err != nil {
+		return h.respondCompanyMemberError(c, err, "List company members failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(members)
+}
+
+// UpdateCompanyMemberRole godoc
+// @Summary Change a member's role
+// @Description Change the role of a member of the current user's company. Owners only.
+// @Tags company
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "Member user ID"
+// @Param request body dto.UpdateCompanyMemberRequest true "New role"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/members/{userId} [patch]
+func (h *UserHandler) UpdateCompanyMemberRole(c *fiber.Ctx) error {
+	memberUserID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid user ID format")
+	}
+
+	var req dto.UpdateCompanyMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update company member request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(validator.In(entity.CompanyMemberRole(req.Role), entity.CompanyMemberRoles...), "Role", "Role must be one of owner, recruiter or viewer")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.UpdateCompanyMemberRole(c.Context(), userID, memberUserID, req); err != nil {
+		return h.respondCompanyMemberError(c, err, "Update company member role failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Company member role updated successfully",
+	})
+}
+
+// RemoveCompanyMember godoc
+// @Summary Remove a company member
+// @Description Remove a member from the current user's company and sign them out. Owners only.
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "Member user ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/members/{userId} [delete]
+func (h *UserHandler) RemoveCompanyMember(c *fiber.Ctx) error {
+	memberUserID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid user ID format")
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.RemoveCompanyMember(c.Context(), userID, memberUserID); err != nil {
+		return h.respondCompanyMemberError(c, err, "Remove company member failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Company member removed successfully",
+	})
+}
+
+// InviteCompanyMember godoc
+// @Summary Invite a company member
+// @Description Email an invitation to join the current user's company. Inviting an email again replaces its open invitation. Owners only.
+// @Tags company
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.InviteCompanyMemberRequest true "Invitee email and role"
+// @Success 201 {object} entity.CompanyInvitation
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/invitations [post]
+func (h *UserHandler) InviteCompanyMember(c *fiber.Ctx) error {
+	var req dto.InviteCompanyMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode invite company member request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Email != "", "Email", "Email is required")
+	req.Validator.CheckField(validator.Matches(req.Email, validator.RgxEmail), "Email", "Must be a valid email address")
+	req.Validator.CheckField(validator.In(entity.CompanyMemberRole(req.Role), entity.CompanyMemberRoles...), "Role", "Role must be one of owner, recruiter or viewer")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	inv, err := h.userUseCase.InviteCompanyMember(c.Context(), userID, req)
+	if err != nil {
+		return h.respondCompanyMemberError(c, err, "Invite company member failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(inv)
+}
+
+// ListCompanyInvitations godoc
+// @Summary List open company invitations
+// @Description List the invitations of the current user's company that were neither accepted nor revoked. Owners only.
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.CompanyInvitation
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/invitations [get]
+func (h *UserHandler) ListCompanyInvitations(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	invitations, err := h.userUseCase.ListCompanyInvitations(c.Context(), userID)
+	if err != nil {
+		return h.respondCompanyMemberError(c, err, "List company invitations failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(invitations)
+}
+
+// RevokeCompanyInvitation godoc
+// @Summary Revoke a company invitation
+// @Description Revoke an open invitation so its link can no longer be used. Owners only.
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/invitations/{id} [delete]
+func (h *UserHandler) RevokeCompanyInvitation(c *fiber.Ctx) error {
+	invitationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid invitation ID format")
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.RevokeCompanyInvitation(c.Context(), userID, invitationID); err != nil {
+		return h.respondCompanyMemberError(c, err, "Revoke company invitation failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Company invitation revoked successfully",
+	})
+}
+
+// AcceptCompanyInvitation godoc
+// @Summary Accept a company invitation
+// @Description Join a company with the token from an invitation email. Existing company accounts confirm with their password; otherwise a username is required and a new, already verified account is created.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptCompanyInvitationRequest true "Invitation token and credentials"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /users/company-invitations/accept [post]
+func (h *UserHandler) AcceptCompanyInvitation(c *fiber.Ctx) error {
+	var req dto.AcceptCompanyInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode accept company invitation request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Email != "", "Email", "Email is required")
+	req.Validator.CheckField(validator.Matches(req.Email, validator.RgxEmail), "Email", "Must be a valid email address")
+	req.Validator.CheckField(req.Token != "", "Token", "Token is required")
+	req.Validator.CheckField(req.Password != "", "Password", "Password is required")
+	if req.Username != "" {
+		req.Validator.CheckField(len(req.Username) >= 3, "Username", "Username is too short")
+		req.Validator.CheckField(len(req.Username) <= 50, "Username", "Username is too long")
+		req.Validator.CheckField(len(req.Password) >= 8, "Password", "Password is too short")
+		req.Validator.CheckField(len(req.Password) <= 72, "Password", "Password is too long")
+		req.Validator.CheckField(validator.NotIn(req.Password, utils.CommonPasswords...), "Password", "Password is too common")
+	}
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	inv, err := h.userUseCase.AcceptCompanyInvitation(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidToken):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid invitation token")
+		case errors.Is(err, repository.ErrTokenAlreadyUsed):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invitation was already used or revoked")
+		case errors.Is(err, repository.ErrTokenExpired):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invitation has expired")
+		case errors.Is(err, repository.ErrInvalidCredentials):
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid credentials")
+		case errors.Is(err, repository.ErrInvalidInput):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Username is required to create a new account")
+		case errors.Is(err, repository.ErrUserAlreadyExists):
+			return responseError.RespondWithError(c, fiber.StatusConflict, "Username is already taken")
+		default:
+			return h.respondCompanyMemberError(c, err, "Accept company invitation failed")
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":    "Company invitation accepted, you can now log in",
+		"company_id": inv.CompanyID,
+		"role":       inv.Role,
+	})
+}
+
+// respondCompanyMemberError maps company team errors to HTTP responses
+func (h *UserHandler) respondCompanyMemberError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrCompanyNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company not found")
+	case errors.Is(err, repository.ErrCompanyMemberNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company member not found")
+	case errors.Is(err, repository.ErrInvitationNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company invitation not found")
+	case errors.Is(err, repository.ErrAlreadyCompanyMember):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "User already belongs to a company")
+	case errors.Is(err, repository.ErrLastCompanyOwner):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Company must keep at least one owner")
+	case errors.Is(err, repository.ErrInviteeNotEligible):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "This account cannot join a company")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
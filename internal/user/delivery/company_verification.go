@@ -0,0 +1,259 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/upload"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxVerificationDocuments is how many documents one verification request may carry
+	maxVerificationDocuments = 5
+	// maxVerificationDocumentSize is the largest verification document accepted, in bytes
+	maxVerificationDocumentSize = 5 * 1024 * 1024
+)
+
+// verificationDocumentRules are the files a verification request may carry
+var verificationDocumentRules = upload.Rules{
+	Label:   "Document",
+	MaxSize: maxVerificationDocumentSize,
+	Types:   upload.DocumentTypes,
+}
+
+// SubmitCompanyVerification godoc
+// @Summary Request company verification
+// @Description Submit supporting documents, such as a business registration, for an admin to verify the company
+// @Tags company
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param documents formData file true "Supporting documents (pdf, jpg, jpeg or png, max 5MB each, up to 5 files)"
+// @Success 201 {object} entity.CompanyVerificationRequest
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/verification [post]
+func (h *UserHandler) SubmitCompanyVerification(c *fiber.Ctx) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "At least one document is required")
+	}
+
+	files := form.File["documents"]
+	if len(files) == 0 {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "At least one document is required")
+	}
+	if len(files) > maxVerificationDocuments {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Too many documents (max 5)")
+	}
+
+	documents := make([]dto.VerificationDocumentUpload, 0, len(files))
+	for _, file := range files {
+		doc, msg, err := upload.Read(file, verificationDocumentRules)
+		if err != nil {
+			h.log.WithError(err).Error("Failed to read verification document")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+		if msg != "" {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, msg)
+		}
+		documents = append(documents, dto.VerificationDocumentUpload(doc))
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	req, err := h.userUseCase.SubmitCompanyVerification(c.Context(), userID, documents)
+	if err != nil {
+		return h.respondVerificationError(c, err, "Submit company verification failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(req)
+}
+
+// GetCompanyVerification godoc
+// @Summary Get company verification status
+// @Description Get the most recent verification request of the current user's company
+// @Tags company
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.CompanyVerificationRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /company/company/verification [get]
+func (h *UserHandler) GetCompanyVerification(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	req, err := h.userUseCase.GetCompanyVerification(c.Context(), userID)
+	if err != nil {
+		return h.respondVerificationError(c, err, "Get company verification failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(req)
+}
+
+// ListCompanyVerifications godoc
+// @Summary List company verification requests
+// @Description Review queue of company verification requests, oldest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, approved or rejected"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} pagination.PageResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /admin/companies/verifications [get]
+func (h *UserHandler) ListCompanyVerifications(c *fiber.Ctx) error {
+	var status *entity.VerificationStatus
+	if s := c.Query("status"); s != "" {
+		filter := entity.VerificationStatus(s)
+		if !validator.In(filter, entity.VerificationStatuses...) {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Status must be one of pending, approved or rejected")
+		}
+		status = &filter
+	}
+
+	paging := pagination.ExtractFromRequest(c)
+
+	requests, err := h.userUseCase.ListCompanyVerifications(c.Context(), status, paging)
+	if err != nil {
+		return h.respondVerificationError(c, err, "List company verifications failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(requests)
+}
+
+// GetCompanyVerificationForReview godoc
+// @Summary Get a company verification request
+// @Description Get a verification request with its company and temporary document download links
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Verification request ID"
+// @Success 200 {object} dto.CompanyVerificationDetailResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /admin/companies/verifications/{id} [get]
+func (h *UserHandler) GetCompanyVerificationForReview(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid verification request ID format")
+	}
+
+	detail, err := h.userUseCase.GetCompanyVerificationForReview(c.Context(), id)
+	if err != nil {
+		return h.respondVerificationError(c, err, "Get company verification failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(detail)
+}
+
+// ApproveCompanyVerification godoc
+// @Summary Approve a company verification request
+// @Description Mark the company as verified and email the result to the company
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Verification request ID"
+// @Success 200 {object} entity.CompanyVerificationRequest
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /admin/companies/verifications/{id}/approve [post]
+func (h *UserHandler) ApproveCompanyVerification(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid verification request ID format")
+	}
+
+	reviewerID := uuid.MustParse(c.Locals("user_id").(string))
+
+	req, err := h.userUseCase.ApproveCompanyVerification(c.Context(), reviewerID, id)
+	if err != nil {
+		return h.respondVerificationError(c, err, "Approve company verification failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Company verified successfully",
+		"data":    req,
+	})
+}
+
+// RejectCompanyVerification godoc
+// @Summary Reject a company verification request
+// @Description Reject the request with a reason that is emailed to the company
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Verification request ID"
+// @Param request body dto.RejectVerificationRequest true "Rejection reason"
+// @Success 200 {object} entity.CompanyVerificationRequest
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 409 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /admin/companies/verifications/{id}/reject [post]
+func (h *UserHandler) RejectCompanyVerification(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid verification request ID format")
+	}
+
+	var req dto.RejectVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode reject verification request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(validator.NotBlank(req.Reason), "Reason", "Reason is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Reason, 1000), "Reason", "Reason is too long (max 1000 characters)")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	reviewerID := uuid.MustParse(c.Locals("user_id").(string))
+
+	rejected, err := h.userUseCase.RejectCompanyVerification(c.Context(), reviewerID, id, req)
+	if err != nil {
+		return h.respondVerificationError(c, err, "Reject company verification failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Verification request rejected",
+		"data":    rejected,
+	})
+}
+
+// respondVerificationError maps company verification errors to HTTP responses
+func (h *UserHandler) respondVerificationError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrCompanyNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Company not found")
+	case errors.Is(err, repository.ErrVerificationNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Verification request not found")
+	case errors.Is(err, repository.ErrCompanyAlreadyVerified):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Company is already verified")
+	case errors.Is(err, repository.ErrVerificationPending):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "A verification request is already waiting for review")
+	case errors.Is(err, repository.ErrVerificationAlreadyReviewed):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Verification request was already reviewed")
+	case errors.Is(err, repository.ErrInvalidInput):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid verification data")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
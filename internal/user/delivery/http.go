@@ -3,13 +3,13 @@ package delivery
 import (
 	"github.com/agpprastyo/career-link/config"
 	"github.com/agpprastyo/career-link/internal/common/middleware"
+	"github.com/agpprastyo/career-link/internal/user/entity"
 	"github.com/agpprastyo/career-link/internal/user/repository"
 	"github.com/agpprastyo/career-link/internal/user/usecase"
 	"github.com/agpprastyo/career-link/pkg/logger"
 	"github.com/agpprastyo/career-link/pkg/redis"
 	"github.com/agpprastyo/career-link/pkg/token"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	_ "image/gif"  // Register GIF format
 	_ "image/jpeg" // Register JPEG format
 	_ "image/png"  // Register PNG format
@@ -41,13 +41,16 @@ func (h *UserHandler) RegisterUserRoutes(router fiber.Router) {
 	router.Use(middleware.AuthRateLimiter())
 	// TODO : implement delete user session in redis if relogin
 	router.Post("/login", h.Login) // test with apidog
-	// TODO: register user (company and jobSeeker) not completed yet
+	router.Post("/login/2fa", h.LoginMFA)
 	router.Post("/register", h.Register)
+	router.Post("/refresh", h.RefreshToken)
 
 	router.Post("/resend-verification-email", h.ResendVerificationEmail) // test with apidog
 
-	// TODO : forgot password not completed yet
-	//router.Post("/forgot-password", h.ForgotPassword)
+	router.Post("/forgot-password", h.ForgotPassword)
+	router.Post("/reset-password", h.ResetPassword)
+
+	router.Post("/company-invitations/accept", h.AcceptCompanyInvitation)
 }
 
 func (h *UserHandler) RegisterUserVerifyRoute(router fiber.Router) {
@@ -60,58 +63,127 @@ func (h *UserHandler) RegisterUserWithMiddlewareRoutes(router fiber.Router) {
 	router.Post("/update-password", h.UpdatePassword)
 	router.Post("/logout", h.Logout)
 
+	router.Get("/sessions", h.ListSessions)
+	router.Delete("/sessions", h.RevokeAllSessions)
+	router.Delete("/sessions/:id", h.RevokeSession)
+
+	router.Get("/2fa", h.GetMFAStatus)
+	router.Post("/2fa/enroll", h.EnrollMFA)
+	router.Post("/2fa/verify", h.ConfirmMFA)
+	router.Post("/2fa/disable", h.DisableMFA)
+	router.Post("/2fa/recovery-codes", h.RegenerateRecoveryCodes)
+
 	router.Post("/update-avatar", h.UpdateAvatar)
 	router.Get("/user", h.GetUser)
 
 }
 
+// RegisterJobSeekerProfileRoutes registers the job seeker profile section routes. The router must be
+// nested under the /profile group so the authentication middleware already applies.
+func (h *UserHandler) RegisterJobSeekerProfileRoutes(router fiber.Router) {
+	router.Use(middleware.RequireJobSeekerMiddleware())
+
+	router.Get("/", h.GetJobSeekerProfile)
+	router.Put("/", h.UpdateJobSeekerProfile)
+	router.Put("/visibility", h.UpdateJobSeekerVisibility)
+
+	router.Get("/skills", h.ListJobSeekerSection(entity.SectionSkills))
+	router.Post("/skills", h.CreateJobSeekerSkill)
+	router.Put("/skills/order", h.ReorderJobSeekerSection(entity.SectionSkills))
+	router.Put("/skills/:id", h.UpdateJobSeekerSkill)
+	router.Delete("/skills/:id", h.DeleteJobSeekerSectionItem(entity.SectionSkills))
+
+	router.Get("/experiences", h.ListJobSeekerSection(entity.SectionExperiences))
+	router.Post("/experiences", h.CreateJobSeekerExperience)
+	router.Put("/experiences/order", h.ReorderJobSeekerSection(entity.SectionExperiences))
+	router.Put("/experiences/:id", h.UpdateJobSeekerExperience)
+	router.Delete("/experiences/:id", h.DeleteJobSeekerSectionItem(entity.SectionExperiences))
+
+	router.Get("/educations", h.ListJobSeekerSection(entity.SectionEducations))
+	router.Post("/educations", h.CreateJobSeekerEducation)
+	router.Put("/educations/order", h.ReorderJobSeekerSection(entity.SectionEducations))
+	router.Put("/educations/:id", h.UpdateJobSeekerEducation)
+	router.Delete("/educations/:id", h.DeleteJobSeekerSectionItem(entity.SectionEducations))
+
+	router.Get("/projects", h.ListJobSeekerSection(entity.SectionProjects))
+	router.Post("/projects", h.CreateJobSeekerProject)
+	router.Put("/projects/order", h.ReorderJobSeekerSection(entity.SectionProjects))
+	router.Put("/projects/:id", h.UpdateJobSeekerProject)
+	router.Delete("/projects/:id", h.DeleteJobSeekerSectionItem(entity.SectionProjects))
+
+	router.Get("/links", h.ListJobSeekerSection(entity.SectionLinks))
+	router.Post("/links", h.CreateJobSeekerLink)
+	router.Put("/links/order", h.ReorderJobSeekerSection(entity.SectionLinks))
+	router.Put("/links/:id", h.UpdateJobSeekerLink)
+	router.Delete("/links/:id", h.DeleteJobSeekerSectionItem(entity.SectionLinks))
+
+	router.Get("/preferences", h.ListJobSeekerSection(entity.SectionPreferences))
+	router.Post("/preferences", h.CreateJobSeekerPreference)
+	router.Put("/preferences/order", h.ReorderJobSeekerSection(entity.SectionPreferences))
+	router.Put("/preferences/:id", h.UpdateJobSeekerPreference)
+	router.Delete("/preferences/:id", h.DeleteJobSeekerSectionItem(entity.SectionPreferences))
+}
+
 // RegisterAdminRoutes admin routes
 func (h *UserHandler) RegisterAdminRoutes(router fiber.Router) {
 	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
 	router.Use(middleware.RequireAdminMiddleware())
-	router.Get("/admin/users", h.GetUsers)
-	router.Get("/admin/users/:id", h.GetUsersByID)
+	router.Use(middleware.RequireAdminMFAMiddleware(h.userRepo, h.log))
+
+	usersRead := middleware.RequirePermission(entity.PermissionUsersRead)
+
+	router.Get("/admin/users", usersRead, h.GetUsers)
+	router.Get("/admin/users/:id", usersRead, h.GetUsersByID)
+
+	companiesVerify := middleware.RequirePermission(entity.PermissionCompaniesVerify)
+
+	router.Get("/companies/verifications", companiesVerify, h.ListCompanyVerifications)
+	router.Get("/companies/verifications/:id", companiesVerify, h.GetCompanyVerificationForReview)
+	router.Post("/companies/verifications/:id/approve", companiesVerify, h.ApproveCompanyVerification)
+	router.Post("/companies/verifications/:id/reject", companiesVerify, h.RejectCompanyVerification)
 
 	//router.Post("/admin/users", h.CreateUser)
 	//router.Put("/admin/users/:id", h.UpdateUser)
 	//router.Delete("/admin/users/:id", h.DeleteUser)
 }
 
+// RegisterSuperAdminRoutes registers admin account management, which only the admins:manage permission allows
 func (h *UserHandler) RegisterSuperAdminRoutes(router fiber.Router) {
 	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
-	router.Use(middleware.RequireSuperAdminMiddleware())
+	router.Use(middleware.RequireAdminMiddleware())
+	router.Use(middleware.RequireAdminMFAMiddleware(h.userRepo, h.log))
+
+	adminsManage := middleware.RequirePermission(entity.PermissionAdminsManage)
 
-	router.Post("/admin", h.CreateAdmin)
+	router.Post("/admin", adminsManage, h.CreateAdmin)
 	// active and deactivate admin
-	router.Patch("/admin/:id/status", h.UpdateAdminStatus)
-	router.Delete("/admin/:id", h.DeleteAdmin)
+	router.Patch("/admin/:id/status", adminsManage, h.UpdateAdminStatus)
+	router.Delete("/admin/:id", adminsManage, h.DeleteAdmin)
 
-	router.Get("/test-admin", func(c *fiber.Ctx) error {
+	router.Get("/test-admin", adminsManage, func(c *fiber.Ctx) error {
 		return c.SendString("Hello Admin")
 	})
 }
 
 func (h *UserHandler) RegisterCompanyRoutes(router fiber.Router) {
 	router.Use(middleware.RequireAuthMiddleware(h.tokenMaker, h.userRepo, h.log))
-	router.Use(middleware.RequireCompanyMiddleware())
+	router.Use(middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log))
+	owner := middleware.RequireCompanyMemberMiddleware(h.userRepo, h.log, entity.CompanyOwner)
 
 	router.Get("/company", h.GetCompany)
-	//router.Put("/company", h.UpdateCompany)
+	router.Put("/company", owner, h.UpdateCompany)
+	router.Put("/company/logo", owner, h.UpdateCompanyLogo)
+	router.Delete("/company/logo", owner, h.DeleteCompanyLogo)
+	router.Get("/company/verification", h.GetCompanyVerification)
+	router.Post("/company/verification", owner, h.SubmitCompanyVerification)
 	//router.Delete("/company", h.DeleteCompany)
-}
 
-// GetCompany get company
-func (h *UserHandler) GetCompany(c *fiber.Ctx) error {
-	ctx := c.Context()
-
-	// Get the user ID from the context
-	userID := c.Locals("user_id").(string)
-	userUUID := uuid.MustParse(userID)
-	company, err := h.userUseCase.GetCompany(ctx, userUUID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-	return c.JSON(company)
+	router.Get("/candidates", h.SearchCandidates)
+
+	router.Get("/company/members", h.ListCompanyMembers)
+	router.Patch("/company/members/:userId", owner, h.UpdateCompanyMemberRole)
+	router.Delete("/company/members/:userId", owner, h.RemoveCompanyMember)
+	router.Get("/company/invitations", owner, h.ListCompanyInvitations)
+	router.Post("/company/invitations", owner, h.InviteCompanyMember)
+	router.Delete("/company/invitations/:id", owner, h.RevokeCompanyInvitation)
 }
@@ -0,0 +1,688 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GetJobSeekerProfile godoc
+// @Summary Get job seeker profile
+// @Description Get the full profile of the current job seeker with every section in display order
+// @Tags job-seeker
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.JobSeekerProfileResponse
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker [get]
+func (h *UserHandler) GetJobSeekerProfile(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	profile, err := h.userUseCase.GetJobSeekerProfile(c.Context(), userID)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Get job seeker profile failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(profile)
+}
+
+// UpdateJobSeekerProfile godoc
+// @Summary Update job seeker profile
+// @Description Update the name, date of birth and bio of the current job seeker
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateJobSeekerRequest true "Profile fields"
+// @Success 200 {object} entity.JobSeeker
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker [put]
+func (h *UserHandler) UpdateJobSeekerProfile(c *fiber.Ctx) error {
+	var req dto.UpdateJobSeekerRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update job seeker request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	validateJobSeekerProfile(&req.Validator, &dto.JobSeekerProfileDTO{
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		DateOfBirth: req.DateOfBirth,
+		Bio:         req.Bio,
+	})
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	seeker, err := h.userUseCase.UpdateJobSeekerProfile(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker profile failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seeker)
+}
+
+// UpdateJobSeekerVisibility godoc
+// @Summary Update candidate search visibility
+// @Description Choose which companies can find the current job seeker in the candidate search: every company (public), companies applied to (applied_only) or none (hidden)
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateVisibilityRequest true "Visibility"
+// @Success 200 {object} entity.JobSeeker
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/visibility [put]
+func (h *UserHandler) UpdateJobSeekerVisibility(c *fiber.Ctx) error {
+	var req dto.UpdateVisibilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode update visibility request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(validator.In(entity.ProfileVisibility(req.Visibility), entity.ProfileVisibilities...), "Visibility", "Visibility must be one of public, applied_only or hidden")
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	seeker, err := h.userUseCase.UpdateJobSeekerVisibility(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker visibility failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(seeker)
+}
+
+// ListJobSeekerSection returns a handler listing the items of a profile section
+func (h *UserHandler) ListJobSeekerSection(section entity.ProfileSection) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID := uuid.MustParse(c.Locals("user_id").(string))
+
+		items, err := h.userUseCase.ListJobSeekerSection(c.Context(), userID, section)
+		if err != nil {
+			return h.respondJobSeekerError(c, err, "List job seeker "+string(section)+" failed")
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": items})
+	}
+}
+
+// DeleteJobSeekerSectionItem returns a handler deleting an item of a profile section
+func (h *UserHandler) DeleteJobSeekerSectionItem(section entity.ProfileSection) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+		}
+
+		userID := uuid.MustParse(c.Locals("user_id").(string))
+
+		if err := h.userUseCase.DeleteJobSeekerSectionItem(c.Context(), userID, section, id); err != nil {
+			return h.respondJobSeekerError(c, err, "Delete job seeker "+string(section)+" item failed")
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Item deleted successfully"})
+	}
+}
+
+// ReorderJobSeekerSection returns a handler setting the display order of a profile section.
+// The request must list every item ID of the section exactly once.
+func (h *UserHandler) ReorderJobSeekerSection(section entity.ProfileSection) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req dto.ReorderRequest
+		if err := c.BodyParser(&req); err != nil {
+			h.log.WithError(err).Error("Failed to decode reorder request")
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+		}
+
+		req.Validator.CheckField(validator.NoDuplicates(req.IDs), "IDs", "Item IDs must be unique")
+		if req.Validator.HasErrors() {
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+		}
+
+		userID := uuid.MustParse(c.Locals("user_id").(string))
+
+		if err := h.userUseCase.ReorderJobSeekerSection(c.Context(), userID, section, req); err != nil {
+			if errors.Is(err, repository.ErrInvalidInput) {
+				return responseError.RespondWithError(c, fiber.StatusBadRequest, "IDs must list every item of the section exactly once")
+			}
+			return h.respondJobSeekerError(c, err, "Reorder job seeker "+string(section)+" failed")
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Order updated successfully"})
+	}
+}
+
+// CreateJobSeekerSkill godoc
+// @Summary Add a skill
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SkillRequest true "Skill"
+// @Success 201 {object} entity.JobSeekerSkill
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/skills [post]
+func (h *UserHandler) CreateJobSeekerSkill(c *fiber.Ctx) error {
+	var req dto.SkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode skill request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateSkillRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerSkill(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker skill failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerSkill godoc
+// @Summary Update a skill
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.SkillRequest true "Skill"
+// @Success 200 {object} entity.JobSeekerSkill
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/skills/{id} [put]
+func (h *UserHandler) UpdateJobSeekerSkill(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.SkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode skill request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateSkillRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerSkill(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker skill failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// CreateJobSeekerExperience godoc
+// @Summary Add a work experience
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ExperienceRequest true "Experience"
+// @Success 201 {object} entity.JobSeekerExperience
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/experiences [post]
+func (h *UserHandler) CreateJobSeekerExperience(c *fiber.Ctx) error {
+	var req dto.ExperienceRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode work experience request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateExperienceRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerExperience(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker work experience failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerExperience godoc
+// @Summary Update a work experience
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.ExperienceRequest true "Experience"
+// @Success 200 {object} entity.JobSeekerExperience
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/experiences/{id} [put]
+func (h *UserHandler) UpdateJobSeekerExperience(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.ExperienceRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode work experience request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateExperienceRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerExperience(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker work experience failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// CreateJobSeekerEducation godoc
+// @Summary Add a education entry
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.EducationRequest true "Education"
+// @Success 201 {object} entity.JobSeekerEducation
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/educations [post]
+func (h *UserHandler) CreateJobSeekerEducation(c *fiber.Ctx) error {
+	var req dto.EducationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode education entry request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateEducationRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerEducation(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker education entry failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerEducation godoc
+// @Summary Update a education entry
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.EducationRequest true "Education"
+// @Success 200 {object} entity.JobSeekerEducation
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/educations/{id} [put]
+func (h *UserHandler) UpdateJobSeekerEducation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.EducationRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode education entry request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateEducationRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerEducation(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker education entry failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// CreateJobSeekerProject godoc
+// @Summary Add a project
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ProjectRequest true "Project"
+// @Success 201 {object} entity.JobSeekerProject
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/projects [post]
+func (h *UserHandler) CreateJobSeekerProject(c *fiber.Ctx) error {
+	var req dto.ProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode project request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateProjectRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerProject(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker project failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerProject godoc
+// @Summary Update a project
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.ProjectRequest true "Project"
+// @Success 200 {object} entity.JobSeekerProject
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/projects/{id} [put]
+func (h *UserHandler) UpdateJobSeekerProject(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.ProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode project request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateProjectRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerProject(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker project failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// CreateJobSeekerLink godoc
+// @Summary Add a link
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.LinkRequest true "Link"
+// @Success 201 {object} entity.JobSeekerLink
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/links [post]
+func (h *UserHandler) CreateJobSeekerLink(c *fiber.Ctx) error {
+	var req dto.LinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode link request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateLinkRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerLink(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker link failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerLink godoc
+// @Summary Update a link
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.LinkRequest true "Link"
+// @Success 200 {object} entity.JobSeekerLink
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/links/{id} [put]
+func (h *UserHandler) UpdateJobSeekerLink(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.LinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode link request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validateLinkRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerLink(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker link failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// CreateJobSeekerPreference godoc
+// @Summary Add a job preference
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PreferenceRequest true "Preference"
+// @Success 201 {object} entity.JobSeekerPreference
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/preferences [post]
+func (h *UserHandler) CreateJobSeekerPreference(c *fiber.Ctx) error {
+	var req dto.PreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode job preference request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validatePreferenceRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.CreateJobSeekerPreference(c.Context(), userID, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Create job seeker job preference failed")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// UpdateJobSeekerPreference godoc
+// @Summary Update a job preference
+// @Tags job-seeker
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Item ID"
+// @Param request body dto.PreferenceRequest true "Preference"
+// @Success 200 {object} entity.JobSeekerPreference
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/job-seeker/preferences/{id} [put]
+func (h *UserHandler) UpdateJobSeekerPreference(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid item ID format")
+	}
+
+	var req dto.PreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode job preference request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	if validatePreferenceRequest(&req); req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	item, err := h.userUseCase.UpdateJobSeekerPreference(c.Context(), userID, id, req)
+	if err != nil {
+		return h.respondJobSeekerError(c, err, "Update job seeker job preference failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(item)
+}
+
+// respondJobSeekerError maps job seeker profile errors to HTTP responses
+func (h *UserHandler) respondJobSeekerError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrJobSeekerNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Job seeker profile not found")
+	case errors.Is(err, repository.ErrProfileItemNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "Profile item not found")
+	case errors.Is(err, repository.ErrInvalidInput):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid profile data")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func validateSkillRequest(req *dto.SkillRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.SkillName), "SkillName", "Skill name is required")
+	req.Validator.CheckField(validator.MaxRunes(req.SkillName, 100), "SkillName", "Skill name is too long")
+	req.Validator.CheckField(validator.In(req.ProficiencyLevel, entity.ProficiencyLevels...), "ProficiencyLevel", "Proficiency level must be one of beginner, intermediate, advanced or expert")
+}
+
+func validateExperienceRequest(req *dto.ExperienceRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.JobTitle), "JobTitle", "Job title is required")
+	req.Validator.CheckField(validator.MaxRunes(req.JobTitle, 255), "JobTitle", "Job title is too long")
+	req.Validator.CheckField(validator.NotBlank(req.CompanyName), "CompanyName", "Company name is required")
+	req.Validator.CheckField(validator.MaxRunes(req.CompanyName, 255), "CompanyName", "Company name is too long")
+	req.Validator.CheckField(validator.MaxRunes(req.Description, 5000), "Description", "Description is too long (max 5000 characters)")
+	validateDateRange(&req.Validator, req.StartDate, req.EndDate)
+}
+
+func validateEducationRequest(req *dto.EducationRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.InstitutionName), "InstitutionName", "Institution name is required")
+	req.Validator.CheckField(validator.MaxRunes(req.InstitutionName, 255), "InstitutionName", "Institution name is too long")
+	req.Validator.CheckField(validator.NotBlank(req.Degree), "Degree", "Degree is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Degree, 255), "Degree", "Degree is too long")
+	req.Validator.CheckField(validator.NotBlank(req.FieldOfStudy), "FieldOfStudy", "Field of study is required")
+	req.Validator.CheckField(validator.MaxRunes(req.FieldOfStudy, 255), "FieldOfStudy", "Field of study is too long")
+	req.Validator.CheckField(validator.MaxRunes(req.Grade, 50), "Grade", "Grade is too long")
+	req.Validator.CheckField(validator.MaxRunes(req.Description, 5000), "Description", "Description is too long (max 5000 characters)")
+	validateDateRange(&req.Validator, req.StartDate, req.EndDate)
+}
+
+func validateProjectRequest(req *dto.ProjectRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.Title), "Title", "Title is required")
+	req.Validator.CheckField(validator.MaxRunes(req.Title, 255), "Title", "Title is too long")
+	req.Validator.CheckField(validator.MaxRunes(req.Description, 5000), "Description", "Description is too long (max 5000 characters)")
+	if req.Link != "" {
+		req.Validator.CheckField(validator.IsURL(req.Link), "Link", "Link must be a valid URL")
+	}
+	validateDateRange(&req.Validator, req.StartDate, req.EndDate)
+}
+
+func validateLinkRequest(req *dto.LinkRequest) {
+	req.Validator.CheckField(validator.In(req.LinkType, entity.LinkTypes...), "LinkType", "Link type must be one of linkedin, github, gitlab, portfolio, website, twitter or other")
+	req.Validator.CheckField(validator.IsURL(req.URL), "URL", "URL must be a valid URL")
+	req.Validator.CheckField(validator.MaxRunes(req.URL, 255), "URL", "URL is too long")
+}
+
+func validatePreferenceRequest(req *dto.PreferenceRequest) {
+	req.Validator.CheckField(validator.NotBlank(req.PreferredJobType), "PreferredJobType", "Preferred job type is required")
+	req.Validator.CheckField(validator.MaxRunes(req.PreferredJobType, 100), "PreferredJobType", "Preferred job type is too long")
+	req.Validator.CheckField(validator.NotBlank(req.PreferredIndustry), "PreferredIndustry", "Preferred industry is required")
+	req.Validator.CheckField(validator.MaxRunes(req.PreferredIndustry, 100), "PreferredIndustry", "Preferred industry is too long")
+	req.Validator.CheckField(validator.NotBlank(req.PreferredLocation), "PreferredLocation", "Preferred location is required")
+	req.Validator.CheckField(validator.MaxRunes(req.PreferredLocation, 255), "PreferredLocation", "Preferred location is too long")
+	if req.SalaryExpectation != nil {
+		req.Validator.CheckField(*req.SalaryExpectation >= 0, "SalaryExpectation", "Salary expectation cannot be negative")
+	}
+}
+
+// validateDateRange checks a YYYY-MM-DD start date and an optional end date that must not be before it
+func validateDateRange(v *validator.Validator, start string, end *string) {
+	startDate, err := time.Parse(time.DateOnly, start)
+	v.CheckField(err == nil, "StartDate", "Start date must use the YYYY-MM-DD format")
+
+	if end == nil || *end == "" {
+		return
+	}
+
+	endDate, endErr := time.Parse(time.DateOnly, *end)
+	v.CheckField(endErr == nil, "EndDate", "End date must use the YYYY-MM-DD format")
+	if err == nil && endErr == nil {
+		v.CheckField(!endDate.Before(startDate), "EndDate", "End date must be on or after the start date")
+	}
+}
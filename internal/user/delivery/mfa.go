@@ -0,0 +1,245 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/monitoring"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// LoginMFA godoc
+// @Summary Complete login with a second factor
+// @Description Exchange the mfa_token returned by login and an authenticator or recovery code for a session
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /login/2fa [post]
+func (h *UserHandler) LoginMFA(c *fiber.Ctx) error {
+	var req dto.MFALoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode MFA login request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.MFAToken != "", "MFAToken", "MFA token is required")
+	req.Validator.CheckField(req.Code != "", "Code", "Code is required")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	resp, err := h.userUseCase.CompleteMFALogin(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidToken):
+			monitoring.LoginAttempts.WithLabelValues("failure_mfa_challenge").Inc()
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Login challenge is invalid or expired, please log in again")
+		case errors.Is(err, repository.ErrUserNotActive):
+			monitoring.LoginAttempts.WithLabelValues("failure_inactive_user").Inc()
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "User is not active, please verify your email")
+		case errors.Is(err, repository.ErrInvalidMFACode), errors.Is(err, repository.ErrMFANotEnrolled):
+			monitoring.LoginAttempts.WithLabelValues("failure_mfa_code").Inc()
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid code")
+		case errors.Is(err, repository.ErrMFALocked):
+			monitoring.LoginAttempts.WithLabelValues("failure_mfa_locked").Inc()
+			return responseError.RespondWithError(c, fiber.StatusTooManyRequests, "Too many attempts, please try again later")
+		default:
+			monitoring.LoginAttempts.WithLabelValues("failure_server_error").Inc()
+			h.log.WithError(err).Error("MFA login failed")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+	}
+
+	// Remove password from response
+	resp.User.Password = ""
+
+	monitoring.LoginAttempts.WithLabelValues("success").Inc()
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// GetMFAStatus godoc
+// @Summary Two-factor authentication status
+// @Description Show whether two-factor authentication is enabled and how many recovery codes are left
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAStatusResponse
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/2fa [get]
+func (h *UserHandler) GetMFAStatus(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	status, err := h.userUseCase.GetMFAStatus(c.Context(), userID)
+	if err != nil {
+		return h.respondMFAError(c, err, "Get MFA status failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(status)
+}
+
+// EnrollMFA godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret and otpauth URI. Enrollment is completed by verifying a code.
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAEnrollResponse
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/2fa/enroll [post]
+func (h *UserHandler) EnrollMFA(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	resp, err := h.userUseCase.EnrollMFA(c.Context(), userID)
+	if err != nil {
+		return h.respondMFAError(c, err, "Enroll MFA failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ConfirmMFA godoc
+// @Summary Verify two-factor enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app and receive recovery codes. Refresh the access token afterwards to use admin routes.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "Authenticator code"
+// @Success 200 {object} dto.MFARecoveryCodesResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/2fa/verify [post]
+func (h *UserHandler) ConfirmMFA(c *fiber.Ctx) error {
+	var req dto.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode MFA code request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Code != "", "Code", "Code is required")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	sessionID, err := uuid.Parse(c.Locals("session_id").(string))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid session")
+	}
+
+	codes, err := h.userUseCase.ConfirmMFA(c.Context(), userID, sessionID, req.Code)
+	if err != nil {
+		return h.respondMFAError(c, err, "Confirm MFA failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFA godoc
+// @Summary Disable two-factor authentication
+// @Description Turn two-factor authentication off. Requires the password and a current or recovery code.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFADisableRequest true "Password and code"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 403 {object} dto.ErrorForbidden
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/2fa/disable [post]
+func (h *UserHandler) DisableMFA(c *fiber.Ctx) error {
+	var req dto.MFADisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode disable MFA request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Password != "", "Password", "Password is required")
+	req.Validator.CheckField(req.Code != "", "Code", "Code is required")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.DisableMFA(c.Context(), userID, req); err != nil {
+		return h.respondMFAError(c, err, "Disable MFA failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes. Requires a current or recovery code.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "Authenticator or recovery code"
+// @Success 200 {object} dto.MFARecoveryCodesResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/2fa/recovery-codes [post]
+func (h *UserHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	var req dto.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode MFA code request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Code != "", "Code", "Code is required")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	codes, err := h.userUseCase.RegenerateRecoveryCodes(c.Context(), userID, req.Code)
+	if err != nil {
+		return h.respondMFAError(c, err, "Regenerate recovery codes failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// respondMFAError maps two-factor errors to HTTP responses
+func (h *UserHandler) respondMFAError(c *fiber.Ctx, err error, logMessage string) error {
+	switch {
+	case errors.Is(err, repository.ErrMFANotEnrolled):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Two-factor authentication is not enabled, start enrollment first")
+	case errors.Is(err, repository.ErrMFAAlreadyEnabled):
+		return responseError.RespondWithError(c, fiber.StatusConflict, "Two-factor authentication is already enabled")
+	case errors.Is(err, repository.ErrInvalidMFACode):
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid code")
+	case errors.Is(err, repository.ErrMFALocked):
+		return responseError.RespondWithError(c, fiber.StatusTooManyRequests, "Too many attempts, please try again later")
+	case errors.Is(err, repository.ErrInvalidCredentials):
+		return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Password is incorrect")
+	case errors.Is(err, repository.ErrMFARequiredForRole):
+		return responseError.RespondWithError(c, fiber.StatusForbidden, "Your admin role requires two-factor authentication")
+	case errors.Is(err, repository.ErrUserNotFound):
+		return responseError.RespondWithError(c, fiber.StatusNotFound, "User not found")
+	default:
+		h.log.WithError(err).Error(logMessage)
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,84 @@
+package delivery
+
+import (
+	"errors"
+	responseError "github.com/agpprastyo/career-link/internal/common/errors"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListSessions godoc
+// @Summary List device sessions
+// @Description List the devices the current user is signed in on
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.SessionResponse
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/sessions [get]
+func (h *UserHandler) ListSessions(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	currentSessionID := c.Locals("session_id").(string)
+
+	sessions, err := h.userUseCase.ListSessions(c.Context(), userID, currentSessionID)
+	if err != nil {
+		h.log.WithError(err).Error("List sessions failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": sessions})
+}
+
+// RevokeSession godoc
+// @Summary Revoke a device session
+// @Description Sign one device out. Its access and refresh tokens stop working immediately.
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 404 {object} dto.ErrorNotFound
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid session ID format")
+	}
+
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.RevokeSession(c.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return responseError.RespondWithError(c, fiber.StatusNotFound, "Session not found")
+		}
+		h.log.WithError(err).Error("Revoke session failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Session revoked"})
+}
+
+// RevokeAllSessions godoc
+// @Summary Revoke all device sessions
+// @Description Sign out of every device, including the one making the request
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /profile/sessions [delete]
+func (h *UserHandler) RevokeAllSessions(c *fiber.Ctx) error {
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+
+	if err := h.userUseCase.RevokeAllSessions(c.Context(), userID); err != nil {
+		h.log.WithError(err).Error("Revoke all sessions failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "All sessions revoked"})
+}
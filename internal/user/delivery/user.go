@@ -170,7 +170,7 @@ func (h *UserHandler) UpdateAvatar(c *fiber.Ctx) error {
 
 // Logout godoc
 // @Summary User logout
-// @Description Logout a user by deleting their session. Clients should also remove the JWT token from local storage.
+// @Description Logout the current device session by revoking its access and refresh tokens. Other devices stay signed in. Clients should also remove the tokens from local storage.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -180,22 +180,66 @@ func (h *UserHandler) UpdateAvatar(c *fiber.Ctx) error {
 // @Failure 500 {object} dto.ErrorInternalServer
 // @Router /logout [post]
 func (h *UserHandler) Logout(c *fiber.Ctx) error {
-	userID := c.Locals("user_id").(string)
-	sessionID := fmt.Sprintf("session:%s", userID)
-
-	// Delete session from Redis
-	err := h.redisClient.Del(c.Context(), sessionID)
+	userID := uuid.MustParse(c.Locals("user_id").(string))
+	sessionID, err := uuid.Parse(c.Locals("session_id").(string))
 	if err != nil {
-		h.log.Error("Failed to delete session from Redis")
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"message": "Logged out",
-		})
+		return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid session")
+	}
+	tokenID := c.Locals("token_id").(string)
+	expiresAt := c.Locals("token_expired_at").(time.Time)
 
+	if err := h.userUseCase.Logout(c.Context(), userID, sessionID, tokenID, expiresAt); err != nil {
+		h.log.WithError(err).Error("Failed to revoke tokens on logout")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Logged out"})
 }
 
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated and the old one stops working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.RefreshTokenResponse
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 401 {object} dto.ErrorUnauthorized
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /refresh [post]
+func (h *UserHandler) RefreshToken(c *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode refresh token request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.RefreshToken != "", "RefreshToken", "Refresh token is required")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	resp, err := h.userUseCase.RefreshToken(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidToken), errors.Is(err, repository.ErrRefreshTokenReused):
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Invalid refresh token")
+		case errors.Is(err, repository.ErrTokenExpired):
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "Refresh token has expired, please log in again")
+		case errors.Is(err, repository.ErrUserNotActive):
+			return responseError.RespondWithError(c, fiber.StatusUnauthorized, "User is not active")
+		default:
+			h.log.WithError(err).Error("Refresh token failed")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
 // UpdatePassword handles password updates for authenticated users
 func (h *UserHandler) UpdatePassword(c *fiber.Ctx) error {
 	// Get user data from context using the User struct stored in Redis
@@ -263,7 +307,7 @@ func (h *UserHandler) UpdatePassword(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Password updated successfully",
+		"message": "Password updated successfully, please log in again",
 	})
 }
 
@@ -344,6 +388,94 @@ func (h *UserHandler) ResendVerificationEmail(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "A new verification link has been sent to your email"})
 }
 
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Send a password reset link to the email address if it belongs to an account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /forgot-password [post]
+func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode forgot password request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Email != "", "Email", "Email is required")
+	req.Validator.CheckField(validator.Matches(req.Email, validator.RgxEmail), "Email", "Must be a valid email address")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	// Unknown emails get the same answer so the endpoint cannot be used to discover accounts
+	message := "If your email exists, a password reset link has been sent"
+
+	ctx := c.Context()
+	err := h.userUseCase.ForgotPassword(ctx, req)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		h.log.WithError(err).Error("Forgot password failed")
+		return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": message})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using the token from a password reset email. All existing sessions are signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorBadRequest
+// @Failure 500 {object} dto.ErrorInternalServer
+// @Router /reset-password [post]
+func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		h.log.WithError(err).Error("Failed to decode reset password request")
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	req.Validator.CheckField(req.Email != "", "Email", "Email is required")
+	req.Validator.CheckField(req.Token != "", "Token", "Token is required")
+	req.Validator.CheckField(req.NewPassword != "", "NewPassword", "New password is required")
+	req.Validator.CheckField(len(req.NewPassword) >= 8, "NewPassword", "Password is too short")
+	req.Validator.CheckField(len(req.NewPassword) <= 72, "NewPassword", "Password is too long")
+	req.Validator.CheckField(validator.NotIn(req.NewPassword, utils.CommonPasswords...), "NewPassword", "Password is too common")
+
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
+	}
+
+	ctx := c.Context()
+	err := h.userUseCase.ResetPassword(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidToken):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid token")
+		case errors.Is(err, repository.ErrTokenAlreadyUsed):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Token has already been used")
+		case errors.Is(err, repository.ErrTokenExpired):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Token has expired, please request a new one")
+		default:
+			h.log.WithError(err).Error("Reset password failed")
+			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Password has been reset, please log in with your new password",
+	})
+}
+
 // Register godoc
 // @Summary User registration
 // @Description Register a new user with email/username and password for job seeker and company
@@ -364,7 +496,7 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 	}
 
 	// validate request
-	req.Validator.CheckField(req.Role != string(entity.CompanyRole) && req.Role != string(entity.JobSeekerRole), "Role", "Role is required")
+	req.Validator.CheckField(req.Role == string(entity.CompanyRole) || req.Role == string(entity.JobSeekerRole), "Role", "Role must be either company or job_seeker")
 	req.Validator.CheckField(req.Email != "", "email ", "either email or username is required")
 	req.Validator.CheckField(validator.Matches(req.Email, validator.RgxEmail), "Email", "Must be a valid email address")
 
@@ -381,26 +513,15 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
 	}
 
-	if req.Role == string(entity.CompanyRole) {
-		req.Validator.CheckField(req.CompanyProfile.Name != "", "CompanyName", "Company name is required")
-		req.Validator.CheckField(len(req.CompanyProfile.Name) >= 3, "CompanyName", "Company name is too short")
-		req.Validator.CheckField(len(req.CompanyProfile.Name) <= 50, "CompanyName", "Company name is too long")
-
-		if req.Validator.HasErrors() {
-			return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
-		}
-	} else if req.Role == string(entity.JobSeekerRole) {
-		req.Validator.CheckField(req.JobSeekerProfile.FirstName != "", "FirstName", "First name is required")
-		req.Validator.CheckField(len(req.JobSeekerProfile.FirstName) >= 3, "FirstName", "First name is too short")
-		req.Validator.CheckField(len(req.JobSeekerProfile.FirstName) <= 50, "FirstName", "First name is too long")
-
-		req.Validator.CheckField(req.JobSeekerProfile.LastName != "", "LastName", "Last name is required")
-		req.Validator.CheckField(len(req.JobSeekerProfile.LastName) >= 3, "LastName", "Last name is too short")
-		req.Validator.CheckField(len(req.JobSeekerProfile.LastName) <= 50, "LastName", "Last name is too long")
+	switch entity.Role(req.Role) {
+	case entity.CompanyRole:
+		validateCompanyProfile(&req.Validator, req.CompanyProfile)
+	case entity.JobSeekerRole:
+		validateJobSeekerProfile(&req.Validator, req.JobSeekerProfile)
+	}
 
-		if req.Validator.HasErrors() {
-			return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
-		}
+	if req.Validator.HasErrors() {
+		return responseError.RespondWithError(c, fiber.StatusBadRequest, req.Validator.FirstErrorMessage())
 	}
 
 	ctx := c.Context()
@@ -409,6 +530,8 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		switch {
 		case errors.Is(err, repository.ErrUserAlreadyExists):
 			return responseError.RespondWithError(c, fiber.StatusConflict, "User already exists")
+		case errors.Is(err, repository.ErrInvalidInput):
+			return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid profile data")
 		default:
 			h.log.WithError(err).Error("Register failed")
 			return responseError.RespondWithError(c, fiber.StatusInternalServerError, "Internal server error")
@@ -421,6 +544,85 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
+// validateJobSeekerProfile validates the job seeker profile submitted during registration
+func validateJobSeekerProfile(v *validator.Validator, profile *dto.JobSeekerProfileDTO) {
+	if profile == nil {
+		v.AddFieldError("JobSeekerProfile", "Job seeker profile is required")
+		return
+	}
+
+	v.CheckField(validator.NotBlank(profile.FirstName), "FirstName", "First name is required")
+	v.CheckField(validator.MinRunes(profile.FirstName, 2), "FirstName", "First name is too short")
+	v.CheckField(validator.MaxRunes(profile.FirstName, 50), "FirstName", "First name is too long")
+
+	v.CheckField(validator.NotBlank(profile.LastName), "LastName", "Last name is required")
+	v.CheckField(validator.MinRunes(profile.LastName, 2), "LastName", "Last name is too short")
+	v.CheckField(validator.MaxRunes(profile.LastName, 50), "LastName", "Last name is too long")
+
+	if profile.DateOfBirth != nil && *profile.DateOfBirth != "" {
+		dob, err := time.Parse(time.DateOnly, *profile.DateOfBirth)
+		v.CheckField(err == nil, "DateOfBirth", "Date of birth must use the YYYY-MM-DD format")
+		if err == nil {
+			v.CheckField(dob.Before(time.Now().AddDate(-15, 0, 0)), "DateOfBirth", "You must be at least 15 years old")
+		}
+	}
+
+	if profile.Bio != nil {
+		v.CheckField(validator.MaxRunes(*profile.Bio, 2000), "Bio", "Bio is too long (max 2000 characters)")
+	}
+}
+
+// validateCompanyProfile validates the company profile submitted during registration
+func validateCompanyProfile(v *validator.Validator, profile *dto.CompanyProfileDTO) {
+	if profile == nil {
+		v.AddFieldError("CompanyProfile", "Company profile is required")
+		return
+	}
+
+	v.CheckField(validator.NotBlank(profile.Name), "CompanyName", "Company name is required")
+	v.CheckField(validator.MinRunes(profile.Name, 3), "CompanyName", "Company name is too short")
+	v.CheckField(validator.MaxRunes(profile.Name, 50), "CompanyName", "Company name is too long")
+
+	if profile.Email != "" {
+		v.CheckField(validator.IsEmail(profile.Email), "CompanyEmail", "Company email must be a valid email address")
+	}
+	if profile.Website != "" {
+		v.CheckField(validator.IsURL(profile.Website), "Website", "Website must be a valid URL")
+		v.CheckField(validator.MaxRunes(profile.Website, 255), "Website", "Website is too long")
+	}
+	if profile.Phone != "" {
+		_, phoneErrors := validator.ValidatePhone(profile.Phone)
+		for _, msg := range phoneErrors {
+			v.AddFieldError("Phone", "Phone "+msg)
+		}
+	}
+	v.CheckField(validator.MaxRunes(profile.Industry, 100), "Industry", "Industry is too long")
+	v.CheckField(validator.MaxRunes(profile.Description, 5000), "Description", "Description is too long")
+	v.CheckField(validator.In(entity.CompanySizeRange(profile.Size), entity.CompanySizeRanges...), "Size", "Size must be one of 1-5, 5-10, 10-25, 25-50, 50-100, 100-500, 500-1000 or 1000+")
+
+	validateCompanyAddress(v, profile.Address)
+}
+
+// validateCompanyAddress validates a company postal address
+func validateCompanyAddress(v *validator.Validator, address *dto.CompanyAddressDTO) {
+	if address == nil {
+		v.AddFieldError("Address", "Company address is required")
+		return
+	}
+
+	v.CheckField(validator.NotBlank(address.AddressLine1), "AddressLine1", "Address line 1 is required")
+	v.CheckField(validator.MaxRunes(address.AddressLine1, 255), "AddressLine1", "Address line 1 is too long")
+	v.CheckField(validator.MaxRunes(address.AddressLine2, 255), "AddressLine2", "Address line 2 is too long")
+	v.CheckField(validator.NotBlank(address.City), "City", "City is required")
+	v.CheckField(validator.MaxRunes(address.City, 100), "City", "City is too long")
+	v.CheckField(validator.NotBlank(address.State), "State", "State is required")
+	v.CheckField(validator.MaxRunes(address.State, 100), "State", "State is too long")
+	v.CheckField(validator.NotBlank(address.ZipCode), "ZipCode", "Zip code is required")
+	v.CheckField(validator.MaxRunes(address.ZipCode, 20), "ZipCode", "Zip code is too long")
+	v.CheckField(validator.NotBlank(address.Country), "Country", "Country is required")
+	v.CheckField(validator.MaxRunes(address.Country, 100), "Country", "Country is too long")
+}
+
 // Login godoc
 // @Summary User login
 // @Description Authenticate a user with email/username and password
@@ -441,6 +643,9 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		return responseError.RespondWithError(c, fiber.StatusBadRequest, "Invalid request payload")
 	}
 
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	req.IPAddress = c.IP()
+
 	ctx := c.Context()
 	resp, err := h.userUseCase.Login(ctx, req)
 	if err != nil {
@@ -458,6 +663,11 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		}
 	}
 
+	if resp.MFARequired {
+		monitoring.LoginAttempts.WithLabelValues("mfa_required").Inc()
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+
 	// Remove password from response
 	resp.User.Password = ""
 
@@ -12,15 +12,39 @@ type LoginRequest struct {
 	Email     string              `json:"email"`
 	Username  string              `json:"username"`
 	Password  string              `json:"password"`
+	UserAgent string              `json:"-"` // Filled from the request headers to describe the device session
+	IPAddress string              `json:"-"`
 	Validator validator.Validator `json:"-"`
 }
 
-// LoginResponse represents successful login response
+// LoginResponse represents successful login response. When the account has two-factor
+// authentication enabled only the MFA fields are set and the mfa_token must be exchanged
+// at /users/login/2fa.
 // @Description Login response with user data and token
 type LoginResponse struct {
-	User   entity.User `json:"user"`
-	Token  string      `json:"token"`
-	Expiry time.Time   `json:"expiry"`
+	User           *entity.User `json:"user,omitempty"`
+	Token          string       `json:"token,omitempty"`
+	Expiry         *time.Time   `json:"expiry,omitempty"`
+	RefreshToken   string       `json:"refresh_token,omitempty"`
+	RefreshExpiry  *time.Time   `json:"refresh_expiry,omitempty"`
+	MFARequired    bool         `json:"mfa_required"`
+	MFAToken       string       `json:"mfa_token,omitempty"`
+	MFATokenExpiry *time.Time   `json:"mfa_token_expiry,omitempty"`
+}
+
+// RefreshTokenRequest represents a request to exchange a refresh token for new tokens
+type RefreshTokenRequest struct {
+	RefreshToken string              `json:"refresh_token"`
+	Validator    validator.Validator `json:"-"`
+}
+
+// RefreshTokenResponse carries a new access token and the refresh token that replaces the one sent
+// @Description Rotated token pair
+type RefreshTokenResponse struct {
+	Token         string    `json:"token"`
+	Expiry        time.Time `json:"expiry"`
+	RefreshToken  string    `json:"refresh_token"`
+	RefreshExpiry time.Time `json:"refresh_expiry"`
 }
 
 // RegisterRequest represents registration request data
@@ -36,26 +60,40 @@ type RegisterRequest struct {
 
 // RegisterResponse represents registration response data
 type RegisterResponse struct {
-	User    entity.User `json:"user"`
-	Message string      `json:"message"`
+	User      entity.User       `json:"user"`
+	JobSeeker *entity.JobSeeker `json:"job_seeker,omitempty"`
+	Company   *entity.Company   `json:"company,omitempty"`
+	Message   string            `json:"message"`
 }
 
 // JobSeekerProfileDTO contains profile data for job seekers during registration
 type JobSeekerProfileDTO struct {
 	FirstName   string  `json:"first_name"`
 	LastName    string  `json:"last_name"`
-	DateOfBirth *string `json:"date_of_birth,omitempty"`
+	DateOfBirth *string `json:"date_of_birth,omitempty"` // Format: YYYY-MM-DD
 	Bio         *string `json:"bio,omitempty"`
 }
 
 // CompanyProfileDTO contains profile data for companies during registration
 type CompanyProfileDTO struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Industry    string `json:"industry,omitempty"`
-	Website     string `json:"website,omitempty"`
-	Email       string `json:"email"`
-	Phone       string `json:"phone,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Industry    string             `json:"industry,omitempty"`
+	Website     string             `json:"website,omitempty"`
+	Email       string             `json:"email"` // Defaults to the account email when empty
+	Phone       string             `json:"phone,omitempty"`
+	Size        string             `json:"size"`
+	Address     *CompanyAddressDTO `json:"address"`
+}
+
+// CompanyAddressDTO contains a company's postal address
+type CompanyAddressDTO struct {
+	AddressLine1 string `json:"address_line_1"`
+	AddressLine2 string `json:"address_line_2,omitempty"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	ZipCode      string `json:"zip_code"`
+	Country      string `json:"country"`
 }
 
 type VerifyEmailRequest struct {
@@ -75,14 +113,14 @@ type LogoutResponse struct {
 
 // ForgotPasswordRequest represents a password reset request
 type ForgotPasswordRequest struct {
-	Email     string `json:"email"`
-	Validator validator.Validator
+	Email     string              `json:"email"`
+	Validator validator.Validator `json:"-"`
 }
 
 // ResetPasswordRequest represents a new password submission
 type ResetPasswordRequest struct {
-	Email       string `json:"email"`
-	Token       string `json:"token"`
-	NewPassword string `json:"new_password"`
-	Validator   validator.Validator
+	Email       string              `json:"email"`
+	Token       string              `json:"token"`
+	NewPassword string              `json:"new_password"`
+	Validator   validator.Validator `json:"-"`
 }
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/agpprastyo/career-link/pkg/validator"
+
+// InviteCompanyMemberRequest invites someone by email to join the company
+type InviteCompanyMemberRequest struct {
+	Email     string              `json:"email"`
+	Role      string              `json:"role"`
+	Validator validator.Validator `json:"-"`
+}
+
+// UpdateCompanyMemberRequest changes the role of a company member
+type UpdateCompanyMemberRequest struct {
+	Role      string              `json:"role"`
+	Validator validator.Validator `json:"-"`
+}
+
+// AcceptCompanyInvitationRequest accepts a company invitation. Username is only needed when the
+// email has no account yet, in which case one is created with the given password. Existing
+// accounts confirm the invitation with their current password.
+type AcceptCompanyInvitationRequest struct {
+	Email     string              `json:"email"`
+	Token     string              `json:"token"`
+	Username  string              `json:"username,omitempty"`
+	Password  string              `json:"password"`
+	Validator validator.Validator `json:"-"`
+}
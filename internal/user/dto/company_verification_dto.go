@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+)
+
+// VerificationDocumentUpload is a supporting document attached to a company verification request
+type VerificationDocumentUpload struct {
+	FileName    string
+	FileExt     string
+	FileContent []byte
+	ContentType string
+}
+
+// RejectVerificationRequest carries the reason shown to the company when its request is rejected
+type RejectVerificationRequest struct {
+	Reason    string              `json:"reason"`
+	Validator validator.Validator `json:"-"`
+}
+
+// CompanyVerificationDetailResponse is a verification request under review with the company it belongs to
+// @Description Verification request with company details and document download links
+type CompanyVerificationDetailResponse struct {
+	Request entity.CompanyVerificationRequest `json:"request"`
+	Company *entity.Company                   `json:"company"`
+}
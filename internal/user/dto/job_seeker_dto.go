@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/validator"
+)
+
+// JobSeekerProfileResponse is the full job seeker profile with every section in display order
+// @Description Aggregated job seeker profile
+type JobSeekerProfileResponse struct {
+	Profile     entity.JobSeeker             `json:"profile"`
+	Skills      []entity.JobSeekerSkill      `json:"skills"`
+	Experiences []entity.JobSeekerExperience `json:"experiences"`
+	Educations  []entity.JobSeekerEducation  `json:"educations"`
+	Projects    []entity.JobSeekerProject    `json:"projects"`
+	Links       []entity.JobSeekerLink       `json:"links"`
+	Preferences []entity.JobSeekerPreference `json:"preferences"`
+}
+
+// UpdateJobSeekerRequest updates the basic fields of a job seeker profile
+type UpdateJobSeekerRequest struct {
+	FirstName   string              `json:"first_name"`
+	LastName    string              `json:"last_name"`
+	DateOfBirth *string             `json:"date_of_birth,omitempty"` // Format: YYYY-MM-DD
+	Bio         *string             `json:"bio,omitempty"`
+	Validator   validator.Validator `json:"-"`
+}
+
+// UpdateVisibilityRequest changes who can find the job seeker in the company candidate search
+type UpdateVisibilityRequest struct {
+	Visibility string              `json:"visibility"` // public, applied_only or hidden
+	Validator  validator.Validator `json:"-"`
+}
+
+// SkillRequest creates or updates a skill
+type SkillRequest struct {
+	SkillName        string              `json:"skill_name"`
+	ProficiencyLevel string              `json:"proficiency_level"`
+	Validator        validator.Validator `json:"-"`
+}
+
+// ExperienceRequest creates or updates a work experience. Dates use the YYYY-MM-DD format and
+// an empty end_date means the position is current.
+type ExperienceRequest struct {
+	JobTitle    string              `json:"job_title"`
+	CompanyName string              `json:"company_name"`
+	StartDate   string              `json:"start_date"`
+	EndDate     *string             `json:"end_date,omitempty"`
+	Description string              `json:"description"`
+	Validator   validator.Validator `json:"-"`
+}
+
+// EducationRequest creates or updates an education entry
+type EducationRequest struct {
+	InstitutionName string              `json:"institution_name"`
+	Degree          string              `json:"degree"`
+	FieldOfStudy    string              `json:"field_of_study"`
+	StartDate       string              `json:"start_date"`
+	EndDate         *string             `json:"end_date,omitempty"`
+	Grade           string              `json:"grade"`
+	Description     string              `json:"description"`
+	Validator       validator.Validator `json:"-"`
+}
+
+// ProjectRequest creates or updates a project
+type ProjectRequest struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	StartDate   string              `json:"start_date"`
+	EndDate     *string             `json:"end_date,omitempty"`
+	Link        string              `json:"link"`
+	Validator   validator.Validator `json:"-"`
+}
+
+// LinkRequest creates or updates a profile link
+type LinkRequest struct {
+	LinkType  string              `json:"link_type"`
+	URL       string              `json:"url"`
+	Validator validator.Validator `json:"-"`
+}
+
+// PreferenceRequest creates or updates a job preference
+type PreferenceRequest struct {
+	PreferredJobType  string              `json:"preferred_job_type"`
+	PreferredIndustry string              `json:"preferred_industry"`
+	PreferredLocation string              `json:"preferred_location"`
+	SalaryExpectation *int                `json:"salary_expectation,omitempty"`
+	RemotePreference  bool                `json:"remote_preference"`
+	Validator         validator.Validator `json:"-"`
+}
+
+// ReorderRequest lists every item ID of a profile section in the new display order
+type ReorderRequest struct {
+	IDs       []string            `json:"ids"`
+	Validator validator.Validator `json:"-"`
+}
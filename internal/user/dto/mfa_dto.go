@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/agpprastyo/career-link/pkg/validator"
+	"time"
+)
+
+// MFAEnrollResponse carries the shared secret of a pending TOTP enrollment
+// @Description Secret and otpauth URI to add to an authenticator app
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFACodeRequest carries a code from the authenticator app or a recovery code
+type MFACodeRequest struct {
+	Code      string              `json:"code"`
+	Validator validator.Validator `json:"-"`
+}
+
+// MFARecoveryCodesResponse lists freshly generated recovery codes. They are only shown once.
+// @Description One-time recovery codes
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFADisableRequest represents a request to turn two-factor authentication off
+type MFADisableRequest struct {
+	Password  string              `json:"password"`
+	Code      string              `json:"code"`
+	Validator validator.Validator `json:"-"`
+}
+
+// MFAStatusResponse describes the current user's two-factor authentication settings
+// @Description Two-factor authentication status
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// MFALoginRequest exchanges the challenge token returned by login and a second factor for a session
+type MFALoginRequest struct {
+	MFAToken  string              `json:"mfa_token"`
+	Code      string              `json:"code"`
+	Validator validator.Validator `json:"-"`
+}
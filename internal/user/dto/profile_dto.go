@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/agpprastyo/career-link/pkg/validator"
+
 // UserDTO contains basic user information
 type UserDTO struct {
 	ID       string `json:"id"`
@@ -23,3 +25,16 @@ type UpdateAvatarRequest struct {
 	FileSize    int64  `json:"file_size"`
 	ContentType string `json:"content_type"`
 }
+
+// UpdateCompanyRequest updates the company profile, size and address. An empty email keeps the current one.
+type UpdateCompanyRequest struct {
+	CompanyProfileDTO
+	Validator validator.Validator `json:"-"`
+}
+
+type UpdateCompanyLogoRequest struct {
+	FileExt     string `json:"file_ext"`
+	FileContent []byte `json:"file_content"`
+	FileSize    int64  `json:"file_size"`
+	ContentType string `json:"content_type"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/agpprastyo/career-link/internal/user/entity"
+
+// SessionResponse describes a signed in device
+// @Description Device session of the current user
+type SessionResponse struct {
+	entity.Session
+	Current bool `json:"current"` // True for the session making the request
+}
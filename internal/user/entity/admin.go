@@ -23,3 +23,8 @@ const (
 	AdminRoleAdmin  AdministratorRole = "admin"
 	AdminRoleViewer AdministratorRole = "viewer"
 )
+
+// RequiresMFA reports whether admins with the role must use a second factor
+func (r AdministratorRole) RequiresMFA() bool {
+	return r == AdminRoleSuper || r == AdminRoleAdmin
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// CandidateSearchFilter holds the criteria of a company's candidate search. Nil and empty fields do not filter.
+type CandidateSearchFilter struct {
+	Skills             []string // Candidates must have every listed skill
+	MinProficiency     *string  // Minimum level of the listed skills, one of ProficiencyLevels
+	FieldsOfStudy      []string // Candidates must have studied at least one of them
+	MinYearsExperience *float64
+	MaxYearsExperience *float64
+	Location           *string
+	Remote             *bool
+}
+
+// Candidate is a job seeker as shown in the company candidate search
+type Candidate struct {
+	JobSeekerID        uuid.UUID      `json:"job_seeker_id" db:"job_seeker_id"`
+	FirstName          string         `json:"first_name" db:"first_name"`
+	LastName           string         `json:"last_name" db:"last_name"`
+	Bio                *string        `json:"bio,omitempty" db:"bio"`
+	YearsOfExperience  float64        `json:"years_of_experience" db:"years_of_experience"` // Sum of the experience durations, to one decimal
+	Skills             pq.StringArray `json:"skills" db:"skills"`
+	FieldsOfStudy      pq.StringArray `json:"fields_of_study" db:"fields_of_study"`
+	PreferredLocations pq.StringArray `json:"preferred_locations" db:"preferred_locations"`
+	OpenToRemote       bool           `json:"open_to_remote" db:"open_to_remote"`
+	AppliedToCompany   bool           `json:"applied_to_company" db:"applied_to_company"`
+	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
+}
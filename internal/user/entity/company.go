@@ -27,6 +27,11 @@ const (
 	Size1000Plus  CompanySizeRange = "1000+"
 )
 
+// CompanySizeRanges lists every supported company size range
+var CompanySizeRanges = []CompanySizeRange{
+	Size1To5, Size5To10, Size10To25, Size25To50, Size50To100, Size100To500, Size500To1000, Size1000Plus,
+}
+
 // Company represents a company entity
 type Company struct {
 	ID          uuid.UUID        `json:"id" db:"id"`
@@ -37,7 +42,7 @@ type Company struct {
 	Website     *string          `json:"website,omitempty" db:"website"`
 	Email       string           `json:"email" db:"email"`
 	Phone       *string          `json:"phone,omitempty" db:"phone"`
-	LogoURL     *string          `json:"logo_url,omitempty" db:"logo_url"`
+	LogoURL     *string          `json:"logo_url,omitempty" db:"logo_url"` // Storage object name, resolved to a temporary URL in responses
 	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
 	Status      CompanyStatus    `json:"status" db:"status"`
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CompanyMemberRole is the role of a user within a company
+type CompanyMemberRole string
+
+const (
+	CompanyOwner     CompanyMemberRole = "owner"     // Manages the company profile, verification and team
+	CompanyRecruiter CompanyMemberRole = "recruiter" // Manages job postings and applications
+	CompanyViewer    CompanyMemberRole = "viewer"    // Read-only access
+)
+
+// CompanyMemberRoles lists every company member role
+var CompanyMemberRoles = []CompanyMemberRole{CompanyOwner, CompanyRecruiter, CompanyViewer}
+
+// In reports whether the role is one of roles
+func (r CompanyMemberRole) In(roles ...CompanyMemberRole) bool {
+	for _, role := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanyMember represents the company_members table
+type CompanyMember struct {
+	CompanyID uuid.UUID         `json:"company_id" db:"company_id"`
+	UserID    uuid.UUID         `json:"user_id" db:"user_id"`
+	Role      CompanyMemberRole `json:"role" db:"role"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// CompanyMemberDetail is a company member with the account details shown to the team
+type CompanyMemberDetail struct {
+	CompanyMember
+	Username string  `json:"username" db:"username"`
+	Email    string  `json:"email" db:"email"`
+	Avatar   *string `json:"avatar" db:"avatar"`
+}
+
+// CompanyInvitation represents the company_invitations table
+type CompanyInvitation struct {
+	ID         uuid.UUID         `json:"id" db:"id"`
+	CompanyID  uuid.UUID         `json:"company_id" db:"company_id"`
+	Email      string            `json:"email" db:"email"`
+	Role       CompanyMemberRole `json:"role" db:"role"`
+	InvitedBy  *uuid.UUID        `json:"invited_by,omitempty" db:"invited_by"`
+	TokenID    uuid.UUID         `json:"-" db:"token_id"`
+	ExpiresAt  time.Time         `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time        `json:"accepted_at,omitempty" db:"accepted_at"`
+	RevokedAt  *time.Time        `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// VerificationStatus is the review state of a company verification request
+type VerificationStatus string
+
+const (
+	VerificationPending  VerificationStatus = "pending"
+	VerificationApproved VerificationStatus = "approved"
+	VerificationRejected VerificationStatus = "rejected"
+)
+
+// VerificationStatuses lists every verification request status
+var VerificationStatuses = []VerificationStatus{VerificationPending, VerificationApproved, VerificationRejected}
+
+// CompanyVerificationRequest represents the company_verification_requests table
+type CompanyVerificationRequest struct {
+	ID              uuid.UUID                     `json:"id" db:"id"`
+	CompanyID       uuid.UUID                     `json:"company_id" db:"company_id"`
+	Status          VerificationStatus            `json:"status" db:"status"`
+	RejectionReason *string                       `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	ReviewedBy      *uuid.UUID                    `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time                    `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt       time.Time                     `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time                     `json:"updated_at" db:"updated_at"`
+	Documents       []CompanyVerificationDocument `json:"documents,omitempty" db:"-"`
+}
+
+// CompanyVerificationDocument represents the company_verification_documents table
+type CompanyVerificationDocument struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	RequestID   uuid.UUID `json:"request_id" db:"request_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ObjectName  string    `json:"-" db:"object_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	URL         string    `json:"url,omitempty" db:"-"` // Temporary download URL, only set for reviewers
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// CompanyVerificationSummary is a verification request in the admin review queue
+type CompanyVerificationSummary struct {
+	CompanyVerificationRequest
+	CompanyName  string `json:"company_name" db:"company_name"`
+	CompanyEmail string `json:"company_email" db:"company_email"`
+}
@@ -5,17 +5,30 @@ import (
 	"time"
 )
 
+// ProfileVisibility controls which companies can find a job seeker in the candidate search
+type ProfileVisibility string
+
+const (
+	VisibilityPublic      ProfileVisibility = "public"       // Every company
+	VisibilityAppliedOnly ProfileVisibility = "applied_only" // Companies the job seeker applied to
+	VisibilityHidden      ProfileVisibility = "hidden"       // Nobody
+)
+
+// ProfileVisibilities lists every profile visibility
+var ProfileVisibilities = []ProfileVisibility{VisibilityPublic, VisibilityAppliedOnly, VisibilityHidden}
+
 // JobSeeker represents the job-seekers table
 type JobSeeker struct {
-	ID                uuid.UUID `db:"id" json:"id"`
-	UserID            uuid.UUID `db:"user_id" json:"user_id"`
-	FirstName         string    `db:"first_name" json:"first_name"`
-	LastName          string    `db:"last_name" json:"last_name"`
-	DateOfBirth       time.Time `db:"date_of_birth" json:"date_of_birth"`
-	Bio               string    `db:"bio" json:"bio"`
-	ProfilePictureURL *string   `db:"profile_picture_url" json:"profile_picture_url"`
-	CreatedAt         time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+	ID                uuid.UUID         `db:"id" json:"id"`
+	UserID            uuid.UUID         `db:"user_id" json:"user_id"`
+	FirstName         string            `db:"first_name" json:"first_name"`
+	LastName          string            `db:"last_name" json:"last_name"`
+	DateOfBirth       *time.Time        `db:"date_of_birth" json:"date_of_birth"` // Nullable since migration 000006
+	Bio               *string           `db:"bio" json:"bio"`
+	ProfilePictureURL *string           `db:"profile_picture_url" json:"profile_picture_url"`
+	Visibility        ProfileVisibility `db:"visibility" json:"visibility"`
+	CreatedAt         time.Time         `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time         `db:"updated_at" json:"updated_at"`
 }
 
 // JobSeekerSkill represents the job_seeker_skills table
@@ -24,6 +37,7 @@ type JobSeekerSkill struct {
 	JobSeekerID      uuid.UUID `db:"job_seeker_id" json:"job_seeker_id"`
 	SkillName        string    `db:"skill_name" json:"skill_name"`
 	ProficiencyLevel string    `db:"proficiency_level" json:"proficiency_level"`
+	Position         int       `db:"position" json:"position"`
 	CreatedAt        time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
 }
@@ -37,6 +51,7 @@ type JobSeekerProject struct {
 	StartDate   time.Time  `db:"start_date" json:"start_date"`
 	EndDate     *time.Time `db:"end_date" json:"end_date"` // Use a pointer to allow null
 	Link        string     `db:"link" json:"link"`
+	Position    int        `db:"position" json:"position"`
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
 }
@@ -50,6 +65,7 @@ type JobSeekerPreference struct {
 	PreferredLocation string    `db:"preferred_location" json:"preferred_location"`
 	SalaryExpectation *int      `db:"salary_expectation" json:"salary_expectation"` // Use a pointer to allow null
 	RemotePreference  bool      `db:"remote_preference" json:"remote_preference"`
+	Position          int       `db:"position" json:"position"`
 	CreatedAt         time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
 }
@@ -60,6 +76,7 @@ type JobSeekerLink struct {
 	JobSeekerID uuid.UUID `db:"job_seeker_id" json:"job_seeker_id"`
 	LinkType    string    `db:"link_type" json:"link_type"`
 	URL         string    `db:"url" json:"url"`
+	Position    int       `db:"position" json:"position"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
 }
@@ -73,6 +90,7 @@ type JobSeekerExperience struct {
 	StartDate   time.Time  `db:"start_date" json:"start_date"`
 	EndDate     *time.Time `db:"end_date" json:"end_date"` // Use a pointer to allow null
 	Description string     `db:"description" json:"description"`
+	Position    int        `db:"position" json:"position"`
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
 }
@@ -88,6 +106,25 @@ type JobSeekerEducation struct {
 	EndDate         *time.Time `db:"end_date" json:"end_date"` // Use a pointer to allow null
 	Grade           string     `db:"grade" json:"grade"`
 	Description     string     `db:"description" json:"description"`
+	Position        int        `db:"position" json:"position"`
 	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
 }
+
+// ProfileSection names a repeatable section of the job seeker profile
+type ProfileSection string
+
+const (
+	SectionSkills      ProfileSection = "skills"
+	SectionExperiences ProfileSection = "experiences"
+	SectionEducations  ProfileSection = "educations"
+	SectionProjects    ProfileSection = "projects"
+	SectionLinks       ProfileSection = "links"
+	SectionPreferences ProfileSection = "preferences"
+)
+
+// ProficiencyLevels are the accepted values of JobSeekerSkill.ProficiencyLevel
+var ProficiencyLevels = []string{"beginner", "intermediate", "advanced", "expert"}
+
+// LinkTypes are the accepted values of JobSeekerLink.LinkType
+var LinkTypes = []string{"linkedin", "github", "gitlab", "portfolio", "website", "twitter", "other"}
@@ -0,0 +1,48 @@
+package entity
+
+// Permission is an action an administrator may perform, named resource:action
+type Permission string
+
+const (
+	PermissionUsersRead       Permission = "users:read"
+	PermissionUsersWrite      Permission = "users:write"
+	PermissionAdminsManage    Permission = "admins:manage"
+	PermissionCompaniesVerify Permission = "companies:verify"
+	PermissionEmailsManage    Permission = "emails:manage"
+)
+
+// rolePermissions is the permission registry. Super admins can do everything,
+// admins run day to day moderation and viewers are read-only.
+var rolePermissions = map[AdministratorRole][]Permission{
+	AdminRoleSuper: {
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionAdminsManage,
+		PermissionCompaniesVerify,
+		PermissionEmailsManage,
+	},
+	AdminRoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionCompaniesVerify,
+		PermissionEmailsManage,
+	},
+	AdminRoleViewer: {
+		PermissionUsersRead,
+	},
+}
+
+// Permissions returns the permissions granted to the role
+func (r AdministratorRole) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// HasPermission reports whether the role grants the permission
+func (r AdministratorRole) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Session is a signed in device. Its ID is the family ID of the refresh tokens issued to
+// the device and is carried in the access token as the sid claim.
+type Session struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	UserAgent   *string    `json:"user_agent" db:"user_agent"`
+	IPAddress   *string    `json:"ip_address" db:"ip_address"`
+	MFAVerified bool       `json:"mfa_verified" db:"mfa_verified"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
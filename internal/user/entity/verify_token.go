@@ -18,6 +18,7 @@ type VerificationToken struct {
 type TokenType string
 
 const (
-	EmailVerification TokenType = "email_verification"
-	PasswordReset     TokenType = "password_reset"
+	EmailVerification      TokenType = "email_verification"
+	PasswordReset          TokenType = "password_reset"
+	CompanyInvitationToken TokenType = "company_invitation"
 )
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	outboxEntity "github.com/agpprastyo/career-link/internal/outbox/entity"
+	outboxRepository "github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"time"
+)
+
+// The emails a user needs to get into their account are written to the email outbox in the same
+// transaction as the token or activation they belong to, so a crash or a mail provider outage delays
+// them instead of losing them.
+
+// insertTokenTx inserts a verification token inside an existing transaction
+func insertTokenTx(ctx context.Context, tx *sql.Tx, token *entity.VerificationToken) error {
+	const query = `
+		INSERT INTO verification_tokens (id, user_id, token, type, expired_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+	_, err := tx.ExecContext(ctx, query, token.ID, token.UserID, token.Token, token.Type, token.ExpiredAt)
+	return err
+}
+
+// insertVerificationTx inserts the email verification token of a new user and queues its email
+// inside an existing transaction
+func (r *UserRepository) insertVerificationTx(ctx context.Context, tx *sql.Tx, usr *entity.User, token *entity.VerificationToken) error {
+	message, err := r.verificationEmail(usr.Username, usr.Email, token.Token)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to render verification email")
+		return err
+	}
+
+	if err := insertTokenTx(ctx, tx, token); err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to create verification token")
+		return err
+	}
+
+	if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindVerification, message); err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to queue verification email")
+		return err
+	}
+
+	return nil
+}
+
+// CreateVerificationToken stores a new email verification token for a user and queues the email
+// carrying it
+func (r *UserRepository) CreateVerificationToken(ctx context.Context, usr *entity.User, token *entity.VerificationToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := r.insertVerificationTx(ctx, tx, usr, token); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// CreatePasswordResetToken replaces the unused password reset tokens of a user with a new one and
+// queues the email carrying it. The record holds the hash of token, which only goes in the email.
+func (r *UserRepository) CreatePasswordResetToken(ctx context.Context, usr *entity.User, token string, record *entity.VerificationToken, expiresIn time.Duration) error {
+	message, err := r.passwordResetEmail(usr.Username, usr.Email, token, expiresIn)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to render password reset email")
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	// Only the newest link works, so a user who asks again does not leave older ones open
+	const revokeQuery = `
+		UPDATE verification_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND type = $2 AND used_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, revokeQuery, usr.ID, entity.PasswordReset); err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to revoke earlier password reset tokens")
+		return err
+	}
+
+	if err := insertTokenTx(ctx, tx, record); err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to create password reset token")
+		return err
+	}
+
+	if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindPasswordReset, message); err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to queue password reset email")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ActivateUserWithToken consumes an email verification token, activates its user and queues the
+// welcome email in one transaction
+func (r *UserRepository) ActivateUserWithToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	// Consume the token first so two concurrent requests cannot both use it
+	const consumeQuery = `
+		UPDATE verification_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND type = $3 AND used_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, consumeQuery, tokenID, userID, entity.EmailVerification)
+	if err != nil {
+		r.log.WithError(err).WithField("token_id", tokenID).Error("Failed to consume verification token")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+
+	const activateQuery = `UPDATE users SET is_active = true WHERE id = $1 RETURNING username, email`
+	var username, email string
+	if err := tx.QueryRowContext(ctx, activateQuery, userID).Scan(&username, &email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to activate user")
+		return err
+	}
+
+	message, err := r.welcomeEmail(username, email)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to render welcome email")
+		return err
+	}
+
+	if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindWelcome, message); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to queue welcome email")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
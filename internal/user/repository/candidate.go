@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+// Candidate search parameters are positional and shared by the count and list queries:
+// $1 company, $2 skills, $3 minimum proficiency, $4 proficiency levels in order, $5 fields of study,
+// $6 minimum years, $7 maximum years, $8 location, $9 remote
+const candidateSearchFrom = `
+	FROM job_seekers s
+	JOIN users u ON u.id = s.user_id
+	LEFT JOIN (
+		SELECT job_seeker_id, SUM(COALESCE(end_date, CURRENT_DATE) - start_date) / 365.25 AS years
+		FROM job_seeker_experiences
+		GROUP BY job_seeker_id
+	) x ON x.job_seeker_id = s.id
+	CROSS JOIN LATERAL (
+		SELECT EXISTS (
+			SELECT 1 FROM job_applications a
+			JOIN job_postings j ON j.id = a.job_id
+			WHERE a.job_seeker_id = s.id AND j.company_id = $1
+		) AS applied
+	) ap
+	WHERE u.is_active = TRUE
+	  AND (s.visibility = 'public' OR (s.visibility = 'applied_only' AND ap.applied))
+	  AND (COALESCE(cardinality($2::text[]), 0) = 0 OR (
+		SELECT COUNT(DISTINCT LOWER(k.skill_name))
+		FROM job_seeker_skills k
+		WHERE k.job_seeker_id = s.id
+		  AND LOWER(k.skill_name) = ANY($2)
+		  AND ($3::text IS NULL OR array_position($4::text[], k.proficiency_level) >= array_position($4::text[], $3))
+	  ) = cardinality($2))
+	  AND (COALESCE(cardinality($5::text[]), 0) = 0 OR EXISTS (
+		SELECT 1 FROM job_seeker_educations ed
+		WHERE ed.job_seeker_id = s.id AND LOWER(ed.field_of_study) LIKE ANY($5)
+	  ))
+	  AND ($6::numeric IS NULL OR COALESCE(x.years, 0) >= $6)
+	  AND ($7::numeric IS NULL OR COALESCE(x.years, 0) <= $7)
+	  AND ($8::text IS NULL OR EXISTS (
+		SELECT 1 FROM job_seeker_preferences p
+		WHERE p.job_seeker_id = s.id AND p.preferred_location ILIKE '%' || $8 || '%'
+	  ))
+	  AND ($9::boolean IS NULL OR EXISTS (
+		SELECT 1 FROM job_seeker_preferences p
+		WHERE p.job_seeker_id = s.id AND p.remote_preference = $9
+	  ))`
+
+// candidateSearchArgs returns the positional parameters of the candidate search queries
+func candidateSearchArgs(companyID uuid.UUID, filter entity.CandidateSearchFilter) []interface{} {
+	// Every distinct skill has to match, so case variants and repeats are folded into one
+	skills := make(pq.StringArray, 0, len(filter.Skills))
+	for _, skill := range filter.Skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill != "" && !slices.Contains(skills, skill) {
+			skills = append(skills, skill)
+		}
+	}
+
+	// Fields of study match anywhere in the recorded field, e.g. "computer" matches "Computer Science"
+	fields := make(pq.StringArray, 0, len(filter.FieldsOfStudy))
+	for _, field := range filter.FieldsOfStudy {
+		fields = append(fields, "%"+escapeLike(strings.ToLower(field))+"%")
+	}
+
+	return []interface{}{
+		companyID,
+		skills,
+		filter.MinProficiency,
+		pq.StringArray(entity.ProficiencyLevels),
+		fields,
+		filter.MinYearsExperience,
+		filter.MaxYearsExperience,
+		filter.Location,
+		filter.Remote,
+	}
+}
+
+// SearchCandidates retrieves the job seekers visible to a company that match the filter,
+// most recently updated profiles first
+func (r *UserRepository) SearchCandidates(ctx context.Context, companyID uuid.UUID, filter entity.CandidateSearchFilter, paging pagination.Pagination) ([]entity.Candidate, int, error) {
+	args := candidateSearchArgs(companyID, filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*)` + candidateSearchFrom
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to count candidates")
+		return nil, 0, err
+	}
+
+	candidates := []entity.Candidate{}
+	query := `
+		SELECT s.id AS job_seeker_id, s.first_name, s.last_name, s.bio,
+		       ROUND(COALESCE(x.years, 0), 1)::float8 AS years_of_experience,
+		       ARRAY(SELECT k.skill_name FROM job_seeker_skills k WHERE k.job_seeker_id = s.id ORDER BY k.position) AS skills,
+		       ARRAY(SELECT DISTINCT ed.field_of_study FROM job_seeker_educations ed WHERE ed.job_seeker_id = s.id) AS fields_of_study,
+		       ARRAY(SELECT DISTINCT p.preferred_location FROM job_seeker_preferences p WHERE p.job_seeker_id = s.id) AS preferred_locations,
+		       EXISTS (SELECT 1 FROM job_seeker_preferences p WHERE p.job_seeker_id = s.id AND p.remote_preference) AS open_to_remote,
+		       ap.applied AS applied_to_company,
+		       s.updated_at` + candidateSearchFrom + `
+		ORDER BY s.updated_at DESC, s.id` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &candidates, query, args...); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to search candidates")
+		return nil, 0, err
+	}
+
+	return candidates, total, nil
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied value
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
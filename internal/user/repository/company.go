@@ -8,15 +8,47 @@ import (
 	"github.com/google/uuid"
 )
 
-// GetCompanyByID retrieves a company by its ID
+// companySelect loads a company together with its address
+const companySelect = `
+	SELECT c.id, c.user_id, c.name, c.description, c.industry, c.website, c.email, c.phone, c.logo_url, c.status,
+	       c.size, c.is_verified, c.address_id, c.created_at, c.updated_at,
+	       a.id, a.company_id, a.address_line_1, a.address_line_2, a.city, a.state, a.zip_code, a.country,
+	       a.created_at, a.updated_at
+	FROM companies c
+	JOIN company_addresses a ON a.id = c.address_id
+`
+
+// GetCompanyByID retrieves a company and its address by the company ID
 func (r *UserRepository) GetCompanyByID(ctx context.Context, id uuid.UUID) (*entity.Company, error) {
-	const query = `
-		SELECT id, user_id, name, description, industry, website, email, phone, logo_url, status, size, is_verified, address_id, created_at, updated_at
-		FROM companies
-		WHERE id = $1
-	`
-	company := &entity.Company{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	company, err := r.getCompany(ctx, companySelect+`WHERE c.id = $1`, id)
+	if err != nil {
+		if !errors.Is(err, ErrCompanyNotFound) {
+			r.log.WithError(err).WithField("company_id", id).Error("Failed to get company by ID")
+		}
+		return nil, err
+	}
+
+	return company, nil
+}
+
+// GetCompanyByUserID retrieves the company and its address the user is a member of
+func (r *UserRepository) GetCompanyByUserID(ctx context.Context, userID uuid.UUID) (*entity.Company, error) {
+	company, err := r.getCompany(ctx, companySelect+`WHERE c.id = (SELECT company_id FROM company_members WHERE user_id = $1)`, userID)
+	if err != nil {
+		if !errors.Is(err, ErrCompanyNotFound) {
+			r.log.WithError(err).WithField("user_id", userID).Error("Failed to get company by user ID")
+		}
+		return nil, err
+	}
+
+	return company, nil
+}
+
+func (r *UserRepository) getCompany(ctx context.Context, query string, arg interface{}) (*entity.Company, error) {
+	company := &entity.Company{Address: &entity.CompanyAddress{}}
+	address := company.Address
+	var addressCompanyID uuid.NullUUID
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
 		&company.ID,
 		&company.UserID,
 		&company.Name,
@@ -32,14 +64,203 @@ func (r *UserRepository) GetCompanyByID(ctx context.Context, id uuid.UUID) (*ent
 		&company.AddressID,
 		&company.CreatedAt,
 		&company.UpdatedAt,
+		&address.ID,
+		&addressCompanyID,
+		&address.AddressLine1,
+		&address.AddressLine2,
+		&address.City,
+		&address.State,
+		&address.ZipCode,
+		&address.Country,
+		&address.CreatedAt,
+		&address.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrUserNotFound
+			return nil, ErrCompanyNotFound
 		}
-		r.log.WithError(err).Error("Failed to get company by ID")
 		return nil, err
 	}
+	address.CompanyID = addressCompanyID.UUID
 
 	return company, nil
 }
+
+// UpdateCompany updates the company profile and its address in one transaction
+func (r *UserRepository) UpdateCompany(ctx context.Context, company *entity.Company) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const companyQuery = `
+		UPDATE companies
+		SET name = $1, description = $2, industry = $3, website = $4, email = $5, phone = $6, size = $7, updated_at = NOW()
+		WHERE id = $8
+		RETURNING updated_at
+	`
+	err = tx.QueryRowContext(ctx, companyQuery,
+		company.Name,
+		company.Description,
+		company.Industry,
+		company.Website,
+		company.Email,
+		company.Phone,
+		company.Size,
+		company.ID,
+	).Scan(&company.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCompanyNotFound
+		}
+		r.log.WithError(err).WithField("company_id", company.ID).Error("Failed to update company")
+		return err
+	}
+
+	address := company.Address
+	const addressQuery = `
+		UPDATE company_addresses
+		SET address_line_1 = $1, address_line_2 = $2, city = $3, state = $4, zip_code = $5, country = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING updated_at
+	`
+	err = tx.QueryRowContext(ctx, addressQuery,
+		address.AddressLine1,
+		address.AddressLine2,
+		address.City,
+		address.State,
+		address.ZipCode,
+		address.Country,
+		company.AddressID,
+	).Scan(&address.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", company.ID).Error("Failed to update company address")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCompanyLogo stores the object name of the company logo, or clears it when logo is nil
+func (r *UserRepository) UpdateCompanyLogo(ctx context.Context, companyID uuid.UUID, logo *string) error {
+	const query = `UPDATE companies SET logo_url = $1, updated_at = NOW() WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, logo, companyID)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to update company logo")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCompanyNotFound
+	}
+
+	return nil
+}
+
+// CreateCompanyUser creates a company user together with its companies and company_addresses rows
+// in one transaction, and makes the user the company owner. The address is inserted first because
+// companies.address_id is required, then linked back to the company once it exists. The email
+// verification token is stored and its email queued in the same transaction.
+func (r *UserRepository) CreateCompanyUser(ctx context.Context, usr *entity.User, company *entity.Company, verification *entity.VerificationToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := insertUserTx(ctx, tx, usr); err != nil {
+		if errors.Is(err, ErrUserAlreadyExists) {
+			return err
+		}
+		r.log.WithError(err).WithField("email", usr.Email).Error("Failed to create user record for company")
+		return err
+	}
+
+	address := company.Address
+	const addressQuery = `
+		INSERT INTO company_addresses (id, address_line_1, address_line_2, city, state, zip_code, country)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, addressQuery,
+		address.ID,
+		address.AddressLine1,
+		address.AddressLine2,
+		address.City,
+		address.State,
+		address.ZipCode,
+		address.Country,
+	).Scan(&address.CreatedAt, &address.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to create company address")
+		return err
+	}
+
+	const companyQuery = `
+		INSERT INTO companies (id, user_id, name, description, industry, website, email, phone, status, size, address_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING is_verified, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, companyQuery,
+		company.ID,
+		company.UserID,
+		company.Name,
+		company.Description,
+		company.Industry,
+		company.Website,
+		company.Email,
+		company.Phone,
+		company.Status,
+		company.Size,
+		company.AddressID,
+	).Scan(&company.IsVerified, &company.CreatedAt, &company.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to create company")
+		return err
+	}
+
+	const linkQuery = `UPDATE company_addresses SET company_id = $1 WHERE id = $2`
+	if _, err := tx.ExecContext(ctx, linkQuery, company.ID, address.ID); err != nil {
+		r.log.WithError(err).WithField("company_id", company.ID).Error("Failed to link company address")
+		return err
+	}
+
+	const memberQuery = `INSERT INTO company_members (company_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, memberQuery, company.ID, usr.ID, entity.CompanyOwner); err != nil {
+		r.log.WithError(err).WithField("company_id", company.ID).Error("Failed to add company owner")
+		return err
+	}
+
+	if err := r.insertVerificationTx(ctx, tx, usr, verification); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
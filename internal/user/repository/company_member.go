@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	outboxEntity "github.com/agpprastyo/career-link/internal/outbox/entity"
+	outboxRepository "github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"time"
+)
+
+const invitationColumns = `i.id, i.company_id, i.email, i.role, i.invited_by, i.token_id, t.expired_at AS expires_at,
+	i.accepted_at, i.revoked_at, i.created_at`
+
+// GetCompanyMembership retrieves the company membership of a user
+func (r *UserRepository) GetCompanyMembership(ctx context.Context, userID uuid.UUID) (*entity.CompanyMember, error) {
+	const query = `SELECT company_id, user_id, role, created_at, updated_at FROM company_members WHERE user_id = $1`
+
+	member := &entity.CompanyMember{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&member.CompanyID,
+		&member.UserID,
+		&member.Role,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCompanyMemberNotFound
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to get company membership")
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ListCompanyMembers retrieves every member of a company, owners first
+func (r *UserRepository) ListCompanyMembers(ctx context.Context, companyID uuid.UUID) ([]entity.CompanyMemberDetail, error) {
+	members := []entity.CompanyMemberDetail{}
+	query := `SELECT m.company_id, m.user_id, m.role, m.created_at, m.updated_at, u.username, u.email, u.avatar
+              FROM company_members m
+              JOIN users u ON u.id = m.user_id
+              WHERE m.company_id = $1
+              ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'recruiter' THEN 1 ELSE 2 END, m.created_at`
+	if err := r.db.SelectContext(ctx, &members, query, companyID); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to list company members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// UpdateCompanyMemberRole changes the role of a member. The last owner of a company cannot be demoted.
+func (r *UserRepository) UpdateCompanyMemberRole(ctx context.Context, companyID, userID uuid.UUID, role entity.CompanyMemberRole) error {
+	return r.changeCompanyMember(ctx, companyID, userID, role != entity.CompanyOwner, func(tx *sql.Tx) (sql.Result, error) {
+		const query = `UPDATE company_members SET role = $1 WHERE company_id = $2 AND user_id = $3`
+		return tx.ExecContext(ctx, query, role, companyID, userID)
+	})
+}
+
+// RemoveCompanyMember removes a member from a company. The last owner of a company cannot be removed.
+func (r *UserRepository) RemoveCompanyMember(ctx context.Context, companyID, userID uuid.UUID) error {
+	return r.changeCompanyMember(ctx, companyID, userID, true, func(tx *sql.Tx) (sql.Result, error) {
+		const query = `DELETE FROM company_members WHERE company_id = $1 AND user_id = $2`
+		return tx.ExecContext(ctx, query, companyID, userID)
+	})
+}
+
+// changeCompanyMember applies change to a member while the company's owners are locked, so two
+// concurrent changes cannot leave the company without an owner
+func (r *UserRepository) changeCompanyMember(ctx context.Context, companyID, userID uuid.UUID, dropsOwner bool, change func(tx *sql.Tx) (sql.Result, error)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const ownersQuery = `SELECT user_id FROM company_members WHERE company_id = $1 AND role = 'owner' FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, ownersQuery, companyID)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to lock company owners")
+		return err
+	}
+	var owners int
+	var isOwner bool
+	for rows.Next() {
+		var ownerID uuid.UUID
+		if err := rows.Scan(&ownerID); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		owners++
+		isOwner = isOwner || ownerID == userID
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	if dropsOwner && isOwner && owners == 1 {
+		return ErrLastCompanyOwner
+	}
+
+	result, err := change(tx)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to change company member")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCompanyMemberNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// CreateCompanyInvitation stores an invitation and the record of its token, which holds the hash of
+// the token only sent in the email. Any open invitation for the same email is revoked, so inviting
+// someone again resends the invitation.
+func (r *UserRepository) CreateCompanyInvitation(ctx context.Context, inv *entity.CompanyInvitation, token string, record *entity.VerificationToken, companyName, inviterName string, expiresIn time.Duration) error {
+	message, err := r.companyInvitationEmail(companyName, inviterName, inv.Email, token, inv.Role, expiresIn)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", inv.CompanyID).Error("Failed to render company invitation email")
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const revokeQuery = `
+		UPDATE company_invitations
+		SET revoked_at = NOW()
+		WHERE company_id = $1 AND LOWER(email) = LOWER($2) AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, revokeQuery, inv.CompanyID, inv.Email); err != nil {
+		r.log.WithError(err).WithField("company_id", inv.CompanyID).Error("Failed to revoke previous company invitation")
+		return err
+	}
+
+	if err := insertTokenTx(ctx, tx, record); err != nil {
+		r.log.WithError(err).WithField("company_id", inv.CompanyID).Error("Failed to create company invitation token")
+		return err
+	}
+
+	const invitationQuery = `
+		INSERT INTO company_invitations (id, company_id, email, role, invited_by, token_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	err = tx.QueryRowContext(ctx, invitationQuery,
+		inv.ID,
+		inv.CompanyID,
+		inv.Email,
+		inv.Role,
+		inv.InvitedBy,
+		inv.TokenID,
+	).Scan(&inv.CreatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", inv.CompanyID).Error("Failed to create company invitation")
+		return err
+	}
+
+	if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindCompanyInvitation, message); err != nil {
+		r.log.WithError(err).WithField("company_id", inv.CompanyID).Error("Failed to queue company invitation email")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ListCompanyInvitations retrieves the open invitations of a company, newest first
+func (r *UserRepository) ListCompanyInvitations(ctx context.Context, companyID uuid.UUID) ([]entity.CompanyInvitation, error) {
+	invitations := []entity.CompanyInvitation{}
+	query := `SELECT ` + invitationColumns + `
+              FROM company_invitations i
+              JOIN verification_tokens t ON t.id = i.token_id
+              WHERE i.company_id = $1 AND i.accepted_at IS NULL AND i.revoked_at IS NULL
+              ORDER BY i.created_at DESC`
+	if err := r.db.SelectContext(ctx, &invitations, query, companyID); err != nil {
+		r.log.WithError(err).WithField("company_id", companyID).Error("Failed to list company invitations")
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// RevokeCompanyInvitation revokes an open invitation of a company
+func (r *UserRepository) RevokeCompanyInvitation(ctx context.Context, companyID, id uuid.UUID) error {
+	const query = `
+		UPDATE company_invitations
+		SET revoked_at = NOW()
+		WHERE id = $1 AND company_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id, companyID)
+	if err != nil {
+		r.log.WithError(err).WithField("invitation_id", id).Error("Failed to revoke company invitation")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// GetCompanyInvitationByToken retrieves the invitation carried by an invitation token, given its hash
+func (r *UserRepository) GetCompanyInvitationByToken(ctx context.Context, token string) (*entity.CompanyInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+              FROM company_invitations i
+              JOIN verification_tokens t ON t.id = i.token_id
+              WHERE t.token = $1 AND t.type = $2`
+
+	inv := &entity.CompanyInvitation{}
+	err := r.db.QueryRowContext(ctx, query, token, entity.CompanyInvitationToken).Scan(
+		&inv.ID,
+		&inv.CompanyID,
+		&inv.Email,
+		&inv.Role,
+		&inv.InvitedBy,
+		&inv.TokenID,
+		&inv.ExpiresAt,
+		&inv.AcceptedAt,
+		&inv.RevokedAt,
+		&inv.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		r.log.WithError(err).Error("Failed to get company invitation by token")
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// AcceptCompanyInvitation consumes an invitation and adds the user to the company. When newUser is
+// set the account is created in the same transaction and activated, since the invitation proves
+// the email address.
+func (r *UserRepository) AcceptCompanyInvitation(ctx context.Context, inv *entity.CompanyInvitation, usr *entity.User, newUser bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if newUser {
+		if err := insertUserTx(ctx, tx, usr); err != nil {
+			if errors.Is(err, ErrUserAlreadyExists) {
+				return err
+			}
+			r.log.WithError(err).WithField("email", usr.Email).Error("Failed to create invited user")
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET is_active = TRUE WHERE id = $1`, usr.ID); err != nil {
+			r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to activate invited user")
+			return err
+		}
+	}
+
+	const tokenQuery = `UPDATE verification_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+	result, err := tx.ExecContext(ctx, tokenQuery, time.Now(), inv.TokenID)
+	if err != nil {
+		r.log.WithError(err).WithField("invitation_id", inv.ID).Error("Failed to consume company invitation token")
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+
+	const invitationQuery = `
+		UPDATE company_invitations
+		SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+	result, err = tx.ExecContext(ctx, invitationQuery, inv.ID)
+	if err != nil {
+		r.log.WithError(err).WithField("invitation_id", inv.ID).Error("Failed to accept company invitation")
+		return err
+	}
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+
+	const memberQuery = `INSERT INTO company_members (company_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, memberQuery, inv.CompanyID, usr.ID, inv.Role); err != nil {
+		if isUniqueViolation(err) {
+			return ErrAlreadyCompanyMember
+		}
+		r.log.WithError(err).WithField("invitation_id", inv.ID).Error("Failed to add company member")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
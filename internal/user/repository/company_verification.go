@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	outboxEntity "github.com/agpprastyo/career-link/internal/outbox/entity"
+	outboxRepository "github.com/agpprastyo/career-link/internal/outbox/repository"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"io"
+	"time"
+)
+
+// verificationDocumentURLExpiry is how long a reviewer's document download link stays valid
+const verificationDocumentURLExpiry = 15 * time.Minute
+
+const verificationColumns = `v.id, v.company_id, v.status, v.rejection_reason, v.reviewed_by, v.reviewed_at, v.created_at, v.updated_at`
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// CreateVerificationRequest inserts a pending verification request together with its documents
+func (r *UserRepository) CreateVerificationRequest(ctx context.Context, req *entity.CompanyVerificationRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const requestQuery = `
+		INSERT INTO company_verification_requests (id, company_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, requestQuery, req.ID, req.CompanyID, req.Status).Scan(&req.CreatedAt, &req.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrVerificationPending
+		}
+		r.log.WithError(err).WithField("company_id", req.CompanyID).Error("Failed to create verification request")
+		return err
+	}
+
+	const documentQuery = `
+		INSERT INTO company_verification_documents (id, request_id, file_name, object_name, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	for i := range req.Documents {
+		doc := &req.Documents[i]
+		err = tx.QueryRowContext(ctx, documentQuery,
+			doc.ID,
+			doc.RequestID,
+			doc.FileName,
+			doc.ObjectName,
+			doc.ContentType,
+			doc.SizeBytes,
+		).Scan(&doc.CreatedAt)
+		if err != nil {
+			r.log.WithError(err).WithField("request_id", req.ID).Error("Failed to create verification document")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// GetLatestVerificationRequest retrieves the most recent verification request of a company with its documents
+func (r *UserRepository) GetLatestVerificationRequest(ctx context.Context, companyID uuid.UUID) (*entity.CompanyVerificationRequest, error) {
+	query := `SELECT ` + verificationColumns + `
+              FROM company_verification_requests v
+              WHERE v.company_id = $1
+              ORDER BY v.created_at DESC
+              LIMIT 1`
+
+	return r.getVerificationRequest(ctx, query, companyID)
+}
+
+// GetVerificationRequestByID retrieves a verification request with its documents
+func (r *UserRepository) GetVerificationRequestByID(ctx context.Context, id uuid.UUID) (*entity.CompanyVerificationRequest, error) {
+	query := `SELECT ` + verificationColumns + ` FROM company_verification_requests v WHERE v.id = $1`
+
+	return r.getVerificationRequest(ctx, query, id)
+}
+
+func (r *UserRepository) getVerificationRequest(ctx context.Context, query string, arg interface{}) (*entity.CompanyVerificationRequest, error) {
+	req := &entity.CompanyVerificationRequest{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&req.ID,
+		&req.CompanyID,
+		&req.Status,
+		&req.RejectionReason,
+		&req.ReviewedBy,
+		&req.ReviewedAt,
+		&req.CreatedAt,
+		&req.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVerificationNotFound
+		}
+		r.log.WithError(err).Error("Failed to get verification request")
+		return nil, err
+	}
+
+	req.Documents = []entity.CompanyVerificationDocument{}
+	const documentQuery = `SELECT id, request_id, file_name, object_name, content_type, size_bytes, created_at
+                           FROM company_verification_documents
+                           WHERE request_id = $1
+                           ORDER BY created_at`
+	if err := r.db.SelectContext(ctx, &req.Documents, documentQuery, req.ID); err != nil {
+		r.log.WithError(err).WithField("request_id", req.ID).Error("Failed to list verification documents")
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ListVerificationRequests retrieves a paginated review queue, oldest first, optionally filtered by status
+func (r *UserRepository) ListVerificationRequests(ctx context.Context, status *entity.VerificationStatus, paging pagination.Pagination) ([]entity.CompanyVerificationSummary, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM company_verification_requests v WHERE ($1::varchar IS NULL OR v.status = $1)`
+	if err := r.db.QueryRowContext(ctx, countQuery, status).Scan(&total); err != nil {
+		r.log.WithError(err).Error("Failed to count verification requests")
+		return nil, 0, err
+	}
+
+	requests := []entity.CompanyVerificationSummary{}
+	query := `SELECT ` + verificationColumns + `, c.name AS company_name, c.email AS company_email
+              FROM company_verification_requests v
+              JOIN companies c ON c.id = v.company_id
+              WHERE ($1::varchar IS NULL OR v.status = $1)
+              ORDER BY v.created_at ASC` + paging.GetSQLLimitOffset()
+	if err := r.db.SelectContext(ctx, &requests, query, status); err != nil {
+		r.log.WithError(err).Error("Failed to list verification requests")
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// ReviewVerificationRequest records the decision on a pending request. Approving also marks the
+// company as verified. Requests that were already reviewed are left untouched.
+func (r *UserRepository) ReviewVerificationRequest(ctx context.Context, id uuid.UUID, status entity.VerificationStatus, reviewerID uuid.UUID, reason *string) (*entity.CompanyVerificationRequest, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	query := `
+		UPDATE company_verification_requests v
+		SET status = $1, rejection_reason = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE v.id = $4 AND v.status = 'pending'
+		RETURNING ` + verificationColumns
+
+	req := &entity.CompanyVerificationRequest{}
+	err = tx.QueryRowContext(ctx, query, status, reason, reviewerID, id).Scan(
+		&req.ID,
+		&req.CompanyID,
+		&req.Status,
+		&req.RejectionReason,
+		&req.ReviewedBy,
+		&req.ReviewedAt,
+		&req.CreatedAt,
+		&req.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVerificationAlreadyReviewed
+		}
+		r.log.WithError(err).WithField("request_id", id).Error("Failed to review verification request")
+		return nil, err
+	}
+
+	if status == entity.VerificationApproved {
+		const companyQuery = `UPDATE companies SET is_verified = TRUE, updated_at = NOW() WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, companyQuery, req.CompanyID); err != nil {
+			r.log.WithError(err).WithField("company_id", req.CompanyID).Error("Failed to mark company as verified")
+			return nil, err
+		}
+	}
+
+	var companyName, companyEmail string
+	const contactQuery = `SELECT name, email FROM companies WHERE id = $1`
+	if err := tx.QueryRowContext(ctx, contactQuery, req.CompanyID).Scan(&companyName, &companyEmail); err != nil {
+		r.log.WithError(err).WithField("company_id", req.CompanyID).Error("Failed to get company for verification email")
+		return nil, err
+	}
+
+	var rejectionReason string
+	if reason != nil {
+		rejectionReason = *reason
+	}
+	message, err := r.companyVerificationResultEmail(companyName, companyEmail, status == entity.VerificationApproved, rejectionReason)
+	if err != nil {
+		r.log.WithError(err).WithField("company_id", req.CompanyID).Error("Failed to render company verification email")
+		return nil, err
+	}
+
+	if err := outboxRepository.EnqueueEmail(ctx, tx, outboxEntity.EmailKindCompanyVerification, message); err != nil {
+		r.log.WithError(err).WithField("company_id", req.CompanyID).Error("Failed to queue company verification email")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// UploadVerificationDocumentFile uploads a verification document to storage
+func (r *UserRepository) UploadVerificationDocumentFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error {
+	if err := r.minio.UploadFile(ctx, objectName, fileContent, fileSize, contentType); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to upload verification document")
+		return fmt.Errorf("failed to upload verification document: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteVerificationDocumentFile removes a verification document from storage
+func (r *UserRepository) DeleteVerificationDocumentFile(ctx context.Context, objectName string) error {
+	if err := r.minio.DeleteFile(ctx, objectName); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to delete verification document")
+		return fmt.Errorf("failed to delete verification document: %w", err)
+	}
+
+	return nil
+}
+
+// GetVerificationDocumentURL generates a short-lived download URL for a verification document
+func (r *UserRepository) GetVerificationDocumentURL(ctx context.Context, objectName string) (string, error) {
+	url, err := r.minio.GetFileURL(ctx, objectName, verificationDocumentURLExpiry)
+	if err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to generate URL for verification document")
+		return "", fmt.Errorf("failed to generate URL for verification document: %w", err)
+	}
+
+	return url, nil
+}
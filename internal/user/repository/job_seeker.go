@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+)
+
+// CreateJobSeekerUser creates a job seeker user together with its job_seekers profile in one transaction,
+// storing the email verification token and queueing its email in the same transaction
+func (r *UserRepository) CreateJobSeekerUser(ctx context.Context, usr *entity.User, seeker *entity.JobSeeker, verification *entity.VerificationToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := insertUserTx(ctx, tx, usr); err != nil {
+		if errors.Is(err, ErrUserAlreadyExists) {
+			return err
+		}
+		r.log.WithError(err).WithField("email", usr.Email).Error("Failed to create user record for job seeker")
+		return err
+	}
+
+	const seekerQuery = `
+		INSERT INTO job_seekers (id, user_id, first_name, last_name, date_of_birth, bio)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING visibility, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, seekerQuery,
+		seeker.ID,
+		seeker.UserID,
+		seeker.FirstName,
+		seeker.LastName,
+		seeker.DateOfBirth,
+		seeker.Bio,
+	).Scan(&seeker.Visibility, &seeker.CreatedAt, &seeker.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", usr.ID).Error("Failed to create job seeker profile")
+		return err
+	}
+
+	if err := r.insertVerificationTx(ctx, tx, usr, verification); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,549 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// sectionTables maps the repeatable profile sections to their tables
+var sectionTables = map[entity.ProfileSection]string{
+	entity.SectionSkills:      "job_seeker_skills",
+	entity.SectionExperiences: "job_seeker_experiences",
+	entity.SectionEducations:  "job_seeker_educations",
+	entity.SectionProjects:    "job_seeker_projects",
+	entity.SectionLinks:       "job_seeker_links",
+	entity.SectionPreferences: "job_seeker_preferences",
+}
+
+// nextPosition appends new section items after the existing ones
+const nextPosition = `(SELECT COALESCE(MAX(position) + 1, 0) FROM %s WHERE job_seeker_id = $2)`
+
+// GetJobSeekerByUserID retrieves the job seeker profile of a user
+func (r *UserRepository) GetJobSeekerByUserID(ctx context.Context, userID uuid.UUID) (*entity.JobSeeker, error) {
+	const query = `
+		SELECT id, user_id, first_name, last_name, date_of_birth, bio, profile_picture_url, visibility, created_at, updated_at
+		FROM job_seekers
+		WHERE user_id = $1
+	`
+
+	var s entity.JobSeeker
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&s.ID,
+		&s.UserID,
+		&s.FirstName,
+		&s.LastName,
+		&s.DateOfBirth,
+		&s.Bio,
+		&s.ProfilePictureURL,
+		&s.Visibility,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrJobSeekerNotFound
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to get job seeker by user ID")
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// UpdateJobSeeker updates the basic fields of a job seeker profile
+func (r *UserRepository) UpdateJobSeeker(ctx context.Context, seeker *entity.JobSeeker) error {
+	const query = `
+		UPDATE job_seekers
+		SET first_name = $1, last_name = $2, date_of_birth = $3, bio = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		seeker.FirstName,
+		seeker.LastName,
+		seeker.DateOfBirth,
+		seeker.Bio,
+		seeker.ID,
+	).Scan(&seeker.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrJobSeekerNotFound
+		}
+		r.log.WithError(err).WithField("job_seeker_id", seeker.ID).Error("Failed to update job seeker")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerVisibility changes who can find a job seeker in the candidate search
+func (r *UserRepository) UpdateJobSeekerVisibility(ctx context.Context, jobSeekerID uuid.UUID, visibility entity.ProfileVisibility) error {
+	const query = `UPDATE job_seekers SET visibility = $1, updated_at = NOW() WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, visibility, jobSeekerID)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to update job seeker visibility")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrJobSeekerNotFound
+	}
+
+	return nil
+}
+
+// ListJobSeekerSkills retrieves the skills of a job seeker in display order
+func (r *UserRepository) ListJobSeekerSkills(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerSkill, error) {
+	items := []entity.JobSeekerSkill{}
+	query := `SELECT id, job_seeker_id, skill_name, proficiency_level, position, created_at, updated_at
+              FROM job_seeker_skills
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker skills")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerSkill adds a skill at the end of the section
+func (r *UserRepository) CreateJobSeekerSkill(ctx context.Context, item *entity.JobSeekerSkill) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_skills (id, job_seeker_id, skill_name, proficiency_level, position)
+		VALUES ($1, $2, $3, $4, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_skills"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.SkillName,
+		item.ProficiencyLevel,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker skill")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerSkill updates a skill owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerSkill(ctx context.Context, item *entity.JobSeekerSkill) error {
+	const query = `
+		UPDATE job_seeker_skills
+		SET skill_name = $1, proficiency_level = $2, updated_at = NOW()
+		WHERE id = $3 AND job_seeker_id = $4
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.SkillName,
+		item.ProficiencyLevel,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker skill")
+}
+
+// ListJobSeekerExperiences retrieves the work experience of a job seeker in display order
+func (r *UserRepository) ListJobSeekerExperiences(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerExperience, error) {
+	items := []entity.JobSeekerExperience{}
+	query := `SELECT id, job_seeker_id, job_title, company_name, start_date, end_date, description, position, created_at, updated_at
+              FROM job_seeker_experiences
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker experiences")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerExperience adds a work experience at the end of the section
+func (r *UserRepository) CreateJobSeekerExperience(ctx context.Context, item *entity.JobSeekerExperience) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_experiences (id, job_seeker_id, job_title, company_name, start_date, end_date, description, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_experiences"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.JobTitle,
+		item.CompanyName,
+		item.StartDate,
+		item.EndDate,
+		item.Description,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker experience")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerExperience updates a work experience owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerExperience(ctx context.Context, item *entity.JobSeekerExperience) error {
+	const query = `
+		UPDATE job_seeker_experiences
+		SET job_title = $1, company_name = $2, start_date = $3, end_date = $4, description = $5, updated_at = NOW()
+		WHERE id = $6 AND job_seeker_id = $7
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.JobTitle,
+		item.CompanyName,
+		item.StartDate,
+		item.EndDate,
+		item.Description,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker experience")
+}
+
+// ListJobSeekerEducations retrieves the education of a job seeker in display order
+func (r *UserRepository) ListJobSeekerEducations(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerEducation, error) {
+	items := []entity.JobSeekerEducation{}
+	query := `SELECT id, job_seeker_id, institution_name, degree, field_of_study, start_date, end_date, grade, description, position, created_at, updated_at
+              FROM job_seeker_educations
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker educations")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerEducation adds an education entry at the end of the section
+func (r *UserRepository) CreateJobSeekerEducation(ctx context.Context, item *entity.JobSeekerEducation) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_educations (id, job_seeker_id, institution_name, degree, field_of_study, start_date, end_date, grade, description, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_educations"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.InstitutionName,
+		item.Degree,
+		item.FieldOfStudy,
+		item.StartDate,
+		item.EndDate,
+		item.Grade,
+		item.Description,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker education")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerEducation updates an education entry owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerEducation(ctx context.Context, item *entity.JobSeekerEducation) error {
+	const query = `
+		UPDATE job_seeker_educations
+		SET institution_name = $1, degree = $2, field_of_study = $3, start_date = $4, end_date = $5,
+		    grade = $6, description = $7, updated_at = NOW()
+		WHERE id = $8 AND job_seeker_id = $9
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.InstitutionName,
+		item.Degree,
+		item.FieldOfStudy,
+		item.StartDate,
+		item.EndDate,
+		item.Grade,
+		item.Description,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker education")
+}
+
+// ListJobSeekerProjects retrieves the projects of a job seeker in display order
+func (r *UserRepository) ListJobSeekerProjects(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerProject, error) {
+	items := []entity.JobSeekerProject{}
+	query := `SELECT id, job_seeker_id, title, description, start_date, end_date, link, position, created_at, updated_at
+              FROM job_seeker_projects
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker projects")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerProject adds a project at the end of the section
+func (r *UserRepository) CreateJobSeekerProject(ctx context.Context, item *entity.JobSeekerProject) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_projects (id, job_seeker_id, title, description, start_date, end_date, link, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_projects"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.Title,
+		item.Description,
+		item.StartDate,
+		item.EndDate,
+		item.Link,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker project")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerProject updates a project owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerProject(ctx context.Context, item *entity.JobSeekerProject) error {
+	const query = `
+		UPDATE job_seeker_projects
+		SET title = $1, description = $2, start_date = $3, end_date = $4, link = $5, updated_at = NOW()
+		WHERE id = $6 AND job_seeker_id = $7
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.Title,
+		item.Description,
+		item.StartDate,
+		item.EndDate,
+		item.Link,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker project")
+}
+
+// ListJobSeekerLinks retrieves the links of a job seeker in display order
+func (r *UserRepository) ListJobSeekerLinks(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerLink, error) {
+	items := []entity.JobSeekerLink{}
+	query := `SELECT id, job_seeker_id, link_type, url, position, created_at, updated_at
+              FROM job_seeker_links
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker links")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerLink adds a link at the end of the section
+func (r *UserRepository) CreateJobSeekerLink(ctx context.Context, item *entity.JobSeekerLink) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_links (id, job_seeker_id, link_type, url, position)
+		VALUES ($1, $2, $3, $4, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_links"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.LinkType,
+		item.URL,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker link")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerLink updates a link owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerLink(ctx context.Context, item *entity.JobSeekerLink) error {
+	const query = `
+		UPDATE job_seeker_links
+		SET link_type = $1, url = $2, updated_at = NOW()
+		WHERE id = $3 AND job_seeker_id = $4
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.LinkType,
+		item.URL,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker link")
+}
+
+// ListJobSeekerPreferences retrieves the job preferences of a job seeker in display order
+func (r *UserRepository) ListJobSeekerPreferences(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerPreference, error) {
+	items := []entity.JobSeekerPreference{}
+	query := `SELECT id, job_seeker_id, preferred_job_type, preferred_industry, preferred_location, salary_expectation,
+                     remote_preference, position, created_at, updated_at
+              FROM job_seeker_preferences
+              WHERE job_seeker_id = $1
+              ORDER BY position, created_at`
+	if err := r.db.SelectContext(ctx, &items, query, jobSeekerID); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to list job seeker preferences")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateJobSeekerPreference adds a job preference at the end of the section
+func (r *UserRepository) CreateJobSeekerPreference(ctx context.Context, item *entity.JobSeekerPreference) error {
+	query := fmt.Sprintf(`
+		INSERT INTO job_seeker_preferences (id, job_seeker_id, preferred_job_type, preferred_industry, preferred_location,
+		                                    salary_expectation, remote_preference, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, %s)
+		RETURNING position, created_at, updated_at
+	`, fmt.Sprintf(nextPosition, "job_seeker_preferences"))
+	err := r.db.QueryRowContext(ctx, query,
+		item.ID,
+		item.JobSeekerID,
+		item.PreferredJobType,
+		item.PreferredIndustry,
+		item.PreferredLocation,
+		item.SalaryExpectation,
+		item.RemotePreference,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", item.JobSeekerID).Error("Failed to create job seeker preference")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateJobSeekerPreference updates a job preference owned by the job seeker
+func (r *UserRepository) UpdateJobSeekerPreference(ctx context.Context, item *entity.JobSeekerPreference) error {
+	const query = `
+		UPDATE job_seeker_preferences
+		SET preferred_job_type = $1, preferred_industry = $2, preferred_location = $3, salary_expectation = $4,
+		    remote_preference = $5, updated_at = NOW()
+		WHERE id = $6 AND job_seeker_id = $7
+		RETURNING position, created_at, updated_at
+	`
+	err := r.db.QueryRowContext(ctx, query,
+		item.PreferredJobType,
+		item.PreferredIndustry,
+		item.PreferredLocation,
+		item.SalaryExpectation,
+		item.RemotePreference,
+		item.ID,
+		item.JobSeekerID,
+	).Scan(&item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return r.sectionItemResult(err, item.ID, "Failed to update job seeker preference")
+}
+
+// DeleteJobSeekerSectionItem deletes an item of a profile section owned by the job seeker
+func (r *UserRepository) DeleteJobSeekerSectionItem(ctx context.Context, section entity.ProfileSection, jobSeekerID, id uuid.UUID) error {
+	table, ok := sectionTables[section]
+	if !ok {
+		return ErrInvalidInput
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND job_seeker_id = $2`, table)
+	result, err := r.db.ExecContext(ctx, query, id, jobSeekerID)
+	if err != nil {
+		r.log.WithError(err).WithField("item_id", id).Errorf("Failed to delete item from %s", table)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrProfileItemNotFound
+	}
+
+	return nil
+}
+
+// ReorderJobSeekerSection sets the display order of a section. ids must list every item of
+// the section exactly once, in the new order.
+func (r *UserRepository) ReorderJobSeekerSection(ctx context.Context, section entity.ProfileSection, jobSeekerID uuid.UUID, ids []uuid.UUID) error {
+	table, ok := sectionTables[section]
+	if !ok {
+		return ErrInvalidInput
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	idStrings := make(pq.StringArray, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+
+	// Lock the section and check the submitted IDs are exactly its items
+	var total, matched int
+	checkQuery := fmt.Sprintf(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE id = ANY($2::uuid[]))
+		FROM (SELECT id FROM %s WHERE job_seeker_id = $1 FOR UPDATE) items
+	`, table)
+	if err := tx.QueryRowContext(ctx, checkQuery, jobSeekerID, idStrings).Scan(&total, &matched); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Errorf("Failed to check %s order", table)
+		return err
+	}
+	if total != len(ids) || matched != len(ids) {
+		return ErrInvalidInput
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE %s t
+		SET position = v.ord - 1, updated_at = NOW()
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS v(id, ord)
+		WHERE t.id = v.id AND t.job_seeker_id = $1
+	`, table)
+	if _, err := tx.ExecContext(ctx, updateQuery, jobSeekerID, idStrings); err != nil {
+		r.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Errorf("Failed to reorder %s", table)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// sectionItemResult maps the result of an owned section item update
+func (r *UserRepository) sectionItemResult(err error, id uuid.UUID, message string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProfileItemNotFound
+	}
+	r.log.WithError(err).WithField("item_id", id).Error(message)
+	return err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// companyLogoURLExpiry is how long a generated company logo URL stays valid
+const companyLogoURLExpiry = 24 * 7 * time.Hour
+
+// UploadCompanyLogoFile uploads a company logo to storage
+func (r *UserRepository) UploadCompanyLogoFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error {
+	if fileContent == nil {
+		return errors.New("file content cannot be nil")
+	}
+
+	if err := r.minio.UploadFile(ctx, objectName, fileContent, fileSize, contentType); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to upload company logo file")
+		return fmt.Errorf("failed to upload company logo file: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCompanyLogoFile removes a company logo from storage
+func (r *UserRepository) DeleteCompanyLogoFile(ctx context.Context, objectName string) error {
+	if objectName == "" {
+		return errors.New("file name cannot be empty")
+	}
+
+	if err := r.minio.DeleteFile(ctx, objectName); err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to delete company logo file")
+		return fmt.Errorf("failed to delete company logo file: %w", err)
+	}
+
+	return nil
+}
+
+// GetCompanyLogoURL generates a temporary URL for a stored company logo
+func (r *UserRepository) GetCompanyLogoURL(ctx context.Context, objectName string) (string, error) {
+	url, err := r.minio.GetFileURL(ctx, objectName, companyLogoURLExpiry)
+	if err != nil {
+		r.log.WithError(err).WithField("fileName", objectName).Error("Failed to generate URL for company logo file")
+		return "", fmt.Errorf("failed to generate URL for company logo file: %w", err)
+	}
+
+	return url, nil
+}
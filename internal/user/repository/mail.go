@@ -1,8 +1,8 @@
 package repository
 
 import (
-	"context"
 	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/entity"
 	"github.com/agpprastyo/career-link/pkg/mail"
 	"github.com/agpprastyo/career-link/pkg/mail/templates"
 	"net/url"
@@ -10,21 +10,24 @@ import (
 	"time"
 )
 
-func (r *UserRepository) SendVerificationEmail(ctx context.Context, username, email, token string) error {
+// The emails in this file are about the account itself: verification, password reset, company
+// verification and team invitations. They are security critical, so notification preferences never
+// turn them off. They all go through the email outbox, written in the same transaction as the change
+// they announce.
+
+// verificationEmail renders the email carrying the link that verifies a new account
+func (r *UserRepository) verificationEmail(username, email, token string) (mail.EmailMessage, error) {
 	// Ensure baseURL is properly formatted
 	baseURL := r.verifyBaseURL + "/verify"
 	if !strings.HasSuffix(baseURL, "/") {
 		baseURL = baseURL + "/"
 	}
 
-	// Remove the path segment that's causing duplication
-	// Change this line to use the correct path structure
 	verificationURL := fmt.Sprintf("%s?email=%s&token=%s",
 		baseURL,
 		url.QueryEscape(email),
 		url.QueryEscape(token))
 
-	// Rest of the function remains unchanged
 	data := templates.RegistrationData{
 		Username:        username,
 		Email:           email,
@@ -36,22 +39,19 @@ func (r *UserRepository) SendVerificationEmail(ctx context.Context, username, em
 
 	htmlContent, err := templates.GetRegistrationEmailHTML(data)
 	if err != nil {
-		return err
+		return mail.EmailMessage{}, err
 	}
 
-	message := mail.EmailMessage{
+	return mail.EmailMessage{
 		To:      email,
 		Subject: "Verify Your Career Link Account",
 		Body:    htmlContent,
 		IsHTML:  true,
-	}
-
-	return r.mail.SendEmail(ctx, message)
+	}, nil
 }
 
-// SendPostVerificationEmail Complete the SendPostVerificationEmail method in internal/user/repository/mail.go
-func (r *UserRepository) SendPostVerificationEmail(ctx context.Context, username, email string) error {
-	// Prepare template data
+// welcomeEmail renders the email confirming that an account was verified
+func (r *UserRepository) welcomeEmail(username, email string) (mail.EmailMessage, error) {
 	loginURL := r.verifyBaseURL
 	if !strings.HasSuffix(loginURL, "/") {
 		loginURL += "/"
@@ -66,19 +66,123 @@ func (r *UserRepository) SendPostVerificationEmail(ctx context.Context, username
 		SupportEmail: "support@careerlink.com",
 	}
 
-	// Generate HTML from template
 	htmlContent, err := templates.GetPostVerificationHTML(data)
 	if err != nil {
-		return err
+		return mail.EmailMessage{}, err
 	}
 
-	// Send email
-	message := mail.EmailMessage{
+	return mail.EmailMessage{
 		To:      email,
 		Subject: "Welcome to Career Link - Your Account is Active",
 		Body:    htmlContent,
 		IsHTML:  true,
+	}, nil
+}
+
+// passwordResetEmail renders the email carrying a link to the frontend reset page with the password
+// reset token
+func (r *UserRepository) passwordResetEmail(username, email, token string, expiresIn time.Duration) (mail.EmailMessage, error) {
+	resetURL := fmt.Sprintf("%s/reset-password?email=%s&token=%s",
+		strings.TrimSuffix(r.frontendURL, "/"),
+		url.QueryEscape(email),
+		url.QueryEscape(token))
+
+	data := templates.PasswordResetData{
+		Username:     username,
+		ResetURL:     resetURL,
+		ExpiresIn:    formatExpiry(expiresIn),
+		CompanyName:  "Career Link",
+		SupportEmail: "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetPasswordResetHTML(data)
+	if err != nil {
+		return mail.EmailMessage{}, err
+	}
+
+	return mail.EmailMessage{
+		To:      email,
+		Subject: "Reset Your Career Link Password",
+		Body:    htmlContent,
+		IsHTML:  true,
+	}, nil
+}
+
+// companyVerificationResultEmail renders the email telling a company whether its verification request
+// was approved
+func (r *UserRepository) companyVerificationResultEmail(companyName, email string, approved bool, reason string) (mail.EmailMessage, error) {
+	data := templates.CompanyVerificationData{
+		CompanyName:  companyName,
+		Approved:     approved,
+		Reason:       reason,
+		DashboardURL: strings.TrimSuffix(r.frontendURL, "/") + "/company",
+		AppName:      "Career Link",
+		SupportEmail: "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetCompanyVerificationHTML(data)
+	if err != nil {
+		return mail.EmailMessage{}, err
+	}
+
+	subject := "Your Company Has Been Verified on Career Link"
+	if !approved {
+		subject = "Your Career Link Company Verification Request"
+	}
+
+	return mail.EmailMessage{
+		To:      email,
+		Subject: subject,
+		Body:    htmlContent,
+		IsHTML:  true,
+	}, nil
+}
+
+// companyInvitationEmail renders the email carrying a link to the frontend invitation page with the
+// invitation token
+func (r *UserRepository) companyInvitationEmail(companyName, inviterName, email, token string, role entity.CompanyMemberRole, expiresIn time.Duration) (mail.EmailMessage, error) {
+	invitationURL := fmt.Sprintf("%s/company-invitation?email=%s&token=%s",
+		strings.TrimSuffix(r.frontendURL, "/"),
+		url.QueryEscape(email),
+		url.QueryEscape(token))
+
+	data := templates.CompanyInvitationData{
+		CompanyName:   companyName,
+		InviterName:   inviterName,
+		Role:          string(role),
+		InvitationURL: invitationURL,
+		ExpiresIn:     formatExpiry(expiresIn),
+		AppName:       "Career Link",
+		SupportEmail:  "support@careerlink.com",
+	}
+
+	htmlContent, err := templates.GetCompanyInvitationHTML(data)
+	if err != nil {
+		return mail.EmailMessage{}, err
 	}
 
-	return r.mail.SendEmail(ctx, message)
+	return mail.EmailMessage{
+		To:      email,
+		Subject: fmt.Sprintf("Join %s on Career Link", companyName),
+		Body:    htmlContent,
+		IsHTML:  true,
+	}, nil
+}
+
+// formatExpiry renders a token lifetime the way it reads in an email, e.g. "7 days", "1 hour" or "30 minutes"
+func formatExpiry(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		if days := int(d / day); days > 1 {
+			return fmt.Sprintf("%d days", days)
+		}
+		return "1 day"
+	}
+	if d >= time.Hour && d%time.Hour == 0 {
+		if hours := int(d / time.Hour); hours > 1 {
+			return fmt.Sprintf("%d hours", hours)
+		}
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d minutes", int(d/time.Minute))
 }
@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"time"
+)
+
+// MFAChallenge is the pending login stored between a valid password and a valid second factor
+type MFAChallenge struct {
+	UserID    uuid.UUID `json:"user_id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+}
+
+// GetUserMFA retrieves the second factor settings of a user
+func (r *UserRepository) GetUserMFA(ctx context.Context, userID uuid.UUID) (*entity.UserMFA, error) {
+	const query = `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	var m entity.UserMFA
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&m.UserID,
+		&m.Secret,
+		&m.EnabledAt,
+		&m.LastUsedStep,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMFANotEnrolled
+		}
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to get user MFA")
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// SaveMFASecret starts or restarts a pending enrollment. An enabled second factor is never overwritten.
+func (r *UserRepository) SaveMFASecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	const query = `
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0
+		WHERE user_mfa.enabled_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to save MFA secret")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// EnableMFA confirms a pending enrollment and replaces the user's recovery codes
+func (r *UserRepository) EnableMFA(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const query = `
+		UPDATE user_mfa
+		SET enabled_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to enable MFA")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMFAAlreadyEnabled
+	}
+
+	if err := replaceRecoveryCodesTx(ctx, tx, userID, codeHashes); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to store recovery codes")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// DisableMFA removes the user's second factor and recovery codes. Sessions that passed the second
+// factor lose that status, so tokens they refresh no longer claim it.
+func (r *UserRepository) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to delete recovery codes")
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to delete user MFA")
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE user_sessions SET mfa_verified = FALSE WHERE user_id = $1`, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to clear session MFA status")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeMFAStep records the time step of an accepted code. It returns ErrInvalidMFACode when
+// the step is not newer than the last accepted one, so a code cannot be replayed.
+func (r *UserRepository) ConsumeMFAStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	const query = `UPDATE user_mfa SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	result, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to record MFA step")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes discards the user's recovery codes and stores a new set
+func (r *UserRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := replaceRecoveryCodesTx(ctx, tx, userID, codeHashes); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to replace recovery codes")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used, returning ErrInvalidMFACode if there is none
+func (r *UserRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	const query = `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to use recovery code")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes the user has left
+func (r *UserRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	const query = `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to count recovery codes")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// StoreMFAChallenge keeps a pending login until the second factor is provided
+func (r *UserRepository) StoreMFAChallenge(ctx context.Context, challengeToken string, challenge MFAChallenge, ttl time.Duration) error {
+	challengeJSON, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("mfa_challenge:%s", challengeToken)
+	if err := r.redis.Set(ctx, key, string(challengeJSON), ttl); err != nil {
+		r.log.WithError(err).Error("Failed to store MFA challenge")
+		return err
+	}
+
+	return nil
+}
+
+// GetMFAChallenge retrieves a pending login and counts the attempt against it
+func (r *UserRepository) GetMFAChallenge(ctx context.Context, challengeToken string, ttl time.Duration) (*MFAChallenge, int64, error) {
+	key := fmt.Sprintf("mfa_challenge:%s", challengeToken)
+	challengeJSON, err := r.redis.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, 0, ErrInvalidToken
+		}
+		r.log.WithError(err).Error("Failed to get MFA challenge")
+		return nil, 0, err
+	}
+
+	attempts, err := r.redis.Incr(ctx, fmt.Sprintf("mfa_challenge_attempts:%s", challengeToken), ttl)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to count MFA challenge attempt")
+		return nil, 0, err
+	}
+
+	var challenge MFAChallenge
+	if err := json.Unmarshal([]byte(challengeJSON), &challenge); err != nil {
+		return nil, 0, err
+	}
+
+	return &challenge, attempts, nil
+}
+
+// DeleteMFAChallenge removes a pending login once it is completed or abandoned
+func (r *UserRepository) DeleteMFAChallenge(ctx context.Context, challengeToken string) error {
+	return r.redis.Delete(ctx,
+		fmt.Sprintf("mfa_challenge:%s", challengeToken),
+		fmt.Sprintf("mfa_challenge_attempts:%s", challengeToken),
+	)
+}
+
+// CountMFAAttempt counts an attempt at the user's second factor and returns how many were made in
+// the current window, which starts with the first attempt and lasts window
+func (r *UserRepository) CountMFAAttempt(ctx context.Context, userID uuid.UUID, window time.Duration) (int64, error) {
+	attempts, err := r.redis.Incr(ctx, fmt.Sprintf("mfa_attempts:%s", userID), window)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to count MFA attempt")
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// ResetMFAAttempts forgets the user's attempts at the second factor once one succeeded
+func (r *UserRepository) ResetMFAAttempts(ctx context.Context, userID uuid.UUID) error {
+	return r.redis.Delete(ctx, fmt.Sprintf("mfa_attempts:%s", userID))
+}
+
+// replaceRecoveryCodesTx deletes the user's recovery codes and inserts the given hashes
+func replaceRecoveryCodesTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`
+	for _, hash := range codeHashes {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, id, userID, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/cache"
 	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/pkg/token"
 	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -88,23 +92,78 @@ func (r *UserRepository) GetUserSession(ctx context.Context, sessionID string) (
 
 // DeleteUserSession removes a user session from Redis
 func (r *UserRepository) DeleteUserSession(ctx context.Context, userIDKey string) error {
-	// Delete based on the user ID key directly
-	if err := r.redis.Del(ctx, userIDKey); err != nil {
-		r.log.Error("Failed to delete user session")
-		return errors.New("failed to delete user session")
-	}
-
 	// Also delete admin session if it exists
 	userID := strings.TrimPrefix(userIDKey, "session:")
 	adminSessionKey := fmt.Sprintf("admin:%s", userID)
-	if err := r.redis.Del(ctx, adminSessionKey); err != nil {
-		r.log.Error("Failed to delete admin session")
-		return errors.New("failed to delete admin session")
+
+	if err := r.redis.Delete(ctx, userIDKey, adminSessionKey); err != nil {
+		r.log.WithError(err).Error("Failed to delete user session")
+		return errors.New("failed to delete user session")
+	}
+
+	return nil
+}
+
+// RevokeAccessToken puts a single access token on the denylist until it would have expired anyway
+func (r *UserRepository) RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf("revoked_jti:%s", tokenID)
+	if err := r.redis.Set(ctx, key, "1", ttl); err != nil {
+		r.log.WithError(err).WithField("token_id", tokenID).Error("Failed to revoke access token")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeUserAccessTokens rejects every access token of the user issued up to now, to the millisecond.
+// The cutoff only has to live as long as the longest access token lifetime.
+func (r *UserRepository) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, ttl time.Duration) error {
+	key := fmt.Sprintf("revoked_before:%s", userID)
+	if err := r.redis.Set(ctx, key, time.Now().UnixMilli(), ttl); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke user access tokens")
+		return err
 	}
 
 	return nil
 }
 
+// IsAccessTokenRevoked reports whether the token is on the denylist, belongs to a revoked
+// session or was issued no later than the user's tokens were last revoked
+func (r *UserRepository) IsAccessTokenRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	for _, key := range []string{
+		fmt.Sprintf("revoked_jti:%s", payload.ID),
+		fmt.Sprintf("revoked_session:%s", payload.SessionID),
+	} {
+		denied, err := r.redis.Exists(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		if denied {
+			return true, nil
+		}
+	}
+
+	cutoff, err := r.redis.Get(ctx, fmt.Sprintf("revoked_before:%s", payload.UserID))
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	revokedAt, err := strconv.ParseInt(cutoff, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	return !payload.IssuedAt.After(time.UnixMilli(revokedAt)), nil
+}
+
 // GetUserSessionByID retrieves user data directly by userID
 func (r *UserRepository) GetUserSessionByID(ctx context.Context, userID string) (*entity.User, error) {
 	userSessionKey := fmt.Sprintf("session:%s", userID)
@@ -136,3 +195,13 @@ func (r *UserRepository) GetAdminSessionByID(ctx context.Context, userID string)
 
 	return &admin, nil
 }
+
+// InvalidateJobRecommendations drops the cached job recommendations of a job seeker
+func (r *UserRepository) InvalidateJobRecommendations(ctx context.Context, jobSeekerID uuid.UUID) error {
+	return r.redis.Delete(ctx, cache.JobRecommendationsKey(jobSeekerID))
+}
+
+// InvalidateAllJobRecommendations marks every cached job recommendation list as stale
+func (r *UserRepository) InvalidateAllJobRecommendations(ctx context.Context) error {
+	return r.redis.GetClient().Incr(ctx, cache.JobRecommendationsVersionKey).Err()
+}
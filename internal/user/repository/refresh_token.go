@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+)
+
+// GetRefreshTokenByHash retrieves a refresh token by the hash of its opaque value
+func (r *UserRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	const query = `
+		SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var t entity.RefreshToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.FamilyID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.RevokedAt,
+		&t.ReplacedBy,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTokenNotFound
+		}
+		r.log.WithError(err).Error("Failed to get refresh token")
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// RotateRefreshToken revokes the current token, stores its replacement and extends the session in one transaction.
+// It returns ErrRefreshTokenReused when the current token has already been revoked, which
+// also covers two requests racing to rotate the same token.
+func (r *UserRepository) RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next *entity.RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const insertQuery = `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+	err = tx.QueryRowContext(ctx, insertQuery,
+		next.ID,
+		next.UserID,
+		next.FamilyID,
+		next.TokenHash,
+		next.ExpiresAt,
+	).Scan(&next.CreatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", next.UserID).Error("Failed to create refresh token")
+		return err
+	}
+
+	const revokeQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(), replaced_by = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, revokeQuery, next.ID, currentID)
+	if err != nil {
+		r.log.WithError(err).WithField("token_id", currentID).Error("Failed to revoke refresh token")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRefreshTokenReused
+	}
+
+	const sessionQuery = `
+		UPDATE user_sessions
+		SET expires_at = $1, last_seen_at = NOW()
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, sessionQuery, next.ExpiresAt, next.FamilyID); err != nil {
+		r.log.WithError(err).WithField("session_id", next.FamilyID).Error("Failed to extend session")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokenFamily revokes every still active token descended from the same login
+// and ends the session that owns them
+func (r *UserRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := revokeSessionTx(ctx, tx, familyID); err != nil {
+		r.log.WithError(err).WithField("family_id", familyID).Error("Failed to revoke refresh token family")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
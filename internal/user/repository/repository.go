@@ -10,24 +10,45 @@ import (
 	"github.com/agpprastyo/career-link/pkg/mail"
 	"github.com/agpprastyo/career-link/pkg/minio"
 	"github.com/agpprastyo/career-link/pkg/redis"
+	"github.com/agpprastyo/career-link/pkg/token"
 	"github.com/google/uuid"
 	"io"
 	"time"
 )
 
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrDuplicate          = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserAlreadyExists  = errors.New("users already exists")
-	ErrInvalidToken       = errors.New("invalid token")
-	ErrTokenExpired       = errors.New("token expired")
-	ErrUserNotActive      = errors.New("users not active")
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrTokenAlreadyUsed   = errors.New("token already used")
-	ErrTokenNotFound      = errors.New("token not found")
-	ErrDatabaseConnection = errors.New("database connection error")
-	ErrUserAlreadyActive  = errors.New("user already active")
+	ErrUserNotFound                = errors.New("user not found")
+	ErrDuplicate                   = errors.New("user already exists")
+	ErrInvalidCredentials          = errors.New("invalid credentials")
+	ErrUserAlreadyExists           = errors.New("users already exists")
+	ErrInvalidToken                = errors.New("invalid token")
+	ErrTokenExpired                = errors.New("token expired")
+	ErrUserNotActive               = errors.New("users not active")
+	ErrInvalidInput                = errors.New("invalid input")
+	ErrTokenAlreadyUsed            = errors.New("token already used")
+	ErrTokenNotFound               = errors.New("token not found")
+	ErrDatabaseConnection          = errors.New("database connection error")
+	ErrUserAlreadyActive           = errors.New("user already active")
+	ErrRefreshTokenReused          = errors.New("refresh token reused")
+	ErrSessionNotFound             = errors.New("session not found")
+	ErrMFANotEnrolled              = errors.New("two-factor authentication not enrolled")
+	ErrMFAAlreadyEnabled           = errors.New("two-factor authentication already enabled")
+	ErrInvalidMFACode              = errors.New("invalid two-factor authentication code")
+	ErrMFARequired                 = errors.New("two-factor authentication required")
+	ErrMFARequiredForRole          = errors.New("two-factor authentication cannot be disabled for this role")
+	ErrMFALocked                   = errors.New("too many two-factor authentication attempts")
+	ErrJobSeekerNotFound           = errors.New("job seeker not found")
+	ErrProfileItemNotFound         = errors.New("profile item not found")
+	ErrCompanyNotFound             = errors.New("company not found")
+	ErrCompanyAlreadyVerified      = errors.New("company already verified")
+	ErrVerificationPending         = errors.New("verification request already pending")
+	ErrVerificationNotFound        = errors.New("verification request not found")
+	ErrVerificationAlreadyReviewed = errors.New("verification request already reviewed")
+	ErrCompanyMemberNotFound       = errors.New("company member not found")
+	ErrAlreadyCompanyMember        = errors.New("user already belongs to a company")
+	ErrLastCompanyOwner            = errors.New("company must keep at least one owner")
+	ErrInvitationNotFound          = errors.New("company invitation not found")
+	ErrInviteeNotEligible          = errors.New("account cannot join a company")
 )
 
 // UserRepository implements user repository using PostgreSQL
@@ -38,6 +59,7 @@ type UserRepository struct {
 	log           *logger.Logger
 	minio         *minio.Client
 	verifyBaseURL string
+	frontendURL   string
 }
 
 // Repository defines the interface for users repository operations
@@ -46,6 +68,8 @@ type Repository interface {
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	CreateUser(ctx context.Context, users *entity.User) error
+	CreateJobSeekerUser(ctx context.Context, usr *entity.User, seeker *entity.JobSeeker, verification *entity.VerificationToken) error
+	CreateCompanyUser(ctx context.Context, usr *entity.User, company *entity.Company, verification *entity.VerificationToken) error
 	CreateToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID, token string, tokenType entity.TokenType, expiry time.Time) error
 	GetToken(ctx context.Context, token string) (*entity.VerificationToken, error)
 	UpdateUser(ctx context.Context, users *entity.User) error
@@ -54,8 +78,62 @@ type Repository interface {
 
 	ActivateUser(ctx context.Context, userID uuid.UUID) error
 
-	SendVerificationEmail(ctx context.Context, username, email, token string) error
-	SendPostVerificationEmail(ctx context.Context, username, email string) error
+	CreateVerificationToken(ctx context.Context, usr *entity.User, token *entity.VerificationToken) error
+	CreatePasswordResetToken(ctx context.Context, usr *entity.User, token string, record *entity.VerificationToken, expiresIn time.Duration) error
+	ActivateUserWithToken(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error
+	ResetPassword(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID, hashedPassword string) error
+
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next *entity.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error
+
+	CreateSession(ctx context.Context, session *entity.Session, token *entity.RefreshToken) error
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error)
+	MarkSessionMFAVerified(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) error
+	DenySessionAccessTokens(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error
+	TouchSession(ctx context.Context, sessionID string) error
+
+	GetUserMFA(ctx context.Context, userID uuid.UUID) (*entity.UserMFA, error)
+	SaveMFASecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableMFA(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+	DisableMFA(ctx context.Context, userID uuid.UUID) error
+	ConsumeMFAStep(ctx context.Context, userID uuid.UUID, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	StoreMFAChallenge(ctx context.Context, challengeToken string, challenge MFAChallenge, ttl time.Duration) error
+	GetMFAChallenge(ctx context.Context, challengeToken string, ttl time.Duration) (*MFAChallenge, int64, error)
+	DeleteMFAChallenge(ctx context.Context, challengeToken string) error
+	CountMFAAttempt(ctx context.Context, userID uuid.UUID, window time.Duration) (int64, error)
+	ResetMFAAttempts(ctx context.Context, userID uuid.UUID) error
+
+	GetJobSeekerByUserID(ctx context.Context, userID uuid.UUID) (*entity.JobSeeker, error)
+	UpdateJobSeeker(ctx context.Context, seeker *entity.JobSeeker) error
+	UpdateJobSeekerVisibility(ctx context.Context, jobSeekerID uuid.UUID, visibility entity.ProfileVisibility) error
+	SearchCandidates(ctx context.Context, companyID uuid.UUID, filter entity.CandidateSearchFilter, paging pagination.Pagination) ([]entity.Candidate, int, error)
+	ListJobSeekerSkills(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerSkill, error)
+	CreateJobSeekerSkill(ctx context.Context, item *entity.JobSeekerSkill) error
+	UpdateJobSeekerSkill(ctx context.Context, item *entity.JobSeekerSkill) error
+	ListJobSeekerExperiences(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerExperience, error)
+	CreateJobSeekerExperience(ctx context.Context, item *entity.JobSeekerExperience) error
+	UpdateJobSeekerExperience(ctx context.Context, item *entity.JobSeekerExperience) error
+	ListJobSeekerEducations(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerEducation, error)
+	CreateJobSeekerEducation(ctx context.Context, item *entity.JobSeekerEducation) error
+	UpdateJobSeekerEducation(ctx context.Context, item *entity.JobSeekerEducation) error
+	ListJobSeekerProjects(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerProject, error)
+	CreateJobSeekerProject(ctx context.Context, item *entity.JobSeekerProject) error
+	UpdateJobSeekerProject(ctx context.Context, item *entity.JobSeekerProject) error
+	ListJobSeekerLinks(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerLink, error)
+	CreateJobSeekerLink(ctx context.Context, item *entity.JobSeekerLink) error
+	UpdateJobSeekerLink(ctx context.Context, item *entity.JobSeekerLink) error
+	ListJobSeekerPreferences(ctx context.Context, jobSeekerID uuid.UUID) ([]entity.JobSeekerPreference, error)
+	CreateJobSeekerPreference(ctx context.Context, item *entity.JobSeekerPreference) error
+	UpdateJobSeekerPreference(ctx context.Context, item *entity.JobSeekerPreference) error
+	DeleteJobSeekerSectionItem(ctx context.Context, section entity.ProfileSection, jobSeekerID, id uuid.UUID) error
+	ReorderJobSeekerSection(ctx context.Context, section entity.ProfileSection, jobSeekerID uuid.UUID, ids []uuid.UUID) error
 
 	UploadAvatarFile(ctx context.Context, fileName string, fileContent io.Reader, fileSize int64, contentType string) (avatarURL string, err error)
 
@@ -71,19 +149,50 @@ type Repository interface {
 	SoftDeleteAdmin(ctx context.Context, userID uuid.UUID) error
 
 	GetCompanyByID(ctx context.Context, id uuid.UUID) (*entity.Company, error)
+	GetCompanyByUserID(ctx context.Context, userID uuid.UUID) (*entity.Company, error)
+	UpdateCompany(ctx context.Context, company *entity.Company) error
+	UpdateCompanyLogo(ctx context.Context, companyID uuid.UUID, logo *string) error
+	UploadCompanyLogoFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error
+	DeleteCompanyLogoFile(ctx context.Context, objectName string) error
+	GetCompanyLogoURL(ctx context.Context, objectName string) (string, error)
+
+	GetCompanyMembership(ctx context.Context, userID uuid.UUID) (*entity.CompanyMember, error)
+	ListCompanyMembers(ctx context.Context, companyID uuid.UUID) ([]entity.CompanyMemberDetail, error)
+	UpdateCompanyMemberRole(ctx context.Context, companyID, userID uuid.UUID, role entity.CompanyMemberRole) error
+	RemoveCompanyMember(ctx context.Context, companyID, userID uuid.UUID) error
+	CreateCompanyInvitation(ctx context.Context, inv *entity.CompanyInvitation, token string, record *entity.VerificationToken, companyName, inviterName string, expiresIn time.Duration) error
+	ListCompanyInvitations(ctx context.Context, companyID uuid.UUID) ([]entity.CompanyInvitation, error)
+	RevokeCompanyInvitation(ctx context.Context, companyID, id uuid.UUID) error
+	GetCompanyInvitationByToken(ctx context.Context, token string) (*entity.CompanyInvitation, error)
+	AcceptCompanyInvitation(ctx context.Context, inv *entity.CompanyInvitation, usr *entity.User, newUser bool) error
+
+	CreateVerificationRequest(ctx context.Context, req *entity.CompanyVerificationRequest) error
+	GetLatestVerificationRequest(ctx context.Context, companyID uuid.UUID) (*entity.CompanyVerificationRequest, error)
+	GetVerificationRequestByID(ctx context.Context, id uuid.UUID) (*entity.CompanyVerificationRequest, error)
+	ListVerificationRequests(ctx context.Context, status *entity.VerificationStatus, paging pagination.Pagination) ([]entity.CompanyVerificationSummary, int, error)
+	ReviewVerificationRequest(ctx context.Context, id uuid.UUID, status entity.VerificationStatus, reviewerID uuid.UUID, reason *string) (*entity.CompanyVerificationRequest, error)
+	UploadVerificationDocumentFile(ctx context.Context, objectName string, fileContent io.Reader, fileSize int64, contentType string) error
+	DeleteVerificationDocumentFile(ctx context.Context, objectName string) error
+	GetVerificationDocumentURL(ctx context.Context, objectName string) (string, error)
 	StoreUserSession(ctx context.Context, userIDStr string, sessionID string, user *entity.User) error
 
 	GetAdminSessionByID(ctx context.Context, userID string) (*entity.Admin, error)
 	GetUserSessionByID(ctx context.Context, userID string) (*entity.User, error)
 	DeleteUserSession(ctx context.Context, sessionID string) error
+	RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, ttl time.Duration) error
+	IsAccessTokenRevoked(ctx context.Context, payload *token.Payload) (bool, error)
+	InvalidateJobRecommendations(ctx context.Context, jobSeekerID uuid.UUID) error
+	InvalidateAllJobRecommendations(ctx context.Context) error
 }
 
 // NewUserRepository creates new UserRepository
-func NewUserRepository(db *database.PostgresDB, log *logger.Logger, client *mail.Client, minio *minio.Client, verifyBaseURL string, redis *redis.Client) *UserRepository {
+func NewUserRepository(db *database.PostgresDB, log *logger.Logger, client *mail.Client, minio *minio.Client, verifyBaseURL, frontendURL string, redis *redis.Client) *UserRepository {
 	return &UserRepository{
 		redis:         redis,
 		mail:          client,
 		verifyBaseURL: verifyBaseURL,
+		frontendURL:   frontendURL,
 		db:            db,
 		minio:         minio,
 		log:           log,
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+	"time"
+)
+
+const sessionColumns = `id, user_id, user_agent, ip_address, mfa_verified, created_at, last_seen_at, expires_at, revoked_at`
+
+// sessionSeenInterval throttles how often a session's last seen time is written to the database
+const sessionSeenInterval = time.Minute
+
+// CreateSession stores a new device session together with its first refresh token
+func (r *UserRepository) CreateSession(ctx context.Context, session *entity.Session, token *entity.RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	const sessionQuery = `
+		INSERT INTO user_sessions (id, user_id, user_agent, ip_address, mfa_verified, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, last_seen_at
+	`
+	err = tx.QueryRowContext(ctx, sessionQuery,
+		session.ID,
+		session.UserID,
+		session.UserAgent,
+		session.IPAddress,
+		session.MFAVerified,
+		session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", session.UserID).Error("Failed to create session")
+		return err
+	}
+
+	const tokenQuery = `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+	err = tx.QueryRowContext(ctx, tokenQuery,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", token.UserID).Error("Failed to create refresh token")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ListActiveSessions retrieves the user's sessions that are neither revoked nor expired, most recently used first
+func (r *UserRepository) ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error) {
+	sessions := []entity.Session{}
+	query := `SELECT ` + sessionColumns + `
+              FROM user_sessions
+              WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+              ORDER BY last_seen_at DESC`
+	if err := r.db.SelectContext(ctx, &sessions, query, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to list sessions")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetSessionByID retrieves a session by its ID
+func (r *UserRepository) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM user_sessions WHERE id = $1`
+
+	var s entity.Session
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&s.ID,
+		&s.UserID,
+		&s.UserAgent,
+		&s.IPAddress,
+		&s.MFAVerified,
+		&s.CreatedAt,
+		&s.LastSeenAt,
+		&s.ExpiresAt,
+		&s.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to get session")
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// MarkSessionMFAVerified records that a session was confirmed with a second factor
+func (r *UserRepository) MarkSessionMFAVerified(ctx context.Context, userID, sessionID uuid.UUID) error {
+	const query = `UPDATE user_sessions SET mfa_verified = TRUE WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to mark session MFA verified")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeSession ends one of the user's sessions and revokes its refresh tokens
+func (r *UserRepository) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	// Make sure the session belongs to the user before touching it
+	const ownerQuery = `SELECT 1 FROM user_sessions WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+	var exists int
+	if err := tx.QueryRowContext(ctx, ownerQuery, sessionID, userID).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to get session")
+		return err
+	}
+
+	if err := revokeSessionTx(ctx, tx, sessionID); err != nil {
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to revoke session")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeUserSessions ends every session of the user and revokes all of their refresh tokens
+func (r *UserRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	if err := revokeUserSessionsTx(ctx, tx, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke user sessions")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// DenySessionAccessTokens rejects access tokens already issued to a revoked session until they would have expired
+func (r *UserRepository) DenySessionAccessTokens(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	key := fmt.Sprintf("revoked_session:%s", sessionID)
+	if err := r.redis.Set(ctx, key, "1", ttl); err != nil {
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to deny session access tokens")
+		return err
+	}
+
+	return nil
+}
+
+// TouchSession records activity on a session, writing to the database at most once per sessionSeenInterval
+func (r *UserRepository) TouchSession(ctx context.Context, sessionID string) error {
+	key := fmt.Sprintf("session_seen:%s", sessionID)
+	seen, err := r.redis.Exists(ctx, key)
+	if err != nil || seen {
+		return err
+	}
+
+	if err := r.redis.Set(ctx, key, "1", sessionSeenInterval); err != nil {
+		return err
+	}
+
+	const query = `UPDATE user_sessions SET last_seen_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, sessionID); err != nil {
+		r.log.WithError(err).WithField("session_id", sessionID).Error("Failed to update session last seen")
+		return err
+	}
+
+	return nil
+}
+
+// revokeSessionTx marks a session revoked and revokes its refresh tokens
+func revokeSessionTx(ctx context.Context, tx *sql.Tx, sessionID uuid.UUID) error {
+	const sessionQuery = `UPDATE user_sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, sessionQuery, sessionID); err != nil {
+		return err
+	}
+
+	const tokenQuery = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err := tx.ExecContext(ctx, tokenQuery, sessionID)
+	return err
+}
+
+// revokeUserSessionsTx marks every session of a user revoked and revokes all of their refresh tokens
+func revokeUserSessionsTx(ctx context.Context, tx *sql.Tx, userID uuid.UUID) error {
+	const sessionQuery = `UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err := tx.ExecContext(ctx, sessionQuery, userID); err != nil {
+		return err
+	}
+
+	const tokenQuery = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	_, err := tx.ExecContext(ctx, tokenQuery, userID)
+	return err
+}
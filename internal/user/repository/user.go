@@ -144,6 +144,9 @@ func (r *UserRepository) CreateUser(ctx context.Context, usr *entity.User) error
 
 	_, err := r.db.ExecContext(ctx, query, usr.ID, usr.Username, usr.Email, usr.Password, usr.Role, usr.Avatar)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrUserAlreadyExists
+		}
 		r.log.WithError(err).WithField("user", usr).Error("Failed to create user")
 		return err
 	}
@@ -151,6 +154,20 @@ func (r *UserRepository) CreateUser(ctx context.Context, usr *entity.User) error
 	return nil
 }
 
+// insertUserTx inserts a user row inside an existing transaction. A username or email taken by a
+// concurrent registration is reported as ErrUserAlreadyExists.
+func insertUserTx(ctx context.Context, tx *sql.Tx, usr *entity.User) error {
+	const query = `
+		INSERT INTO users (id, username, email, password, role, avatar)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := tx.ExecContext(ctx, query, usr.ID, usr.Username, usr.Email, usr.Password, usr.Role, usr.Avatar)
+	if isUniqueViolation(err) {
+		return ErrUserAlreadyExists
+	}
+	return err
+}
+
 // UpdateUser updates a user
 func (r *UserRepository) UpdateUser(ctx context.Context, usr *entity.User) error {
 	const query = `
@@ -240,6 +257,9 @@ func (r *UserRepository) GetToken(ctx context.Context, token string) (*entity.Ve
 		&t.UsedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTokenNotFound
+		}
 		r.log.WithError(err).WithField("token", token).Error("Failed to get token")
 		return nil, err
 	}
@@ -297,3 +317,77 @@ func (r *UserRepository) DeleteExpiredTokens(ctx context.Context, before time.Ti
 
 	return nil
 }
+
+// ResetPassword sets a new password hash and consumes the password reset token in one transaction.
+// Every other outstanding reset token of the user is consumed too, so older links stop working.
+func (r *UserRepository) ResetPassword(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID, hashedPassword string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.WithError(err).Error("Failed to rollback transaction")
+		}
+	}(tx)
+
+	// Consume the token first so two concurrent requests cannot both use it
+	const consumeQuery = `
+		UPDATE verification_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND type = $3 AND used_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, consumeQuery, tokenID, userID, entity.PasswordReset)
+	if err != nil {
+		r.log.WithError(err).WithField("token_id", tokenID).Error("Failed to consume password reset token")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+
+	const passwordQuery = `UPDATE users SET password = $1 WHERE id = $2`
+	result, err = tx.ExecContext(ctx, passwordQuery, hashedPassword, userID)
+	if err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to update password")
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	const revokeQuery = `
+		UPDATE verification_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND type = $2 AND used_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, revokeQuery, userID, entity.PasswordReset); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke other password reset tokens")
+		return err
+	}
+
+	// Sessions opened before the reset must not outlive the old password
+	if err := revokeUserSessionsTx(ctx, tx, userID); err != nil {
+		r.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke user sessions")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
@@ -75,8 +75,24 @@ func (uc *UserUseCase) CreateAdmin(ctx context.Context, req dto.CreateAdminReque
 
 // ToggleAdminStatus toggles the admin status of a users
 func (uc *UserUseCase) ToggleAdminStatus(ctx context.Context, userID uuid.UUID) (bool, error) {
+	admin, err := uc.repo.GetAdminByUserID(ctx, userID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to get admin data")
+		return false, err
+	}
+
+	if admin == nil {
+		return false, repository.ErrUserNotFound
+	}
 
-	// Update user in the database
+	// A deactivated admin must be signed out at once. The tokens are revoked before the status
+	// flips, so a failed revocation leaves the admin unchanged and the call can simply be retried.
+	if admin.IsActive {
+		if err := uc.revokeUserTokens(ctx, userID); err != nil {
+			uc.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke tokens of deactivated admin")
+			return false, err
+		}
+	}
 
 	adminStatus, err := uc.repo.UpdateAdminStatus(ctx, userID)
 	if err != nil {
@@ -105,6 +121,12 @@ func (uc *UserUseCase) SoftDeleteAdmin(ctx context.Context, userID uuid.UUID) er
 		return errors.New("cannot delete super admin accounts")
 	}
 
+	// Revoke first, so a failed revocation never leaves a deleted admin with working tokens
+	if err := uc.revokeUserTokens(ctx, userID); err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke tokens of deleted admin")
+		return err
+	}
+
 	err = uc.repo.SoftDeleteAdmin(ctx, userID)
 	if err != nil {
 		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to soft delete admin")
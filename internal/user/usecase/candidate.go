@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/google/uuid"
+)
+
+// SearchCandidates searches the job seekers visible to the user's company
+func (uc *UserUseCase) SearchCandidates(ctx context.Context, userID uuid.UUID, filter entity.CandidateSearchFilter, paging pagination.Pagination) (pagination.PageResponse, error) {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	candidates, total, err := uc.repo.SearchCandidates(ctx, member.CompanyID, filter, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(candidates, paging, total), nil
+}
@@ -1,17 +1,151 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/dto"
 	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
 	"github.com/google/uuid"
+	"strings"
 )
 
-// GetCompany returns a company by ID
-func (uc *UserUseCase) GetCompany(ctx context.Context, id uuid.UUID) (*entity.Company, error) {
-	company, err := uc.repo.GetCompanyByID(ctx, id)
+// GetCompany returns the company owned by the user, including its address
+func (uc *UserUseCase) GetCompany(ctx context.Context, userID uuid.UUID) (*entity.Company, error) {
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := uc.resolveCompanyLogoURL(ctx, company); err != nil {
+		return nil, err
+	}
+
 	return company, nil
 }
+
+// UpdateCompany updates the profile, size and address of the company owned by the user
+func (uc *UserUseCase) UpdateCompany(ctx context.Context, userID uuid.UUID, req dto.UpdateCompanyRequest) (*entity.Company, error) {
+	if req.Address == nil {
+		return nil, repository.ErrInvalidInput
+	}
+
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousIndustry := company.Industry
+
+	company.Name = strings.TrimSpace(req.Name)
+	company.Description = optionalString(req.Description)
+	company.Industry = optionalString(req.Industry)
+	company.Website = optionalString(req.Website)
+	company.Phone = optionalString(req.Phone)
+	company.Size = entity.CompanySizeRange(req.Size)
+	if email := strings.TrimSpace(req.Email); email != "" {
+		company.Email = email
+	}
+
+	company.Address.AddressLine1 = strings.TrimSpace(req.Address.AddressLine1)
+	company.Address.AddressLine2 = optionalString(req.Address.AddressLine2)
+	company.Address.City = strings.TrimSpace(req.Address.City)
+	company.Address.State = strings.TrimSpace(req.Address.State)
+	company.Address.ZipCode = strings.TrimSpace(req.Address.ZipCode)
+	company.Address.Country = strings.TrimSpace(req.Address.Country)
+
+	if err := uc.repo.UpdateCompany(ctx, company); err != nil {
+		return nil, err
+	}
+
+	// Job recommendations match postings on their company's industry
+	if !equalOptionalString(previousIndustry, company.Industry) {
+		if err := uc.repo.InvalidateAllJobRecommendations(ctx); err != nil {
+			uc.log.WithError(err).Error("Failed to invalidate job recommendations")
+		}
+	}
+
+	if err := uc.resolveCompanyLogoURL(ctx, company); err != nil {
+		return nil, err
+	}
+
+	return company, nil
+}
+
+// UpdateCompanyLogo uploads a new logo for the user's company and removes the previous one
+func (uc *UserUseCase) UpdateCompanyLogo(ctx context.Context, userID uuid.UUID, req dto.UpdateCompanyLogoRequest) (logoURL string, err error) {
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	fileID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return "", err
+	}
+	objectName := fmt.Sprintf("logos/%s/%s%s", company.ID, fileID, req.FileExt)
+
+	if err := uc.repo.UploadCompanyLogoFile(ctx, objectName, bytes.NewReader(req.FileContent), req.FileSize, req.ContentType); err != nil {
+		return "", err
+	}
+
+	if err := uc.repo.UpdateCompanyLogo(ctx, company.ID, &objectName); err != nil {
+		uc.deleteCompanyLogoFile(&objectName)
+		return "", err
+	}
+
+	uc.deleteCompanyLogoFile(company.LogoURL)
+
+	return uc.repo.GetCompanyLogoURL(ctx, objectName)
+}
+
+// DeleteCompanyLogo removes the logo of the user's company
+func (uc *UserUseCase) DeleteCompanyLogo(ctx context.Context, userID uuid.UUID) error {
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if company.LogoURL == nil {
+		return nil
+	}
+
+	if err := uc.repo.UpdateCompanyLogo(ctx, company.ID, nil); err != nil {
+		return err
+	}
+
+	uc.deleteCompanyLogoFile(company.LogoURL)
+
+	return nil
+}
+
+// resolveCompanyLogoURL replaces the stored logo object name with a temporary download URL
+func (uc *UserUseCase) resolveCompanyLogoURL(ctx context.Context, company *entity.Company) error {
+	if company.LogoURL == nil {
+		return nil
+	}
+
+	url, err := uc.repo.GetCompanyLogoURL(ctx, *company.LogoURL)
+	if err != nil {
+		return err
+	}
+	company.LogoURL = &url
+
+	return nil
+}
+
+// deleteCompanyLogoFile removes a logo object from storage in the background
+func (uc *UserUseCase) deleteCompanyLogoFile(objectName *string) {
+	if objectName == nil || *objectName == "" {
+		return
+	}
+
+	name := *objectName
+	go func() {
+		if err := uc.repo.DeleteCompanyLogoFile(context.Background(), name); err != nil {
+			uc.log.WithError(err).Error("Failed to delete old company logo file")
+		}
+	}()
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// companyInvitationTTL is how long a company invitation link stays valid
+const companyInvitationTTL = 7 * 24 * time.Hour
+
+// ListCompanyMembers returns the team of the user's company
+func (uc *UserUseCase) ListCompanyMembers(ctx context.Context, userID uuid.UUID) ([]entity.CompanyMemberDetail, error) {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListCompanyMembers(ctx, member.CompanyID)
+}
+
+// UpdateCompanyMemberRole changes the role of another member of the user's company
+func (uc *UserUseCase) UpdateCompanyMemberRole(ctx context.Context, userID, memberUserID uuid.UUID, req dto.UpdateCompanyMemberRequest) error {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.UpdateCompanyMemberRole(ctx, member.CompanyID, memberUserID, entity.CompanyMemberRole(req.Role))
+}
+
+// RemoveCompanyMember removes a member from the user's company and signs them out, since their
+// tokens were issued while they acted for the company
+func (uc *UserUseCase) RemoveCompanyMember(ctx context.Context, userID, memberUserID uuid.UUID) error {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.RemoveCompanyMember(ctx, member.CompanyID, memberUserID); err != nil {
+		return err
+	}
+
+	if err := uc.revokeUserTokens(ctx, memberUserID); err != nil {
+		uc.log.WithError(err).WithField("user_id", memberUserID).Error("Failed to revoke tokens of removed company member")
+	}
+
+	return nil
+}
+
+// InviteCompanyMember emails an invitation to join the user's company
+func (uc *UserUseCase) InviteCompanyMember(ctx context.Context, userID uuid.UUID, req dto.InviteCompanyMemberRequest) (*entity.CompanyInvitation, error) {
+	inviter, err := uc.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if invitee, err := uc.repo.GetUserByEmail(ctx, email); err == nil {
+		if invitee.Role != entity.CompanyRole {
+			return nil, repository.ErrInviteeNotEligible
+		}
+		if _, err := uc.repo.GetCompanyMembership(ctx, invitee.ID); err == nil {
+			return nil, repository.ErrAlreadyCompanyMember
+		} else if !errors.Is(err, repository.ErrCompanyMemberNotFound) {
+			return nil, err
+		}
+	}
+
+	invitationID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	token, record, err := uc.newLinkToken(inviter.ID, entity.CompanyInvitationToken, companyInvitationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &entity.CompanyInvitation{
+		ID:        invitationID,
+		CompanyID: company.ID,
+		Email:     email,
+		Role:      entity.CompanyMemberRole(req.Role),
+		InvitedBy: &inviter.ID,
+		TokenID:   record.ID,
+		ExpiresAt: record.ExpiredAt,
+	}
+
+	// The invitation email is queued with the invitation and delivered by the outbox worker
+	if err := uc.repo.CreateCompanyInvitation(ctx, inv, token, record, company.Name, inviter.Username, companyInvitationTTL); err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// ListCompanyInvitations returns the open invitations of the user's company
+func (uc *UserUseCase) ListCompanyInvitations(ctx context.Context, userID uuid.UUID) ([]entity.CompanyInvitation, error) {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListCompanyInvitations(ctx, member.CompanyID)
+}
+
+// RevokeCompanyInvitation cancels an open invitation of the user's company
+func (uc *UserUseCase) RevokeCompanyInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	member, err := uc.repo.GetCompanyMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.RevokeCompanyInvitation(ctx, member.CompanyID, invitationID)
+}
+
+// AcceptCompanyInvitation adds the invited email to the company. A company account is created when
+// the email has none yet; existing company accounts confirm with their password.
+func (uc *UserUseCase) AcceptCompanyInvitation(ctx context.Context, req dto.AcceptCompanyInvitationRequest) (*entity.CompanyInvitation, error) {
+	inv, err := uc.repo.GetCompanyInvitationByToken(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return nil, repository.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if !strings.EqualFold(inv.Email, email) {
+		return nil, repository.ErrInvalidToken
+	}
+	if inv.AcceptedAt != nil || inv.RevokedAt != nil {
+		return nil, repository.ErrTokenAlreadyUsed
+	}
+	if time.Now().After(inv.ExpiresAt) {
+		return nil, repository.ErrTokenExpired
+	}
+
+	usr, err := uc.repo.GetUserByEmail(ctx, inv.Email)
+	switch {
+	case err == nil:
+		if usr.Role != entity.CompanyRole {
+			return nil, repository.ErrInviteeNotEligible
+		}
+		if err := utils.VerifyPassword(usr.Password, req.Password); err != nil {
+			return nil, repository.ErrInvalidCredentials
+		}
+		if err := uc.repo.AcceptCompanyInvitation(ctx, inv, usr, false); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, repository.ErrUserNotFound):
+		if strings.TrimSpace(req.Username) == "" {
+			return nil, repository.ErrInvalidInput
+		}
+		if _, err := uc.repo.GetUserByUsername(ctx, req.Username); err == nil {
+			return nil, repository.ErrUserAlreadyExists
+		}
+
+		hashedPassword, err := utils.HashPassword(req.Password)
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to hash password")
+			return nil, err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			return nil, err
+		}
+
+		usr = &entity.User{
+			ID:       id,
+			Username: req.Username,
+			Email:    inv.Email,
+			Password: hashedPassword,
+			Role:     entity.CompanyRole,
+		}
+		if err := uc.repo.AcceptCompanyInvitation(ctx, inv, usr, true); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	now := time.Now()
+	inv.AcceptedAt = &now
+
+	return inv, nil
+}
@@ -0,0 +1,257 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/internal/user/usecase"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// teamRepo holds the members of two companies and scopes member changes to a company like the
+// company_members queries do
+type teamRepo struct {
+	repository.Repository
+	members     map[uuid.UUID]entity.CompanyMember
+	users       map[string]entity.User // By email
+	invitations map[string]entity.CompanyInvitation
+	changed     []uuid.UUID
+	signedOut   []uuid.UUID
+	inviteToken string
+	inviteHash  string
+}
+
+func (r *teamRepo) GetCompanyMembership(ctx context.Context, userID uuid.UUID) (*entity.CompanyMember, error) {
+	member, ok := r.members[userID]
+	if !ok {
+		return nil, repository.ErrCompanyMemberNotFound
+	}
+	return &member, nil
+}
+
+func (r *teamRepo) UpdateCompanyMemberRole(ctx context.Context, companyID, userID uuid.UUID, role entity.CompanyMemberRole) error {
+	if r.members[userID].CompanyID != companyID {
+		return repository.ErrCompanyMemberNotFound
+	}
+	r.changed = append(r.changed, userID)
+	return nil
+}
+
+func (r *teamRepo) RemoveCompanyMember(ctx context.Context, companyID, userID uuid.UUID) error {
+	if r.members[userID].CompanyID != companyID {
+		return repository.ErrCompanyMemberNotFound
+	}
+	r.changed = append(r.changed, userID)
+	return nil
+}
+
+func (r *teamRepo) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, ttl time.Duration) error {
+	r.signedOut = append(r.signedOut, userID)
+	return nil
+}
+
+func (r *teamRepo) RevokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (r *teamRepo) DeleteUserSession(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (r *teamRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	for _, usr := range r.users {
+		if usr.ID == id {
+			return &usr, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (r *teamRepo) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	usr, ok := r.users[strings.ToLower(email)]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return &usr, nil
+}
+
+func (r *teamRepo) GetCompanyByUserID(ctx context.Context, userID uuid.UUID) (*entity.Company, error) {
+	return &entity.Company{ID: r.members[userID].CompanyID, Name: "Acme"}, nil
+}
+
+func (r *teamRepo) CreateCompanyInvitation(ctx context.Context, inv *entity.CompanyInvitation, token string, record *entity.VerificationToken, companyName, inviterName string, expiresIn time.Duration) error {
+	r.inviteToken = token
+	r.inviteHash = record.Token
+	return nil
+}
+
+func (r *teamRepo) GetCompanyInvitationByToken(ctx context.Context, token string) (*entity.CompanyInvitation, error) {
+	inv, ok := r.invitations[token]
+	if !ok {
+		return nil, repository.ErrInvitationNotFound
+	}
+	return &inv, nil
+}
+
+// companyTeam is an owner and a recruiter of one company, and the owner of another
+type companyTeam struct {
+	owner, recruiter, otherOwner uuid.UUID
+}
+
+func newTeamUseCase() (*usecase.UserUseCase, *teamRepo, companyTeam) {
+	companyID, otherCompanyID := uuid.New(), uuid.New()
+	team := companyTeam{owner: uuid.New(), recruiter: uuid.New(), otherOwner: uuid.New()}
+
+	repo := &teamRepo{
+		members: map[uuid.UUID]entity.CompanyMember{
+			team.owner:      {CompanyID: companyID, UserID: team.owner, Role: entity.CompanyOwner},
+			team.recruiter:  {CompanyID: companyID, UserID: team.recruiter, Role: entity.CompanyRecruiter},
+			team.otherOwner: {CompanyID: otherCompanyID, UserID: team.otherOwner, Role: entity.CompanyOwner},
+		},
+		users: map[string]entity.User{
+			"owner@acme.com":     {ID: team.owner, Email: "owner@acme.com", Role: entity.CompanyRole},
+			"recruiter@acme.com": {ID: team.recruiter, Email: "recruiter@acme.com", Role: entity.CompanyRole},
+			"owner@other.com":    {ID: team.otherOwner, Email: "owner@other.com", Role: entity.CompanyRole},
+			"seeker@mail.com":    {ID: uuid.New(), Email: "seeker@mail.com", Role: entity.JobSeekerRole},
+		},
+		invitations: map[string]entity.CompanyInvitation{},
+	}
+
+	return usecase.NewUserUseCase(repo, testutil.Logger(), nil, testJWTConfig), repo, team
+}
+
+func TestCompanyMemberChangesStayInOwnCompany(t *testing.T) {
+	ctx := context.Background()
+	owner := func(team companyTeam) uuid.UUID { return team.owner }
+	outsider := func(companyTeam) uuid.UUID { return uuid.New() }
+
+	tests := []struct {
+		name    string
+		caller  func(companyTeam) uuid.UUID
+		member  func(companyTeam) uuid.UUID
+		wantErr error
+	}{
+		{"member of the same company", owner, func(team companyTeam) uuid.UUID { return team.recruiter }, nil},
+		{"member of another company", owner, func(team companyTeam) uuid.UUID { return team.otherOwner }, repository.ErrCompanyMemberNotFound},
+		{"caller without a company", outsider, func(team companyTeam) uuid.UUID { return team.recruiter }, repository.ErrCompanyMemberNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, action := range []string{"update", "remove"} {
+				uc, repo, team := newTeamUseCase()
+				userID, memberID := tt.caller(team), tt.member(team)
+
+				var err error
+				if action == "update" {
+					err = uc.UpdateCompanyMemberRole(ctx, userID, memberID, dto.UpdateCompanyMemberRequest{Role: string(entity.CompanyViewer)})
+				} else {
+					err = uc.RemoveCompanyMember(ctx, userID, memberID)
+				}
+
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("%s: got %v, want %v", action, err, tt.wantErr)
+				}
+				if tt.wantErr != nil && (len(repo.changed) > 0 || len(repo.signedOut) > 0) {
+					t.Errorf("%s: changed %v and signed out %v", action, repo.changed, repo.signedOut)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveCompanyMemberSignsThemOut(t *testing.T) {
+	uc, repo, team := newTeamUseCase()
+
+	if err := uc.RemoveCompanyMember(context.Background(), team.owner, team.recruiter); err != nil {
+		t.Fatalf("RemoveCompanyMember: %v", err)
+	}
+
+	if len(repo.signedOut) != 1 || repo.signedOut[0] != team.recruiter {
+		t.Errorf("signed out %v, want the removed member", repo.signedOut)
+	}
+}
+
+func TestInviteCompanyMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		wantErr error
+	}{
+		{"new email", "new@acme.com", nil},
+		{"job seeker account", "seeker@mail.com", repository.ErrInviteeNotEligible},
+		{"member of another company", "owner@other.com", repository.ErrAlreadyCompanyMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, team := newTeamUseCase()
+
+			_, err := uc.InviteCompanyMember(context.Background(), team.owner, dto.InviteCompanyMemberRequest{
+				Email: tt.email,
+				Role:  string(entity.CompanyRecruiter),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			// Only the hash of the emailed token is stored
+			if repo.inviteToken == "" || repo.inviteHash != utils.HashToken(repo.inviteToken) {
+				t.Errorf("stored %q for token %q, want its hash", repo.inviteHash, repo.inviteToken)
+			}
+		})
+	}
+}
+
+func TestAcceptCompanyInvitationRejected(t *testing.T) {
+	const token = "invitation-token"
+	revokedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		token   string
+		invited string
+		email   string
+		modify  func(*entity.CompanyInvitation)
+		wantErr error
+	}{
+		{"unknown token", "other-token", "new@acme.com", "new@acme.com", nil, repository.ErrInvalidToken},
+		{"token of another email", token, "new@acme.com", "someone@acme.com", nil, repository.ErrInvalidToken},
+		{"revoked", token, "new@acme.com", "new@acme.com", func(inv *entity.CompanyInvitation) { inv.RevokedAt = &revokedAt }, repository.ErrTokenAlreadyUsed},
+		{"expired", token, "new@acme.com", "new@acme.com", func(inv *entity.CompanyInvitation) { inv.ExpiresAt = time.Now().Add(-time.Minute) }, repository.ErrTokenExpired},
+		{"invitee is a job seeker", token, "seeker@mail.com", "seeker@mail.com", nil, repository.ErrInviteeNotEligible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, _ := newTeamUseCase()
+
+			inv := entity.CompanyInvitation{ID: uuid.New(), Email: tt.invited, Role: entity.CompanyRecruiter, ExpiresAt: time.Now().Add(time.Hour)}
+			if tt.modify != nil {
+				tt.modify(&inv)
+			}
+			// Invitations are looked up by the hash of the token
+			repo.invitations[utils.HashToken(token)] = inv
+
+			_, err := uc.AcceptCompanyInvitation(context.Background(), dto.AcceptCompanyInvitationRequest{
+				Email:    tt.email,
+				Token:    tt.token,
+				Password: "password",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
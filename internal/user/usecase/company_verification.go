@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/common/pagination"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// SubmitCompanyVerification uploads the supporting documents and opens a verification request for
+// the user's company. A company can only have one pending request at a time.
+func (uc *UserUseCase) SubmitCompanyVerification(ctx context.Context, userID uuid.UUID, documents []dto.VerificationDocumentUpload) (*entity.CompanyVerificationRequest, error) {
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if company.IsVerified {
+		return nil, repository.ErrCompanyAlreadyVerified
+	}
+
+	latest, err := uc.repo.GetLatestVerificationRequest(ctx, company.ID)
+	switch {
+	case err == nil && latest.Status == entity.VerificationPending:
+		return nil, repository.ErrVerificationPending
+	case err != nil && !errors.Is(err, repository.ErrVerificationNotFound):
+		return nil, err
+	}
+
+	requestID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	req := &entity.CompanyVerificationRequest{
+		ID:        requestID,
+		CompanyID: company.ID,
+		Status:    entity.VerificationPending,
+		Documents: make([]entity.CompanyVerificationDocument, 0, len(documents)),
+	}
+
+	for _, upload := range documents {
+		documentID, err := uuid.NewV7()
+		if err != nil {
+			uc.log.WithError(err).Error("Failed to generate UUID")
+			uc.deleteVerificationDocuments(req.Documents)
+			return nil, err
+		}
+
+		doc := entity.CompanyVerificationDocument{
+			ID:          documentID,
+			RequestID:   requestID,
+			FileName:    upload.FileName,
+			ObjectName:  fmt.Sprintf("verifications/%s/%s/%s%s", company.ID, requestID, documentID, upload.FileExt),
+			ContentType: upload.ContentType,
+			SizeBytes:   int64(len(upload.FileContent)),
+		}
+
+		if err := uc.repo.UploadVerificationDocumentFile(ctx, doc.ObjectName, bytes.NewReader(upload.FileContent), doc.SizeBytes, doc.ContentType); err != nil {
+			uc.deleteVerificationDocuments(req.Documents)
+			return nil, err
+		}
+		req.Documents = append(req.Documents, doc)
+	}
+
+	if err := uc.repo.CreateVerificationRequest(ctx, req); err != nil {
+		uc.deleteVerificationDocuments(req.Documents)
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// GetCompanyVerification returns the most recent verification request of the user's company
+func (uc *UserUseCase) GetCompanyVerification(ctx context.Context, userID uuid.UUID) (*entity.CompanyVerificationRequest, error) {
+	company, err := uc.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetLatestVerificationRequest(ctx, company.ID)
+}
+
+// ListCompanyVerifications retrieves the admin review queue
+func (uc *UserUseCase) ListCompanyVerifications(ctx context.Context, status *entity.VerificationStatus, paging pagination.Pagination) (pagination.PageResponse, error) {
+	requests, total, err := uc.repo.ListVerificationRequests(ctx, status, paging)
+	if err != nil {
+		return pagination.PageResponse{}, err
+	}
+
+	return pagination.NewResponse(requests, paging, total), nil
+}
+
+// GetCompanyVerificationForReview returns a verification request with its company and temporary
+// download links for the documents
+func (uc *UserUseCase) GetCompanyVerificationForReview(ctx context.Context, id uuid.UUID) (*dto.CompanyVerificationDetailResponse, error) {
+	req, err := uc.repo.GetVerificationRequestByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := uc.repo.GetCompanyByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.resolveCompanyLogoURL(ctx, company); err != nil {
+		return nil, err
+	}
+
+	for i := range req.Documents {
+		url, err := uc.repo.GetVerificationDocumentURL(ctx, req.Documents[i].ObjectName)
+		if err != nil {
+			return nil, err
+		}
+		req.Documents[i].URL = url
+	}
+
+	return &dto.CompanyVerificationDetailResponse{
+		Request: *req,
+		Company: company,
+	}, nil
+}
+
+// ApproveCompanyVerification approves a pending request and marks the company as verified
+func (uc *UserUseCase) ApproveCompanyVerification(ctx context.Context, reviewerID, id uuid.UUID) (*entity.CompanyVerificationRequest, error) {
+	return uc.reviewCompanyVerification(ctx, reviewerID, id, entity.VerificationApproved, nil)
+}
+
+// RejectCompanyVerification rejects a pending request with a reason that is emailed to the company
+func (uc *UserUseCase) RejectCompanyVerification(ctx context.Context, reviewerID, id uuid.UUID, req dto.RejectVerificationRequest) (*entity.CompanyVerificationRequest, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	return uc.reviewCompanyVerification(ctx, reviewerID, id, entity.VerificationRejected, &reason)
+}
+
+func (uc *UserUseCase) reviewCompanyVerification(ctx context.Context, reviewerID, id uuid.UUID, status entity.VerificationStatus, reason *string) (*entity.CompanyVerificationRequest, error) {
+	// Distinguish unknown requests from ones that were already reviewed
+	if _, err := uc.repo.GetVerificationRequestByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// The result email is queued with the review and delivered by the outbox worker
+	reviewed, err := uc.repo.ReviewVerificationRequest(ctx, id, status, reviewerID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return reviewed, nil
+}
+
+// deleteVerificationDocuments removes uploaded documents of a request that could not be created
+func (uc *UserUseCase) deleteVerificationDocuments(documents []entity.CompanyVerificationDocument) {
+	if len(documents) == 0 {
+		return
+	}
+
+	go func() {
+		bgCtx := context.Background()
+		for _, doc := range documents {
+			if err := uc.repo.DeleteVerificationDocumentFile(bgCtx, doc.ObjectName); err != nil {
+				uc.log.WithError(err).Error("Failed to delete verification document")
+			}
+		}
+	}()
+}
@@ -0,0 +1,542 @@
+package usecase
+
+import (
+	"context"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// GetJobSeekerProfile returns the job seeker profile of the user together with every section
+func (uc *UserUseCase) GetJobSeekerProfile(ctx context.Context, userID uuid.UUID) (*dto.JobSeekerProfileResponse, error) {
+	seeker, err := uc.repo.GetJobSeekerByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobSeekerProfileResponse{Profile: *seeker}
+
+	if resp.Skills, err = uc.repo.ListJobSeekerSkills(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+	if resp.Experiences, err = uc.repo.ListJobSeekerExperiences(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+	if resp.Educations, err = uc.repo.ListJobSeekerEducations(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+	if resp.Projects, err = uc.repo.ListJobSeekerProjects(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+	if resp.Links, err = uc.repo.ListJobSeekerLinks(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+	if resp.Preferences, err = uc.repo.ListJobSeekerPreferences(ctx, seeker.ID); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateJobSeekerProfile updates the basic fields of the user's job seeker profile
+func (uc *UserUseCase) UpdateJobSeekerProfile(ctx context.Context, userID uuid.UUID, req dto.UpdateJobSeekerRequest) (*entity.JobSeeker, error) {
+	seeker, err := uc.repo.GetJobSeekerByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seeker.FirstName = strings.TrimSpace(req.FirstName)
+	seeker.LastName = strings.TrimSpace(req.LastName)
+	seeker.Bio = req.Bio
+	seeker.DateOfBirth = nil
+	if req.DateOfBirth != nil && *req.DateOfBirth != "" {
+		dob, err := time.Parse(time.DateOnly, *req.DateOfBirth)
+		if err != nil {
+			return nil, repository.ErrInvalidInput
+		}
+		seeker.DateOfBirth = &dob
+	}
+
+	if err := uc.repo.UpdateJobSeeker(ctx, seeker); err != nil {
+		return nil, err
+	}
+
+	return seeker, nil
+}
+
+// UpdateJobSeekerVisibility changes who can find the user in the company candidate search
+func (uc *UserUseCase) UpdateJobSeekerVisibility(ctx context.Context, userID uuid.UUID, req dto.UpdateVisibilityRequest) (*entity.JobSeeker, error) {
+	seeker, err := uc.repo.GetJobSeekerByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seeker.Visibility = entity.ProfileVisibility(req.Visibility)
+	if err := uc.repo.UpdateJobSeekerVisibility(ctx, seeker.ID, seeker.Visibility); err != nil {
+		return nil, err
+	}
+
+	return seeker, nil
+}
+
+// ListJobSeekerSection returns the items of one profile section in display order
+func (uc *UserUseCase) ListJobSeekerSection(ctx context.Context, userID uuid.UUID, section entity.ProfileSection) (interface{}, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch section {
+	case entity.SectionSkills:
+		return uc.repo.ListJobSeekerSkills(ctx, jobSeekerID)
+	case entity.SectionExperiences:
+		return uc.repo.ListJobSeekerExperiences(ctx, jobSeekerID)
+	case entity.SectionEducations:
+		return uc.repo.ListJobSeekerEducations(ctx, jobSeekerID)
+	case entity.SectionProjects:
+		return uc.repo.ListJobSeekerProjects(ctx, jobSeekerID)
+	case entity.SectionLinks:
+		return uc.repo.ListJobSeekerLinks(ctx, jobSeekerID)
+	case entity.SectionPreferences:
+		return uc.repo.ListJobSeekerPreferences(ctx, jobSeekerID)
+	default:
+		return nil, repository.ErrInvalidInput
+	}
+}
+
+// CreateJobSeekerSkill adds a skill to the user's profile
+func (uc *UserUseCase) CreateJobSeekerSkill(ctx context.Context, userID uuid.UUID, req dto.SkillRequest) (*entity.JobSeekerSkill, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newSkill(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerSkill(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// UpdateJobSeekerSkill updates a skill of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerSkill(ctx context.Context, userID, id uuid.UUID, req dto.SkillRequest) (*entity.JobSeekerSkill, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newSkill(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerSkill(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// CreateJobSeekerExperience adds a work experience to the user's profile
+func (uc *UserUseCase) CreateJobSeekerExperience(ctx context.Context, userID uuid.UUID, req dto.ExperienceRequest) (*entity.JobSeekerExperience, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newExperience(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerExperience(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// UpdateJobSeekerExperience updates a work experience of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerExperience(ctx context.Context, userID, id uuid.UUID, req dto.ExperienceRequest) (*entity.JobSeekerExperience, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newExperience(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerExperience(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// CreateJobSeekerEducation adds a education entry to the user's profile
+func (uc *UserUseCase) CreateJobSeekerEducation(ctx context.Context, userID uuid.UUID, req dto.EducationRequest) (*entity.JobSeekerEducation, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newEducation(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerEducation(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateJobSeekerEducation updates a education entry of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerEducation(ctx context.Context, userID, id uuid.UUID, req dto.EducationRequest) (*entity.JobSeekerEducation, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newEducation(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerEducation(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// CreateJobSeekerProject adds a project to the user's profile
+func (uc *UserUseCase) CreateJobSeekerProject(ctx context.Context, userID uuid.UUID, req dto.ProjectRequest) (*entity.JobSeekerProject, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newProject(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerProject(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateJobSeekerProject updates a project of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerProject(ctx context.Context, userID, id uuid.UUID, req dto.ProjectRequest) (*entity.JobSeekerProject, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newProject(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerProject(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// CreateJobSeekerLink adds a link to the user's profile
+func (uc *UserUseCase) CreateJobSeekerLink(ctx context.Context, userID uuid.UUID, req dto.LinkRequest) (*entity.JobSeekerLink, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newLink(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerLink(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateJobSeekerLink updates a link of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerLink(ctx context.Context, userID, id uuid.UUID, req dto.LinkRequest) (*entity.JobSeekerLink, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newLink(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerLink(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// CreateJobSeekerPreference adds a job preference to the user's profile
+func (uc *UserUseCase) CreateJobSeekerPreference(ctx context.Context, userID uuid.UUID, req dto.PreferenceRequest) (*entity.JobSeekerPreference, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	item, err := newPreference(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateJobSeekerPreference(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// UpdateJobSeekerPreference updates a job preference of the user's profile
+func (uc *UserUseCase) UpdateJobSeekerPreference(ctx context.Context, userID, id uuid.UUID, req dto.PreferenceRequest) (*entity.JobSeekerPreference, error) {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newPreference(id, jobSeekerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateJobSeekerPreference(ctx, item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateJobRecommendations(ctx, jobSeekerID)
+
+	return item, nil
+}
+
+// DeleteJobSeekerSectionItem removes an item from one of the user's profile sections
+func (uc *UserUseCase) DeleteJobSeekerSectionItem(ctx context.Context, userID uuid.UUID, section entity.ProfileSection, id uuid.UUID) error {
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteJobSeekerSectionItem(ctx, section, jobSeekerID, id); err != nil {
+		return err
+	}
+
+	switch section {
+	case entity.SectionSkills, entity.SectionExperiences, entity.SectionPreferences:
+		uc.invalidateJobRecommendations(ctx, jobSeekerID)
+	}
+
+	return nil
+}
+
+// ReorderJobSeekerSection sets the display order of one of the user's profile sections
+func (uc *UserUseCase) ReorderJobSeekerSection(ctx context.Context, userID uuid.UUID, section entity.ProfileSection, req dto.ReorderRequest) error {
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, raw := range req.IDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return repository.ErrInvalidInput
+		}
+		ids = append(ids, id)
+	}
+
+	jobSeekerID, err := uc.getJobSeekerID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.ReorderJobSeekerSection(ctx, section, jobSeekerID, ids)
+}
+
+// getJobSeekerID resolves the job seeker profile that owns the user's profile sections
+func (uc *UserUseCase) getJobSeekerID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	seeker, err := uc.repo.GetJobSeekerByUserID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return seeker.ID, nil
+}
+
+// invalidateJobRecommendations drops the cached job recommendations after the skills, experiences
+// or preferences they are scored against changed. Failures only delay fresh results, so they are logged.
+func (uc *UserUseCase) invalidateJobRecommendations(ctx context.Context, jobSeekerID uuid.UUID) {
+	if err := uc.repo.InvalidateJobRecommendations(ctx, jobSeekerID); err != nil {
+		uc.log.WithError(err).WithField("job_seeker_id", jobSeekerID).Error("Failed to invalidate job recommendations")
+	}
+}
+
+// parseDateRange parses YYYY-MM-DD start and optional end dates and rejects ranges that end before they start
+func parseDateRange(start string, end *string) (time.Time, *time.Time, error) {
+	startDate, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return time.Time{}, nil, repository.ErrInvalidInput
+	}
+
+	if end == nil || *end == "" {
+		return startDate, nil, nil
+	}
+
+	endDate, err := time.Parse(time.DateOnly, *end)
+	if err != nil || endDate.Before(startDate) {
+		return time.Time{}, nil, repository.ErrInvalidInput
+	}
+
+	return startDate, &endDate, nil
+}
+
+func newSkill(id, jobSeekerID uuid.UUID, req dto.SkillRequest) (*entity.JobSeekerSkill, error) {
+	return &entity.JobSeekerSkill{
+		ID:               id,
+		JobSeekerID:      jobSeekerID,
+		SkillName:        strings.TrimSpace(req.SkillName),
+		ProficiencyLevel: req.ProficiencyLevel,
+	}, nil
+}
+
+func newExperience(id, jobSeekerID uuid.UUID, req dto.ExperienceRequest) (*entity.JobSeekerExperience, error) {
+	startDate, endDate, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.JobSeekerExperience{
+		ID:          id,
+		JobSeekerID: jobSeekerID,
+		JobTitle:    strings.TrimSpace(req.JobTitle),
+		CompanyName: strings.TrimSpace(req.CompanyName),
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Description: strings.TrimSpace(req.Description),
+	}, nil
+}
+
+func newEducation(id, jobSeekerID uuid.UUID, req dto.EducationRequest) (*entity.JobSeekerEducation, error) {
+	startDate, endDate, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.JobSeekerEducation{
+		ID:              id,
+		JobSeekerID:     jobSeekerID,
+		InstitutionName: strings.TrimSpace(req.InstitutionName),
+		Degree:          strings.TrimSpace(req.Degree),
+		FieldOfStudy:    strings.TrimSpace(req.FieldOfStudy),
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Grade:           strings.TrimSpace(req.Grade),
+		Description:     strings.TrimSpace(req.Description),
+	}, nil
+}
+
+func newProject(id, jobSeekerID uuid.UUID, req dto.ProjectRequest) (*entity.JobSeekerProject, error) {
+	startDate, endDate, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.JobSeekerProject{
+		ID:          id,
+		JobSeekerID: jobSeekerID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Link:        strings.TrimSpace(req.Link),
+	}, nil
+}
+
+func newLink(id, jobSeekerID uuid.UUID, req dto.LinkRequest) (*entity.JobSeekerLink, error) {
+	return &entity.JobSeekerLink{
+		ID:          id,
+		JobSeekerID: jobSeekerID,
+		LinkType:    req.LinkType,
+		URL:         strings.TrimSpace(req.URL),
+	}, nil
+}
+
+func newPreference(id, jobSeekerID uuid.UUID, req dto.PreferenceRequest) (*entity.JobSeekerPreference, error) {
+	return &entity.JobSeekerPreference{
+		ID:                id,
+		JobSeekerID:       jobSeekerID,
+		PreferredJobType:  strings.TrimSpace(req.PreferredJobType),
+		PreferredIndustry: strings.TrimSpace(req.PreferredIndustry),
+		PreferredLocation: strings.TrimSpace(req.PreferredLocation),
+		SalaryExpectation: req.SalaryExpectation,
+		RemotePreference:  req.RemotePreference,
+	}, nil
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/totp"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	mfaIssuer            = "Career Link"
+	mfaChallengeTTL      = 5 * time.Minute
+	mfaChallengeAttempts = 5
+	// mfaMaxAttempts codes per mfaAttemptWindow are accepted from a user, wrong or right, before the
+	// second factor locks until the window ends
+	mfaMaxAttempts    = 10
+	mfaAttemptWindow  = 15 * time.Minute
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet leaves out characters that are easy to misread
+	recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// EnrollMFA starts TOTP enrollment and returns the secret for the authenticator app.
+// The second factor is not enforced until ConfirmMFA accepts a code generated from it.
+func (uc *UserUseCase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*dto.MFAEnrollResponse, error) {
+	usr, err := uc.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to get user for MFA enrollment")
+		return nil, repository.ErrUserNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate TOTP secret")
+		return nil, err
+	}
+
+	if err := uc.repo.SaveMFASecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(mfaIssuer, usr.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables the second factor after a valid code and returns the initial recovery codes.
+// The session that confirmed the code counts as second-factor authenticated from its next token refresh.
+func (uc *UserUseCase) ConfirmMFA(ctx context.Context, userID, sessionID uuid.UUID, code string) ([]string, error) {
+	mfa, err := uc.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa.IsEnabled() {
+		return nil, repository.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(code, mfa.Secret, time.Now())
+	if !ok {
+		return nil, repository.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate recovery codes")
+		return nil, err
+	}
+
+	if err := uc.repo.EnableMFA(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.MarkSessionMFAVerified(ctx, userID, sessionID); err != nil {
+		uc.log.WithError(err).WithField("session_id", sessionID).Error("Failed to mark session MFA verified")
+	}
+
+	return codes, nil
+}
+
+// DisableMFA turns the second factor off after checking the password and a current code. Admin roles
+// that must use a second factor cannot turn it off.
+func (uc *UserUseCase) DisableMFA(ctx context.Context, userID uuid.UUID, req dto.MFADisableRequest) error {
+	usr, err := uc.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to get user to disable MFA")
+		return repository.ErrUserNotFound
+	}
+
+	if usr.Role == entity.AdminRole {
+		admin, err := uc.repo.GetAdminByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if admin != nil && admin.Role.RequiresMFA() {
+			return repository.ErrMFARequiredForRole
+		}
+	}
+
+	if err := utils.VerifyPassword(usr.Password, req.Password); err != nil {
+		return repository.ErrInvalidCredentials
+	}
+
+	if err := uc.verifySecondFactor(ctx, userID, req.Code); err != nil {
+		return err
+	}
+
+	return uc.repo.DisableMFA(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after a valid second factor
+func (uc *UserUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := uc.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate recovery codes")
+		return nil, err
+	}
+
+	if err := uc.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// GetMFAStatus reports whether the user has a second factor and how many recovery codes are left
+func (uc *UserUseCase) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusResponse, error) {
+	mfa, err := uc.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotEnrolled) {
+			return &dto.MFAStatusResponse{}, nil
+		}
+		return nil, err
+	}
+
+	if !mfa.IsEnabled() {
+		return &dto.MFAStatusResponse{}, nil
+	}
+
+	remaining, err := uc.repo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAStatusResponse{
+		Enabled:                true,
+		EnabledAt:              mfa.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// CompleteMFALogin exchanges a login challenge and a valid second factor for a session
+func (uc *UserUseCase) CompleteMFALogin(ctx context.Context, req dto.MFALoginRequest) (*dto.LoginResponse, error) {
+	challenge, attempts, err := uc.repo.GetMFAChallenge(ctx, req.MFAToken, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Too many wrong codes burn the challenge, the user has to enter the password again
+	if attempts > mfaChallengeAttempts {
+		if err := uc.repo.DeleteMFAChallenge(ctx, req.MFAToken); err != nil {
+			uc.log.WithError(err).Error("Failed to delete MFA challenge")
+		}
+		return nil, repository.ErrInvalidToken
+	}
+
+	usr, err := uc.repo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", challenge.UserID).Error("Failed to get user for MFA login")
+		return nil, repository.ErrInvalidToken
+	}
+
+	if !usr.IsActive {
+		return nil, repository.ErrUserNotActive
+	}
+
+	if err := uc.verifySecondFactor(ctx, usr.ID, req.Code); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.DeleteMFAChallenge(ctx, req.MFAToken); err != nil {
+		uc.log.WithError(err).Error("Failed to delete MFA challenge")
+	}
+
+	return uc.completeLogin(ctx, usr, challenge.UserAgent, challenge.IPAddress, true)
+}
+
+// startMFAChallenge parks a login that passed the password check until the second factor arrives
+func (uc *UserUseCase) startMFAChallenge(ctx context.Context, userID uuid.UUID, userAgent, ipAddress string) (*dto.LoginResponse, error) {
+	challengeToken, err := utils.GenerateSecureToken(refreshTokenBytes)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate MFA challenge token")
+		return nil, err
+	}
+
+	challenge := repository.MFAChallenge{
+		UserID:    userID,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	}
+	if err := uc.repo.StoreMFAChallenge(ctx, challengeToken, challenge, mfaChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	expiry := time.Now().Add(mfaChallengeTTL)
+	return &dto.LoginResponse{
+		MFARequired:    true,
+		MFAToken:       challengeToken,
+		MFATokenExpiry: &expiry,
+	}, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code.
+// Accepted TOTP codes cannot be replayed and recovery codes are consumed. Attempts are counted per
+// user across every path that asks for the second factor, so codes cannot be guessed.
+func (uc *UserUseCase) verifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := uc.repo.GetUserMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !mfa.IsEnabled() {
+		return repository.ErrMFANotEnrolled
+	}
+
+	// The attempt is counted before the code is checked, so concurrent guesses cannot slip past the limit
+	attempts, err := uc.repo.CountMFAAttempt(ctx, userID, mfaAttemptWindow)
+	if err != nil {
+		return err
+	}
+	if attempts > mfaMaxAttempts {
+		return repository.ErrMFALocked
+	}
+
+	if err := uc.checkSecondFactor(ctx, userID, mfa.Secret, code); err != nil {
+		return err
+	}
+
+	if err := uc.repo.ResetMFAAttempts(ctx, userID); err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to reset MFA attempts")
+	}
+
+	return nil
+}
+
+// checkSecondFactor checks a TOTP or recovery code against the user's second factor and consumes it
+func (uc *UserUseCase) checkSecondFactor(ctx context.Context, userID uuid.UUID, secret, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(code, secret, time.Now())
+		if !ok {
+			return repository.ErrInvalidMFACode
+		}
+		return uc.repo.ConsumeMFAStep(ctx, userID, step)
+	}
+
+	return uc.repo.UseRecoveryCode(ctx, userID, utils.HashToken(normalizeRecoveryCode(code)))
+}
+
+// generateRecoveryCodes returns recovery codes formatted as XXXXX-XXXXX and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		code := string(b[:5]) + "-" + string(b[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and separator insensitive
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,185 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/internal/user/usecase"
+	"github.com/agpprastyo/career-link/pkg/totp"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+)
+
+const mfaTestPassword = "account-password"
+
+// mfaRepo holds one user with a confirmed second factor and counts attempts like the Redis counter
+type mfaRepo struct {
+	repository.Repository
+	user     entity.User
+	admin    *entity.Admin
+	mfa      entity.UserMFA
+	attempts int64
+	disabled bool
+}
+
+func (r *mfaRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	usr := r.user
+	return &usr, nil
+}
+
+func (r *mfaRepo) GetAdminByUserID(ctx context.Context, userID uuid.UUID) (*entity.Admin, error) {
+	return r.admin, nil
+}
+
+func (r *mfaRepo) GetUserMFA(ctx context.Context, userID uuid.UUID) (*entity.UserMFA, error) {
+	mfa := r.mfa
+	return &mfa, nil
+}
+
+func (r *mfaRepo) CountMFAAttempt(ctx context.Context, userID uuid.UUID, window time.Duration) (int64, error) {
+	r.attempts++
+	return r.attempts, nil
+}
+
+func (r *mfaRepo) ResetMFAAttempts(ctx context.Context, userID uuid.UUID) error {
+	r.attempts = 0
+	return nil
+}
+
+func (r *mfaRepo) ConsumeMFAStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	if step <= r.mfa.LastUsedStep {
+		return repository.ErrInvalidMFACode
+	}
+	r.mfa.LastUsedStep = step
+	return nil
+}
+
+func (r *mfaRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	return repository.ErrInvalidMFACode
+}
+
+func (r *mfaRepo) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	r.disabled = true
+	return nil
+}
+
+func newMFAUseCase(t *testing.T, role entity.Role) (*usecase.UserUseCase, *mfaRepo) {
+	t.Helper()
+
+	hashed, err := utils.HashPassword(mfaTestPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enabledAt := time.Now().Add(-time.Hour)
+	repo := &mfaRepo{
+		user: entity.User{ID: uuid.New(), Role: role, Password: hashed},
+		mfa:  entity.UserMFA{Secret: secret, EnabledAt: &enabledAt},
+	}
+	repo.mfa.UserID = repo.user.ID
+
+	return usecase.NewUserUseCase(repo, testutil.Logger(), nil, testJWTConfig), repo
+}
+
+// currentCode returns the TOTP code of the user's secret for the current step
+func currentCode(t *testing.T, repo *mfaRepo) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(repo.mfa.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestDisableMFA(t *testing.T) {
+	uc, repo := newMFAUseCase(t, entity.JobSeekerRole)
+	repo.attempts = 3
+
+	err := uc.DisableMFA(context.Background(), repo.user.ID, dto.MFADisableRequest{
+		Password: mfaTestPassword,
+		Code:     currentCode(t, repo),
+	})
+	if err != nil {
+		t.Fatalf("DisableMFA: %v", err)
+	}
+
+	if !repo.disabled {
+		t.Error("second factor was not disabled")
+	}
+	if repo.attempts != 0 {
+		t.Errorf("attempts: got %d, want the counter reset after a valid code", repo.attempts)
+	}
+}
+
+func TestDisableMFARequiredForAdminRoles(t *testing.T) {
+	tests := []struct {
+		role    entity.AdministratorRole
+		wantErr error
+	}{
+		{entity.AdminRoleSuper, repository.ErrMFARequiredForRole},
+		{entity.AdminRoleAdmin, repository.ErrMFARequiredForRole},
+		{entity.AdminRoleViewer, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			uc, repo := newMFAUseCase(t, entity.AdminRole)
+			repo.admin = &entity.Admin{UserID: repo.user.ID, Role: tt.role}
+
+			err := uc.DisableMFA(context.Background(), repo.user.ID, dto.MFADisableRequest{
+				Password: mfaTestPassword,
+				Code:     currentCode(t, repo),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if repo.disabled != (tt.wantErr == nil) {
+				t.Errorf("disabled: got %v", repo.disabled)
+			}
+		})
+	}
+}
+
+func TestSecondFactorLocksAfterTooManyAttempts(t *testing.T) {
+	uc, repo := newMFAUseCase(t, entity.JobSeekerRole)
+	ctx := context.Background()
+
+	var lockedAfter int
+	for attempt := 1; attempt <= 20; attempt++ {
+		_, err := uc.RegenerateRecoveryCodes(ctx, repo.user.ID, "WRONG-GUESS")
+		if errors.Is(err, repository.ErrMFALocked) {
+			lockedAfter = attempt - 1
+			break
+		}
+		if !errors.Is(err, repository.ErrInvalidMFACode) {
+			t.Fatalf("attempt %d: got %v, want %v", attempt, err, repository.ErrInvalidMFACode)
+		}
+	}
+	if lockedAfter != 10 {
+		t.Fatalf("locked after %d failed attempts, want 10", lockedAfter)
+	}
+
+	// Every path that asks for the second factor shares the counter, and once locked even the right
+	// code is refused until the window passes
+	err := uc.DisableMFA(ctx, repo.user.ID, dto.MFADisableRequest{
+		Password: mfaTestPassword,
+		Code:     currentCode(t, repo),
+	})
+	if !errors.Is(err, repository.ErrMFALocked) {
+		t.Fatalf("got %v, want %v", err, repository.ErrMFALocked)
+	}
+	if repo.disabled {
+		t.Error("second factor was disabled while locked")
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+// refreshTokenBytes is the entropy of an opaque refresh token
+const refreshTokenBytes = 32
+
+// RefreshToken exchanges a refresh token for a new access token and a rotated refresh token.
+// Presenting a token that was already rotated means it leaked, so the whole family is revoked.
+func (uc *UserUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error) {
+	current, err := uc.repo.GetRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenNotFound) {
+			return nil, repository.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		uc.revokeRefreshTokenFamily(ctx, current)
+		return nil, repository.ErrRefreshTokenReused
+	}
+
+	if current.ExpiresAt.Before(time.Now()) {
+		return nil, repository.ErrTokenExpired
+	}
+
+	usr, err := uc.repo.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		uc.log.WithError(err).WithField("user_id", current.UserID).Error("Failed to get user for token refresh")
+		return nil, repository.ErrInvalidToken
+	}
+
+	if !usr.IsActive {
+		uc.revokeRefreshTokenFamily(ctx, current)
+		return nil, repository.ErrUserNotActive
+	}
+
+	refreshToken, next, err := uc.newRefreshToken(usr.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.RotateRefreshToken(ctx, current.ID, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			uc.revokeRefreshTokenFamily(ctx, current)
+		}
+		return nil, err
+	}
+
+	session, err := uc.repo.GetSessionByID(ctx, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, expiry, err := uc.issueAccessToken(ctx, usr, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshTokenResponse{
+		Token:         accessToken,
+		Expiry:        expiry,
+		RefreshToken:  refreshToken,
+		RefreshExpiry: next.ExpiresAt,
+	}, nil
+}
+
+// issueAccessToken signs a short-lived access token for a session and caches the user for the auth middleware
+func (uc *UserUseCase) issueAccessToken(ctx context.Context, usr *entity.User, session *entity.Session) (string, time.Time, error) {
+	subject := token.Subject{
+		UserID:      usr.ID.String(),
+		Email:       usr.Email,
+		SessionID:   session.ID.String(),
+		MFAVerified: session.MFAVerified,
+	}
+
+	tokenString, err := uc.tokenMaker.CreateToken(subject, uc.jwtConfig.AccessTokenDuration)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate token")
+		return "", time.Time{}, errors.New("failed to generate token")
+	}
+
+	// Store user data in Redis for session management
+	if err := uc.repo.StoreUserSession(ctx, usr.ID.String(), tokenString, usr); err != nil {
+		uc.log.WithError(err).Error("Failed to store user session in Redis")
+		return "", time.Time{}, errors.New("failed to store session")
+	}
+
+	return tokenString, time.Now().Add(uc.jwtConfig.AccessTokenDuration), nil
+}
+
+// newRefreshToken generates an opaque refresh token and the record that stores its hash
+func (uc *UserUseCase) newRefreshToken(userID, familyID uuid.UUID) (string, *entity.RefreshToken, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return "", nil, err
+	}
+
+	refreshToken, err := utils.GenerateSecureToken(refreshTokenBytes)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate refresh token")
+		return "", nil, err
+	}
+
+	return refreshToken, &entity.RefreshToken{
+		ID:        id,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(uc.jwtConfig.RefreshTokenDuration),
+	}, nil
+}
+
+// revokeRefreshTokenFamily ends the session a refresh token belongs to. The family ID is the session
+// ID, so access tokens already issued to the session are rejected as well.
+func (uc *UserUseCase) revokeRefreshTokenFamily(ctx context.Context, token *entity.RefreshToken) {
+	uc.log.WithField("user_id", token.UserID).WithField("family_id", token.FamilyID).Warn("Revoking refresh token family")
+	if err := uc.repo.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		uc.log.WithError(err).Error("Failed to revoke refresh token family")
+	}
+	if err := uc.repo.DenySessionAccessTokens(ctx, token.FamilyID, uc.jwtConfig.AccessTokenDuration); err != nil {
+		uc.log.WithError(err).Error("Failed to deny access tokens of revoked refresh token family")
+	}
+}
@@ -0,0 +1,204 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/config"
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/internal/user/usecase"
+	"github.com/agpprastyo/career-link/pkg/token"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// refreshRepo holds the refresh tokens of one user's session and records the revocations made
+type refreshRepo struct {
+	repository.Repository
+	user          entity.User
+	tokens        map[string]*entity.RefreshToken // By hash
+	rotateErr     error
+	rotated       *entity.RefreshToken
+	revokedFamily *uuid.UUID
+	deniedSession *uuid.UUID
+}
+
+func (r *refreshRepo) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	stored, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, repository.ErrTokenNotFound
+	}
+	current := *stored
+	return &current, nil
+}
+
+func (r *refreshRepo) RotateRefreshToken(ctx context.Context, currentID uuid.UUID, next *entity.RefreshToken) error {
+	if r.rotateErr != nil {
+		return r.rotateErr
+	}
+	r.rotated = next
+	return nil
+}
+
+func (r *refreshRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	r.revokedFamily = &familyID
+	return nil
+}
+
+func (r *refreshRepo) DenySessionAccessTokens(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	r.deniedSession = &sessionID
+	return nil
+}
+
+func (r *refreshRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	usr := r.user
+	return &usr, nil
+}
+
+func (r *refreshRepo) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	return &entity.Session{ID: sessionID, UserID: r.user.ID}, nil
+}
+
+func (r *refreshRepo) StoreUserSession(ctx context.Context, userIDStr string, sessionID string, user *entity.User) error {
+	return nil
+}
+
+var testJWTConfig = config.JWT{
+	Secret:               "test-secret",
+	AccessTokenDuration:  15 * time.Minute,
+	RefreshTokenDuration: 24 * time.Hour,
+}
+
+// newRefreshUseCase returns a use case whose repository holds one refresh token, and that token
+func newRefreshUseCase(t *testing.T, stored entity.RefreshToken) (*usecase.UserUseCase, *refreshRepo, string) {
+	t.Helper()
+
+	raw, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.TokenHash = utils.HashToken(raw)
+
+	repo := &refreshRepo{
+		user:   entity.User{ID: stored.UserID, Email: "seeker@example.com", IsActive: true},
+		tokens: map[string]*entity.RefreshToken{stored.TokenHash: &stored},
+	}
+
+	maker, err := token.NewJWTMaker(testJWTConfig.Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return usecase.NewUserUseCase(repo, testutil.Logger(), maker, testJWTConfig), repo, raw
+}
+
+// validRefreshToken is an unused refresh token of a fresh session
+func validRefreshToken() entity.RefreshToken {
+	return entity.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	stored := validRefreshToken()
+	uc, repo, raw := newRefreshUseCase(t, stored)
+
+	resp, err := uc.RefreshToken(context.Background(), dto.RefreshTokenRequest{RefreshToken: raw})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	if resp.RefreshToken == raw {
+		t.Error("refresh token was not rotated")
+	}
+	if repo.rotated == nil {
+		t.Fatal("rotation was not stored")
+	}
+	if repo.rotated.TokenHash != utils.HashToken(resp.RefreshToken) {
+		t.Error("stored hash does not match the returned refresh token")
+	}
+	if repo.rotated.FamilyID != stored.FamilyID {
+		t.Errorf("family: got %s, want %s", repo.rotated.FamilyID, stored.FamilyID)
+	}
+	if repo.revokedFamily != nil || repo.deniedSession != nil {
+		t.Error("a valid refresh revoked the session")
+	}
+	if resp.Token == "" {
+		t.Error("no access token issued")
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		revokedAt *time.Time
+		rotateErr error
+		inactive  bool
+		wantErr   error
+	}{
+		{"token already rotated", &revokedAt, nil, false, repository.ErrRefreshTokenReused},
+		{"rotated concurrently", nil, repository.ErrRefreshTokenReused, false, repository.ErrRefreshTokenReused},
+		{"user deactivated", nil, nil, true, repository.ErrUserNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := validRefreshToken()
+			stored.RevokedAt = tt.revokedAt
+			uc, repo, raw := newRefreshUseCase(t, stored)
+			repo.rotateErr = tt.rotateErr
+			repo.user.IsActive = !tt.inactive
+
+			_, err := uc.RefreshToken(context.Background(), dto.RefreshTokenRequest{RefreshToken: raw})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if repo.revokedFamily == nil || *repo.revokedFamily != stored.FamilyID {
+				t.Error("refresh token family was not revoked")
+			}
+			// Access tokens already issued to the session must stop working too
+			if repo.deniedSession == nil || *repo.deniedSession != stored.FamilyID {
+				t.Error("access tokens of the session were not denied")
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRejected(t *testing.T) {
+	t.Run("unknown token", func(t *testing.T) {
+		uc, repo, _ := newRefreshUseCase(t, validRefreshToken())
+
+		_, err := uc.RefreshToken(context.Background(), dto.RefreshTokenRequest{RefreshToken: "not-a-token"})
+		if !errors.Is(err, repository.ErrInvalidToken) {
+			t.Fatalf("got %v, want %v", err, repository.ErrInvalidToken)
+		}
+		if repo.rotated != nil {
+			t.Error("unknown token was rotated")
+		}
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		stored := validRefreshToken()
+		stored.ExpiresAt = time.Now().Add(-time.Second)
+		uc, repo, raw := newRefreshUseCase(t, stored)
+
+		_, err := uc.RefreshToken(context.Background(), dto.RefreshTokenRequest{RefreshToken: raw})
+		if !errors.Is(err, repository.ErrTokenExpired) {
+			t.Fatalf("got %v, want %v", err, repository.ErrTokenExpired)
+		}
+		if repo.rotated != nil {
+			t.Error("expired token was rotated")
+		}
+	})
+}
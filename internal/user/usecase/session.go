@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+// startSession opens a new device session for a fresh login and issues its first refresh token
+func (uc *UserUseCase) startSession(ctx context.Context, userID uuid.UUID, userAgent, ipAddress string, mfaVerified bool) (string, *entity.Session, *entity.RefreshToken, error) {
+	sessionID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return "", nil, nil, err
+	}
+
+	refreshToken, record, err := uc.newRefreshToken(userID, sessionID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	session := &entity.Session{
+		ID:          sessionID,
+		UserID:      userID,
+		UserAgent:   optionalString(userAgent),
+		IPAddress:   optionalString(ipAddress),
+		MFAVerified: mfaVerified,
+		ExpiresAt:   record.ExpiresAt,
+	}
+
+	if err := uc.repo.CreateSession(ctx, session, record); err != nil {
+		return "", nil, nil, err
+	}
+
+	return refreshToken, session, record, nil
+}
+
+// ListSessions returns the user's active device sessions, flagging the one making the request
+func (uc *UserUseCase) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := uc.repo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, dto.SessionResponse{
+			Session: session,
+			Current: session.ID.String() == currentSessionID,
+		})
+	}
+
+	return resp, nil
+}
+
+// RevokeSession signs one of the user's devices out. Access tokens already issued to the
+// device are rejected right away, and its refresh tokens stop working.
+func (uc *UserUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := uc.repo.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	return uc.repo.DenySessionAccessTokens(ctx, sessionID, uc.jwtConfig.AccessTokenDuration)
+}
+
+// RevokeAllSessions signs the user out of every device, including the current one
+func (uc *UserUseCase) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	return uc.revokeUserTokens(ctx, userID)
+}
+
+// Logout ends the session the request was made from. The presented access token is
+// denylisted as well, since it is the one the client is most likely to retry with.
+func (uc *UserUseCase) Logout(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, tokenID string, expiresAt time.Time) error {
+	if err := uc.repo.RevokeAccessToken(ctx, tokenID, expiresAt); err != nil {
+		return err
+	}
+
+	err := uc.RevokeSession(ctx, userID, sessionID)
+	if err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// revokeUserTokens invalidates every outstanding access and refresh token of a user and drops the cached user data
+func (uc *UserUseCase) revokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if err := uc.repo.RevokeUserAccessTokens(ctx, userID, uc.jwtConfig.AccessTokenDuration); err != nil {
+		return err
+	}
+
+	if err := uc.repo.RevokeUserSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	sessionKey := fmt.Sprintf("session:%s", userID)
+	if err := uc.repo.DeleteUserSession(ctx, sessionKey); err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to delete session")
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agpprastyo/career-link/internal/common/testutil"
+	"github.com/agpprastyo/career-link/internal/user/dto"
+	"github.com/agpprastyo/career-link/internal/user/entity"
+	"github.com/agpprastyo/career-link/internal/user/repository"
+	"github.com/agpprastyo/career-link/internal/user/usecase"
+	"github.com/agpprastyo/career-link/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// passwordRepo stores one user and records the sign-outs a password change makes
+type passwordRepo struct {
+	repository.Repository
+	user            entity.User
+	revokeErr       error
+	revokedTokens   bool
+	revokedSessions bool
+}
+
+func (r *passwordRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	usr := r.user
+	return &usr, nil
+}
+
+func (r *passwordRepo) UpdateUser(ctx context.Context, usr *entity.User) error {
+	r.user = *usr
+	return nil
+}
+
+func (r *passwordRepo) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, ttl time.Duration) error {
+	if r.revokeErr != nil {
+		return r.revokeErr
+	}
+	r.revokedTokens = true
+	return nil
+}
+
+func (r *passwordRepo) RevokeUserSessions(ctx context.Context, userID uuid.UUID) error {
+	r.revokedSessions = true
+	return nil
+}
+
+func (r *passwordRepo) DeleteUserSession(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func TestUpdatePasswordSignsOutEverySession(t *testing.T) {
+	const currentPassword = "current-password"
+
+	hashed, err := utils.HashPassword(currentPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errRedis := errors.New("redis unavailable")
+
+	tests := []struct {
+		name      string
+		revokeErr error
+	}{
+		{"signed out", nil},
+		{"sign out fails", errRedis},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &passwordRepo{user: entity.User{ID: uuid.New(), Password: hashed}, revokeErr: tt.revokeErr}
+			uc := usecase.NewUserUseCase(repo, testutil.Logger(), nil, testJWTConfig)
+
+			err := uc.UpdatePassword(context.Background(), dto.UpdatePasswordRequest{
+				UserID:          repo.user.ID.String(),
+				CurrentPassword: currentPassword,
+				NewPassword:     "new-password",
+			})
+
+			// A password change that leaves old sessions signed in must not report success
+			if !errors.Is(err, tt.revokeErr) {
+				t.Fatalf("got %v, want %v", err, tt.revokeErr)
+			}
+			if tt.revokeErr != nil {
+				return
+			}
+
+			if !repo.revokedTokens {
+				t.Error("access tokens were not revoked")
+			}
+			if !repo.revokedSessions {
+				t.Error("sessions were not revoked")
+			}
+			if utils.VerifyPassword(repo.user.Password, "new-password") != nil {
+				t.Error("new password was not stored")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"github.com/agpprastyo/career-link/config"
 	"github.com/agpprastyo/career-link/internal/user/repository"
 	"github.com/agpprastyo/career-link/pkg/logger"
 	token2 "github.com/agpprastyo/career-link/pkg/token"
@@ -11,13 +12,15 @@ type UserUseCase struct {
 	repo       repository.Repository
 	log        *logger.Logger
 	tokenMaker token2.Maker
+	jwtConfig  config.JWT
 }
 
 // NewUserUseCase creates a new usersUseCase instance
-func NewUserUseCase(repo repository.Repository, log *logger.Logger, tokenMaker token2.Maker) *UserUseCase {
+func NewUserUseCase(repo repository.Repository, log *logger.Logger, tokenMaker token2.Maker, jwtConfig config.JWT) *UserUseCase {
 	return &UserUseCase{
 		repo:       repo,
 		log:        log,
 		tokenMaker: tokenMaker,
+		jwtConfig:  jwtConfig,
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/agpprastyo/career-link/internal/user/repository"
 	"github.com/agpprastyo/career-link/pkg/utils"
 	"github.com/google/uuid"
+	"strings"
 	"sync"
 	"time"
 )
@@ -76,17 +77,101 @@ func (uc *UserUseCase) UpdateAvatar(ctx context.Context, req dto.UpdateAvatarReq
 	return avatarURL, nil
 }
 
-func (uc *UserUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
-	// Parse user ID from string to UUID
-	email := req.Email
+const (
+	// passwordResetTokenTTL is how long a password reset link stays valid
+	passwordResetTokenTTL = time.Hour
+	// emailVerificationTokenTTL is how long an email verification link stays valid
+	emailVerificationTokenTTL = 24 * time.Hour
+	// linkTokenBytes is the entropy of a token that grants access to an account from an emailed link
+	linkTokenBytes = 32
+)
 
-	// Get the user from the database
-	userData, err := uc.repo.GetUserByEmail(ctx, email)
+// newToken generates a unique verification token of a type for a user, valid for ttl
+func (uc *UserUseCase) newToken(ctx context.Context, userID uuid.UUID, tokenType entity.TokenType, ttl time.Duration) (*entity.VerificationToken, error) {
+	tokenID, err := uuid.NewV7()
 	if err != nil {
-		uc.log.WithError(err).Error("Failed to get user for password reset")
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return nil, err
+	}
+
+	token, err := utils.GenerateUniqueToken(ctx, uc.repo.TokenExists)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate unique token")
+		return nil, err
+	}
+
+	return &entity.VerificationToken{
+		ID:        tokenID,
+		UserID:    userID,
+		Token:     token,
+		Type:      string(tokenType),
+		ExpiredAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// newLinkToken generates an unguessable token of a type for a user, valid for ttl, and the record
+// that stores its hash. Only the returned token can be sent to the user.
+func (uc *UserUseCase) newLinkToken(userID uuid.UUID, tokenType entity.TokenType, ttl time.Duration) (string, *entity.VerificationToken, error) {
+	tokenID, err := uuid.NewV7()
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate UUID")
+		return "", nil, err
+	}
+
+	token, err := utils.GenerateSecureToken(linkTokenBytes)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to generate link token")
+		return "", nil, err
+	}
+
+	return token, &entity.VerificationToken{
+		ID:        tokenID,
+		UserID:    userID,
+		Token:     utils.HashToken(token),
+		Type:      string(tokenType),
+		ExpiredAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// ForgotPassword issues a password reset token and emails the reset link to the user
+func (uc *UserUseCase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	usr, err := uc.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to get user by email")
 		return repository.ErrUserNotFound
 	}
 
+	token, record, err := uc.newLinkToken(usr.ID, entity.PasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	// Earlier reset links stop working, and the new one is emailed through the outbox
+	if err := uc.repo.CreatePasswordResetToken(ctx, usr, token, record, passwordResetTokenTTL); err != nil {
+		uc.log.WithError(err).Error("Failed to create password reset token")
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a password reset token and signs the user out everywhere
+func (uc *UserUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	userData, err := uc.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		uc.log.WithError(err).Error("Failed to get user for password reset")
+		return repository.ErrInvalidToken
+	}
+
+	t, err := uc.getPasswordResetToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	if t.UserID != userData.ID {
+		return repository.ErrInvalidToken
+	}
+
 	// Hash the new password
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
@@ -94,11 +179,14 @@ func (uc *UserUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordR
 		return err
 	}
 
-	// Update password in the database
-	userData.Password = hashedPassword
-	err = uc.repo.UpdateUser(ctx, userData)
-	if err != nil {
-		uc.log.WithError(err).Error("Failed to update password in database")
+	if err := uc.repo.ResetPassword(ctx, userData.ID, t.ID, hashedPassword); err != nil {
+		uc.log.WithError(err).Error("Failed to reset password in database")
+		return err
+	}
+
+	// Existing sessions were opened with the old password, so drop them
+	if err := uc.revokeUserTokens(ctx, userData.ID); err != nil {
+		uc.log.WithError(err).WithField("user_id", userData.ID).Error("Failed to revoke sessions after password reset")
 		return err
 	}
 
@@ -107,21 +195,38 @@ func (uc *UserUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordR
 
 // VerifyPasswordResetToken checks if the password reset token is valid
 func (uc *UserUseCase) VerifyPasswordResetToken(ctx context.Context, token string) (bool, error) {
-	t, err := uc.repo.GetToken(ctx, token)
+	if _, err := uc.getPasswordResetToken(ctx, token); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getPasswordResetToken loads a token by its hash and checks that it is an unused, unexpired password
+// reset token
+func (uc *UserUseCase) getPasswordResetToken(ctx context.Context, token string) (*entity.VerificationToken, error) {
+	t, err := uc.repo.GetToken(ctx, utils.HashToken(token))
 	if err != nil {
+		if errors.Is(err, repository.ErrTokenNotFound) {
+			return nil, repository.ErrInvalidToken
+		}
 		uc.log.WithError(err).Error("Failed to get token")
-		return false, err
+		return nil, err
 	}
 
 	if t.Type != string(entity.PasswordReset) {
-		return false, repository.ErrInvalidToken
+		return nil, repository.ErrInvalidToken
+	}
+
+	if t.UsedAt != nil {
+		return nil, repository.ErrTokenAlreadyUsed
 	}
 
 	if t.ExpiredAt.Before(time.Now()) {
-		return false, repository.ErrTokenExpired
+		return nil, repository.ErrTokenExpired
 	}
 
-	return true, nil
+	return t, nil
 }
 
 // UpdatePassword changes a user's password after verifying the current password
@@ -162,6 +267,12 @@ func (uc *UserUseCase) UpdatePassword(ctx context.Context, req dto.UpdatePasswor
 		return err
 	}
 
+	// Sign out every session, including the one that made the change
+	if err := uc.revokeUserTokens(ctx, userID); err != nil {
+		uc.log.WithError(err).WithField("user_id", userID).Error("Failed to revoke sessions after password update")
+		return err
+	}
+
 	return nil
 }
 
@@ -178,37 +289,17 @@ func (uc *UserUseCase) ResendVerificationEmail(ctx context.Context, req dto.Rese
 		return repository.ErrUserAlreadyActive
 	}
 
-	// Generate a new token ID for the verification link
-	tokenID, err := uuid.NewV7()
-	if err != nil {
-		uc.log.WithError(err).Error("Failed to generate UUID")
-		return err
-	}
-
-	// Generate secure token for the verification link
-	token, err := utils.GenerateUniqueToken(ctx, uc.repo.TokenExists)
+	token, err := uc.newToken(ctx, usr.ID, entity.EmailVerification, emailVerificationTokenTTL)
 	if err != nil {
-		uc.log.WithError(err).Error("Failed to generate unique token")
 		return err
 	}
 
-	tokenExpiry := time.Now().Add(24 * time.Hour)
-	tokenType := entity.EmailVerification
-
-	// Store token in database for later verification via link
-	if err := uc.repo.CreateToken(ctx, usr.ID, tokenID, token, tokenType, tokenExpiry); err != nil {
+	// The verification link email is queued with the token and delivered by the outbox worker
+	if err := uc.repo.CreateVerificationToken(ctx, usr, token); err != nil {
 		uc.log.WithError(err).Error("Failed to create verification token")
 		return err
 	}
 
-	// Send verification link email in background
-	go func() {
-		bgCtx := context.Background()
-		if err := uc.repo.SendVerificationEmail(bgCtx, usr.Username, usr.Email, token); err != nil {
-			uc.log.WithError(err).Error("Failed to send verification email with link")
-		}
-	}()
-
 	return nil
 }
 
@@ -233,32 +324,11 @@ func (uc *UserUseCase) VerifyEmail(ctx context.Context, req dto.VerifyEmailReque
 		return repository.ErrTokenExpired
 	}
 
-	if err := uc.repo.ActivateUser(ctx, token.UserID); err != nil {
+	// Consume the token, activate the user and queue the welcome email together
+	if err := uc.repo.ActivateUserWithToken(ctx, token.UserID, token.ID); err != nil {
 		uc.log.WithError(err).Error("Failed to activate user")
 		return err
 	}
-	// Mark token as used and send post-verification email in background
-	go func() {
-		// Create a new background context for goroutine operations
-		bgCtx := context.Background()
-
-		// Get user data for email
-		usr, err := uc.repo.GetUserByID(bgCtx, token.UserID)
-		if err != nil {
-			uc.log.WithError(err).Error("Failed to get user by ID in background task")
-			return
-		}
-
-		// Mark token as used
-		if err := uc.repo.UpdateToken(bgCtx, token.ID, time.Now()); err != nil {
-			uc.log.WithError(err).Error("Failed to update token")
-		}
-
-		// Send confirmation email
-		if err := uc.repo.SendPostVerificationEmail(bgCtx, usr.Username, usr.Email); err != nil {
-			uc.log.WithError(err).Error("Failed to send post-verification email")
-		}
-	}()
 
 	return nil
 }
@@ -323,50 +393,141 @@ func (uc *UserUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*
 		Role:     entity.Role(req.Role),
 	}
 
-	if err := uc.repo.CreateUser(ctx, usr); err != nil {
-		uc.log.WithError(err).Error("Failed to create user")
+	// The verification token and its email are stored in the same transaction as the user
+	verification, err := uc.newToken(ctx, usr.ID, entity.EmailVerification, emailVerificationTokenTTL)
+	if err != nil {
 		return nil, err
 	}
 
-	// Launch verification link generation and email sending in background
-	go func() {
-		// Create a new context for background processing
-		bgCtx := context.Background()
+	resp := &dto.RegisterResponse{
+		User:    *usr,
+		Message: "User created successfully. Please check your email to verify your account",
+	}
 
-		tokenID, err := uuid.NewV7()
+	// Create the user and its role profile in a single transaction
+	switch usr.Role {
+	case entity.JobSeekerRole:
+		seeker, err := newJobSeekerProfile(usr.ID, req.JobSeekerProfile)
 		if err != nil {
-			uc.log.WithError(err).Error("Failed to generate UUID in background")
-			return
+			uc.log.WithError(err).Error("Failed to build job seeker profile")
+			return nil, err
 		}
-
-		// Generate secure token for the verification link
-		token, err := utils.GenerateUniqueToken(bgCtx, uc.repo.TokenExists)
+		if err := uc.repo.CreateJobSeekerUser(ctx, usr, seeker, verification); err != nil {
+			uc.log.WithError(err).Error("Failed to create job seeker user")
+			return nil, err
+		}
+		resp.JobSeeker = seeker
+	case entity.CompanyRole:
+		company, err := newCompanyProfile(usr, req.CompanyProfile)
 		if err != nil {
-			uc.log.WithError(err).Error("Failed to generate unique token in background")
-			return
+			uc.log.WithError(err).Error("Failed to build company profile")
+			return nil, err
 		}
+		if err := uc.repo.CreateCompanyUser(ctx, usr, company, verification); err != nil {
+			uc.log.WithError(err).Error("Failed to create company user")
+			return nil, err
+		}
+		resp.Company = company
+	default:
+		return nil, repository.ErrInvalidInput
+	}
 
-		tokenExpiry := time.Now().Add(24 * time.Hour)
-		tokenType := entity.EmailVerification
+	return resp, nil
+}
 
-		// Store token in database for later verification
-		if err := uc.repo.CreateToken(bgCtx, usr.ID, tokenID, token, tokenType, tokenExpiry); err != nil {
-			uc.log.WithError(err).Error("Failed to create verification token in background")
-			return
-		}
+// newJobSeekerProfile builds the job_seekers row for a registering job seeker
+func newJobSeekerProfile(userID uuid.UUID, profile *dto.JobSeekerProfileDTO) (*entity.JobSeeker, error) {
+	if profile == nil {
+		return nil, repository.ErrInvalidInput
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	seeker := &entity.JobSeeker{
+		ID:        id,
+		UserID:    userID,
+		FirstName: strings.TrimSpace(profile.FirstName),
+		LastName:  strings.TrimSpace(profile.LastName),
+		Bio:       profile.Bio,
+	}
 
-		// Send email with verification link
-		if err := uc.repo.SendVerificationEmail(bgCtx, usr.Username, usr.Email, token); err != nil {
-			uc.log.WithError(err).Error("Failed to send verification email in background")
+	if profile.DateOfBirth != nil && *profile.DateOfBirth != "" {
+		dob, err := time.Parse(time.DateOnly, *profile.DateOfBirth)
+		if err != nil {
+			return nil, repository.ErrInvalidInput
 		}
-	}()
+		seeker.DateOfBirth = &dob
+	}
 
-	return &dto.RegisterResponse{
-		User:    *usr,
-		Message: "User created successfully. Please check your email to verify your account",
+	return seeker, nil
+}
+
+// newCompanyProfile builds the companies and company_addresses rows for a registering company
+func newCompanyProfile(usr *entity.User, profile *dto.CompanyProfileDTO) (*entity.Company, error) {
+	if profile == nil || profile.Address == nil {
+		return nil, repository.ErrInvalidInput
+	}
+
+	companyID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	addressID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(profile.Email)
+	if email == "" {
+		email = usr.Email
+	}
+
+	return &entity.Company{
+		ID:          companyID,
+		UserID:      usr.ID,
+		Name:        strings.TrimSpace(profile.Name),
+		Description: optionalString(profile.Description),
+		Industry:    optionalString(profile.Industry),
+		Website:     optionalString(profile.Website),
+		Email:       email,
+		Phone:       optionalString(profile.Phone),
+		Status:      entity.Active,
+		Size:        entity.CompanySizeRange(profile.Size),
+		AddressID:   addressID,
+		Address: &entity.CompanyAddress{
+			ID:           addressID,
+			CompanyID:    companyID,
+			AddressLine1: strings.TrimSpace(profile.Address.AddressLine1),
+			AddressLine2: optionalString(profile.Address.AddressLine2),
+			City:         strings.TrimSpace(profile.Address.City),
+			State:        strings.TrimSpace(profile.Address.State),
+			ZipCode:      strings.TrimSpace(profile.Address.ZipCode),
+			Country:      strings.TrimSpace(profile.Address.Country),
+		},
 	}, nil
 }
 
+// optionalString returns nil for blank strings so they are stored as NULL
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// equalOptionalString reports whether two optional strings are both unset or hold the same value
+func equalOptionalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // Login authenticates a users and returns users data with auth token
 func (uc *UserUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
 	var usr *entity.User
@@ -397,31 +558,37 @@ func (uc *UserUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.Lo
 		return nil, repository.ErrUserNotActive
 	}
 
-	tokenString, err := uc.tokenMaker.CreateToken(usr.ID.String(), usr.Email, 24*time.Hour)
-	if err != nil {
-		uc.log.WithError(err).Error("Failed to generate token")
-		return nil, errors.New("failed to generate token")
+	// Accounts with a second factor get a challenge instead of a session
+	mfa, err := uc.repo.GetUserMFA(ctx, usr.ID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotEnrolled) {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return uc.startMFAChallenge(ctx, usr.ID, req.UserAgent, req.IPAddress)
 	}
 
-	expiry := time.Now().Add(24 * time.Hour)
+	return uc.completeLogin(ctx, usr, req.UserAgent, req.IPAddress, false)
+}
 
-	sessionKey := fmt.Sprintf("session:%s", usr.ID)
-	err = uc.repo.DeleteUserSession(ctx, sessionKey)
+// completeLogin opens a device session for an authenticated user and issues its tokens.
+// Every login opens its own session, other devices stay signed in.
+func (uc *UserUseCase) completeLogin(ctx context.Context, usr *entity.User, userAgent, ipAddress string, mfaVerified bool) (*dto.LoginResponse, error) {
+	refreshToken, session, refresh, err := uc.startSession(ctx, usr.ID, userAgent, ipAddress, mfaVerified)
 	if err != nil {
-		uc.log.WithError(err).Error("Failed to delete old session")
-
+		return nil, errors.New("failed to start session")
 	}
 
-	// Store user data in Redis for session management
-	if err := uc.repo.StoreUserSession(ctx, usr.ID.String(), tokenString, usr); err != nil {
-		uc.log.WithError(err).Error("Failed to store user session in Redis")
-		return nil, errors.New("failed to store session")
+	tokenString, expiry, err := uc.issueAccessToken(ctx, usr, session)
+	if err != nil {
+		return nil, err
 	}
 
 	return &dto.LoginResponse{
-		User:   *usr,
-		Token:  tokenString,
-		Expiry: expiry,
+		User:          usr,
+		Token:         tokenString,
+		Expiry:        &expiry,
+		RefreshToken:  refreshToken,
+		RefreshExpiry: &refresh.ExpiresAt,
 	}, nil
 }
 
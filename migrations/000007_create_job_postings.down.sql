@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_job_postings_timestamp ON job_postings;
+DROP INDEX IF EXISTS idx_job_postings_status;
+DROP INDEX IF EXISTS idx_job_postings_company_id;
+DROP TABLE IF EXISTS job_postings;
@@ -0,0 +1,28 @@
+-- Job postings table
+CREATE TABLE IF NOT EXISTS job_postings (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    employment_type VARCHAR(20) NOT NULL CHECK (employment_type IN ('full_time', 'part_time', 'contract', 'internship', 'freelance')),
+    location VARCHAR(255) NOT NULL,
+    is_remote BOOLEAN NOT NULL DEFAULT FALSE,
+    salary_min INTEGER,
+    salary_max INTEGER,
+    required_skills TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'closed')),
+    published_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_job_postings_salary_range CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max)
+);
+
+CREATE INDEX idx_job_postings_company_id ON job_postings(company_id);
+CREATE INDEX idx_job_postings_status ON job_postings(status);
+
+-- Keep updated_at in sync, reusing the function from 000004
+CREATE TRIGGER update_job_postings_timestamp
+BEFORE UPDATE ON job_postings
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
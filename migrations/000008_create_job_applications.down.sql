@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_job_applications_timestamp ON job_applications;
+DROP INDEX IF EXISTS idx_job_application_stage_history_application_id;
+DROP INDEX IF EXISTS idx_job_applications_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_applications_job_id;
+DROP TABLE IF EXISTS job_application_stage_history;
+DROP TABLE IF EXISTS job_applications;
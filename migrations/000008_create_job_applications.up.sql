@@ -0,0 +1,32 @@
+-- Job applications table
+CREATE TABLE IF NOT EXISTS job_applications (
+    id UUID PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    job_seeker_id UUID NOT NULL REFERENCES job_seekers(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (stage IN ('applied', 'screening', 'interview', 'offer', 'hired', 'rejected', 'withdrawn')),
+    cover_letter TEXT,
+    stage_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_job_applications_job_seeker UNIQUE (job_id, job_seeker_id)
+);
+
+-- Audit trail of every stage change
+CREATE TABLE IF NOT EXISTS job_application_stage_history (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    from_stage VARCHAR(20),
+    to_stage VARCHAR(20) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_job_applications_job_id ON job_applications(job_id);
+CREATE INDEX idx_job_applications_job_seeker_id ON job_applications(job_seeker_id);
+CREATE INDEX idx_job_application_stage_history_application_id ON job_application_stage_history(application_id);
+
+CREATE TRIGGER update_job_applications_timestamp
+BEFORE UPDATE ON job_applications
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
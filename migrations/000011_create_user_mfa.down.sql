@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_user_mfa_timestamp ON user_mfa;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
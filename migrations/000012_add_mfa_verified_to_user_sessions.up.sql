@@ -0,0 +1,2 @@
+-- Sessions opened through a second factor, required for admin routes
+ALTER TABLE user_sessions ADD COLUMN IF NOT EXISTS mfa_verified BOOLEAN NOT NULL DEFAULT FALSE;
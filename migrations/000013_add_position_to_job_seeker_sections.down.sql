@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_job_seeker_preferences_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_seeker_links_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_seeker_projects_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_seeker_educations_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_seeker_experiences_job_seeker_id;
+DROP INDEX IF EXISTS idx_job_seeker_skills_job_seeker_id;
+
+ALTER TABLE job_seeker_preferences DROP COLUMN IF EXISTS position;
+ALTER TABLE job_seeker_links DROP COLUMN IF EXISTS position;
+ALTER TABLE job_seeker_projects DROP COLUMN IF EXISTS position;
+ALTER TABLE job_seeker_educations DROP COLUMN IF EXISTS position;
+ALTER TABLE job_seeker_experiences DROP COLUMN IF EXISTS position;
+ALTER TABLE job_seeker_skills DROP COLUMN IF EXISTS position;
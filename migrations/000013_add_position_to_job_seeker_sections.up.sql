@@ -0,0 +1,14 @@
+-- Display order of the job seeker profile sections, lowest first
+ALTER TABLE job_seeker_skills ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_seeker_experiences ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_seeker_educations ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_seeker_projects ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_seeker_links ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_seeker_preferences ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_job_seeker_skills_job_seeker_id ON job_seeker_skills(job_seeker_id, position);
+CREATE INDEX IF NOT EXISTS idx_job_seeker_experiences_job_seeker_id ON job_seeker_experiences(job_seeker_id, position);
+CREATE INDEX IF NOT EXISTS idx_job_seeker_educations_job_seeker_id ON job_seeker_educations(job_seeker_id, position);
+CREATE INDEX IF NOT EXISTS idx_job_seeker_projects_job_seeker_id ON job_seeker_projects(job_seeker_id, position);
+CREATE INDEX IF NOT EXISTS idx_job_seeker_links_job_seeker_id ON job_seeker_links(job_seeker_id, position);
+CREATE INDEX IF NOT EXISTS idx_job_seeker_preferences_job_seeker_id ON job_seeker_preferences(job_seeker_id, position);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_company_verification_requests_timestamp ON company_verification_requests;
+DROP TABLE IF EXISTS company_verification_documents;
+DROP TABLE IF EXISTS company_verification_requests;
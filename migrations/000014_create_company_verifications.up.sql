@@ -0,0 +1,37 @@
+-- Requests from companies to be verified, reviewed by admins
+CREATE TABLE IF NOT EXISTS company_verification_requests (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    rejection_reason TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_verification_requests_company_id ON company_verification_requests(company_id);
+CREATE INDEX idx_company_verification_requests_status ON company_verification_requests(status, created_at);
+
+-- A company can only have one request waiting for review
+CREATE UNIQUE INDEX uq_company_verification_requests_pending
+    ON company_verification_requests(company_id)
+    WHERE status = 'pending';
+
+-- Supporting documents stored in object storage
+CREATE TABLE IF NOT EXISTS company_verification_documents (
+    id UUID PRIMARY KEY,
+    request_id UUID NOT NULL REFERENCES company_verification_requests(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    object_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_verification_documents_request_id ON company_verification_documents(request_id);
+
+CREATE TRIGGER update_company_verification_requests_timestamp
+BEFORE UPDATE ON company_verification_requests
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
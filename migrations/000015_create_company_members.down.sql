@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_company_members_timestamp ON company_members;
+DROP TABLE IF EXISTS company_invitations;
+DROP TABLE IF EXISTS company_members;
+DELETE FROM verification_tokens WHERE type = 'company_invitation';
@@ -0,0 +1,39 @@
+-- Users acting on behalf of a company. A user belongs to at most one company.
+CREATE TABLE IF NOT EXISTS company_members (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'recruiter', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (company_id, user_id),
+    CONSTRAINT uq_company_members_user_id UNIQUE (user_id)
+);
+
+-- Every existing company account becomes the owner of its company
+INSERT INTO company_members (company_id, user_id, role, created_at)
+SELECT id, user_id, 'owner', created_at
+FROM companies
+ON CONFLICT DO NOTHING;
+
+-- Email invitations to join a company. The token lives in verification_tokens.
+CREATE TABLE IF NOT EXISTS company_invitations (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'recruiter', 'viewer')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    token_id UUID NOT NULL REFERENCES verification_tokens(id) ON DELETE CASCADE,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Only one open invitation per email and company
+CREATE UNIQUE INDEX uq_company_invitations_open
+    ON company_invitations(company_id, LOWER(email))
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+CREATE TRIGGER update_company_members_timestamp
+BEFORE UPDATE ON company_members
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_job_postings_published_at;
+DROP INDEX IF EXISTS idx_job_postings_search_vector;
+
+DROP TRIGGER IF EXISTS update_job_postings_search_vector ON job_postings;
+DROP FUNCTION IF EXISTS job_postings_search_vector_update();
+
+ALTER TABLE job_postings DROP COLUMN IF EXISTS search_vector;
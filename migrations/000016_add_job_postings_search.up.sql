@@ -0,0 +1,26 @@
+-- Full-text search document of a job posting. Maintained by a trigger because array_to_string is
+-- not immutable and cannot be used in a generated column.
+ALTER TABLE job_postings ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION job_postings_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', array_to_string(NEW.required_skills, ' ')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.location, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_job_postings_search_vector
+BEFORE INSERT OR UPDATE OF title, required_skills, location, description ON job_postings
+FOR EACH ROW
+EXECUTE FUNCTION job_postings_search_vector_update();
+
+-- Backfill existing postings through the trigger
+UPDATE job_postings SET title = title;
+
+CREATE INDEX IF NOT EXISTS idx_job_postings_search_vector ON job_postings USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_job_postings_published_at ON job_postings(published_at DESC) WHERE status = 'published';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_job_seeker_skills_name;
+DROP INDEX IF EXISTS idx_job_seekers_visibility;
+
+ALTER TABLE job_seekers DROP COLUMN IF EXISTS visibility;
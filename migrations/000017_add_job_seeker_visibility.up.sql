@@ -0,0 +1,8 @@
+-- Who can find a job seeker in the company candidate search. Existing profiles start as
+-- applied_only so nobody becomes searchable without opting in.
+ALTER TABLE job_seekers
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'applied_only'
+    CHECK (visibility IN ('public', 'applied_only', 'hidden'));
+
+CREATE INDEX IF NOT EXISTS idx_job_seekers_visibility ON job_seekers(visibility) WHERE visibility <> 'hidden';
+CREATE INDEX IF NOT EXISTS idx_job_seeker_skills_name ON job_seeker_skills(LOWER(skill_name));
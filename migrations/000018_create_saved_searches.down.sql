@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_saved_searches_timestamp ON saved_searches;
+DROP TABLE IF EXISTS saved_searches;
@@ -0,0 +1,30 @@
+-- Job searches saved by job seekers, with their alert settings
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY,
+    job_seeker_id UUID NOT NULL REFERENCES job_seekers(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query VARCHAR(200),
+    location VARCHAR(255),
+    is_remote BOOLEAN,
+    employment_types TEXT[] NOT NULL DEFAULT '{}',
+    salary_min INTEGER,
+    salary_max INTEGER,
+    industries TEXT[] NOT NULL DEFAULT '{}',
+    company_sizes TEXT[] NOT NULL DEFAULT '{}',
+    alert_frequency VARCHAR(20) NOT NULL DEFAULT 'daily' CHECK (alert_frequency IN ('instant', 'daily', 'weekly')),
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Postings published after this moment are new to the next alert
+    last_alerted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_saved_searches_salary_range CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max)
+);
+
+CREATE INDEX idx_saved_searches_job_seeker_id ON saved_searches(job_seeker_id);
+CREATE INDEX idx_saved_searches_due ON saved_searches(alert_frequency, last_alerted_at) WHERE alerts_enabled;
+
+CREATE TRIGGER update_saved_searches_timestamp
+BEFORE UPDATE ON saved_searches
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_job_postings_application_deadline;
+DROP TABLE IF EXISTS saved_jobs;
+ALTER TABLE job_postings DROP COLUMN IF EXISTS application_deadline;
@@ -0,0 +1,18 @@
+-- Optional last day to apply; applications are refused after it passes
+ALTER TABLE job_postings
+    ADD COLUMN IF NOT EXISTS application_deadline TIMESTAMP WITH TIME ZONE;
+
+-- Postings bookmarked by job seekers
+CREATE TABLE IF NOT EXISTS saved_jobs (
+    job_seeker_id UUID NOT NULL REFERENCES job_seekers(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    -- When the job seeker was last reminded that the deadline is near
+    deadline_reminded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_seeker_id, job_id)
+);
+
+CREATE INDEX idx_saved_jobs_job_id ON saved_jobs(job_id);
+CREATE INDEX idx_saved_jobs_job_seeker_created ON saved_jobs(job_seeker_id, created_at DESC);
+CREATE INDEX idx_job_postings_application_deadline ON job_postings(application_deadline)
+    WHERE status = 'published' AND application_deadline IS NOT NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_job_applications_rejection_email_due_at;
+
+ALTER TABLE job_applications
+    DROP COLUMN IF EXISTS rejection_email_due_at,
+    DROP COLUMN IF EXISTS knocked_out;
+
+ALTER TABLE job_postings DROP COLUMN IF EXISTS rejection_email_delay_hours;
+
+DROP TABLE IF EXISTS job_application_answers;
+DROP TABLE IF EXISTS job_screening_questions;
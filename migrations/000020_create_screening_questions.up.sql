@@ -0,0 +1,36 @@
+-- Screening questions asked when applying to a posting. A question is a knockout when it has
+-- knockout answers (yes/no and multiple choice) or an accepted range (numeric).
+CREATE TABLE IF NOT EXISTS job_screening_questions (
+    id UUID PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    question VARCHAR(500) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('yes_no', 'multiple_choice', 'numeric', 'free_text')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT TRUE,
+    knockout_answers TEXT[] NOT NULL DEFAULT '{}',
+    min_accepted NUMERIC,
+    max_accepted NUMERIC,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_job_screening_questions_position UNIQUE (job_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS job_application_answers (
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES job_screening_questions(id) ON DELETE CASCADE,
+    answer TEXT NOT NULL,
+    knocked_out BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (application_id, question_id)
+);
+
+-- Hours to wait before emailing applicants rejected by a knockout; NULL sends no email
+ALTER TABLE job_postings
+    ADD COLUMN IF NOT EXISTS rejection_email_delay_hours INTEGER
+    CHECK (rejection_email_delay_hours BETWEEN 0 AND 720);
+
+ALTER TABLE job_applications
+    ADD COLUMN IF NOT EXISTS knocked_out BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS rejection_email_due_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_job_applications_rejection_email_due_at ON job_applications(rejection_email_due_at)
+    WHERE rejection_email_due_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_interviews_timestamp ON interviews;
+DROP TABLE IF EXISTS interview_slots;
+DROP TABLE IF EXISTS interviews;
@@ -0,0 +1,37 @@
+-- Interviews of an application. The company proposes slots, the candidate picks one and the
+-- interview becomes scheduled. Times are stored as instants; timezone is the IANA zone they are
+-- shown in.
+CREATE TABLE IF NOT EXISTS interviews (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed' CHECK (status IN ('proposed', 'scheduled', 'cancelled')),
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes BETWEEN 15 AND 480),
+    timezone VARCHAR(64) NOT NULL,
+    location VARCHAR(500),
+    notes TEXT,
+    starts_at TIMESTAMP WITH TIME ZONE,
+    -- Revision of the calendar invite, increased whenever an updated invite is sent
+    sequence INTEGER NOT NULL DEFAULT 0,
+    proposed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cancelled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cancel_reason TEXT,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_interviews_scheduled_starts_at CHECK (status <> 'scheduled' OR starts_at IS NOT NULL)
+);
+
+-- Start times proposed by the company
+CREATE TABLE IF NOT EXISTS interview_slots (
+    id UUID PRIMARY KEY,
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT uq_interview_slots_starts_at UNIQUE (interview_id, starts_at)
+);
+
+CREATE INDEX idx_interviews_application_id ON interviews(application_id);
+
+CREATE TRIGGER update_interviews_timestamp
+BEFORE UPDATE ON interviews
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,37 @@
+-- Messages exchanged between a company and an applicant, one thread per application
+CREATE TABLE IF NOT EXISTS application_messages (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    sender_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Side of the application the sender acts for; company messages may come from any teammate
+    sender_role VARCHAR(20) NOT NULL CHECK (sender_role IN ('company', 'job_seeker')),
+    body TEXT NOT NULL DEFAULT '',
+    -- When the other side first read the message
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_application_messages_application_created ON application_messages(application_id, created_at DESC);
+CREATE INDEX idx_application_messages_unread ON application_messages(application_id, sender_role)
+    WHERE read_at IS NULL;
+
+-- Files attached to a message, stored in object storage
+CREATE TABLE IF NOT EXISTS application_message_attachments (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES application_messages(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    object_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_application_message_attachments_message_id ON application_message_attachments(message_id);
+
+-- How far each user has read a thread, used for per-user unread counts
+CREATE TABLE IF NOT EXISTS application_thread_reads (
+    application_id UUID NOT NULL REFERENCES job_applications(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (application_id, user_id)
+);
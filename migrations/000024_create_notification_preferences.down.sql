@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_notifications_digest_pending;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS digest_pending,
+    DROP COLUMN IF EXISTS in_app;
+
+DROP TRIGGER IF EXISTS update_notification_preferences_timestamp ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,23 @@
+-- Per user choice of channels for each notification type. Types without a row use their defaults.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    email BOOLEAN NOT NULL,
+    in_app BOOLEAN NOT NULL,
+    digest BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+CREATE TRIGGER update_notification_preferences_timestamp
+BEFORE UPDATE ON notification_preferences
+FOR EACH ROW
+EXECUTE FUNCTION update_timestamp();
+
+-- Notifications are also kept for the digest email of users who turned the in-app channel off
+ALTER TABLE notifications
+    ADD COLUMN in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN digest_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_notifications_digest_pending ON notifications(user_id, created_at) WHERE digest_pending;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_email_outbox_status;
+DROP INDEX IF EXISTS idx_email_outbox_due;
+DROP TRIGGER IF EXISTS update_email_outbox_timestamp ON email_outbox;
+DROP TABLE IF EXISTS email_outbox;
@@ -0,0 +1,155 @@
+// Package ics builds iCalendar (RFC 5545) invitations that calendar clients can import from an email
+// attachment, using the iTIP (RFC 5546) REQUEST and CANCEL methods.
+package ics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Method is the iTIP method of an invitation
+type Method string
+
+const (
+	// MethodRequest creates or updates an event in the attendee's calendar
+	MethodRequest Method = "REQUEST"
+	// MethodCancel removes an event from the attendee's calendar
+	MethodCancel Method = "CANCEL"
+)
+
+const (
+	prodID = "-//Career Link//Interviews//EN"
+	// Times are always written in UTC, so no VTIMEZONE component is needed and clients show them in
+	// the viewer's own zone
+	utcFormat = "20060102T150405Z"
+	// maxLineOctets is the longest content line allowed before folding, excluding the line break
+	maxLineOctets = 75
+)
+
+// Person is an organizer or attendee of an event
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Event is a single calendar event
+type Event struct {
+	// UID identifies the event across updates; an update or cancellation must reuse it
+	UID string
+	// Sequence must increase every time the event is sent again with changes
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Organizer   Person
+	Attendee    Person
+	// Stamp is when the invitation was created, defaults to now
+	Stamp time.Time
+}
+
+// ContentType returns the MIME type of an invitation sent with method
+func ContentType(method Method) string {
+	return "text/calendar; charset=utf-8; method=" + string(method)
+}
+
+// Invite builds a calendar containing the event for method
+func Invite(method Method, event Event) []byte {
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	status := "CONFIRMED"
+	if method == MethodCancel {
+		status = "CANCELLED"
+	}
+
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(fold(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + prodID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + string(method))
+	line("BEGIN:VEVENT")
+	line("UID:" + escapeText(event.UID))
+	line("SEQUENCE:" + strconv.Itoa(event.Sequence))
+	line("DTSTAMP:" + stamp.UTC().Format(utcFormat))
+	line("DTSTART:" + event.Start.UTC().Format(utcFormat))
+	line("DTEND:" + event.End.UTC().Format(utcFormat))
+	line("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.Location != "" {
+		line("LOCATION:" + escapeText(event.Location))
+	}
+	line("ORGANIZER" + commonName(event.Organizer.Name) + ":mailto:" + event.Organizer.Email)
+	line("ATTENDEE" + commonName(event.Attendee.Name) + ";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:" + event.Attendee.Email)
+	line("STATUS:" + status)
+	line("TRANSP:OPAQUE")
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// commonName returns the CN parameter for name, or nothing when name is empty. Parameter values are
+// quoted and cannot contain double quotes or line breaks.
+func commonName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
+
+// fold splits a content line longer than 75 octets into continuation lines, never inside a UTF-8
+// sequence
+func fold(s string) string {
+	if len(s) <= maxLineOctets {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards their length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
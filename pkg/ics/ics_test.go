@@ -0,0 +1,204 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Interview", "Interview"},
+		{`C:\path`, `C:\\path`},
+		{"Room 4; floor 2", `Room 4\; floor 2`},
+		{"Jakarta, Indonesia", `Jakarta\, Indonesia`},
+		{"line one\nline two", `line one\nline two`},
+		{"line one\r\nline two", `line one\nline two`},
+		{"line one\rline two", `line one\nline two`},
+		{`a\;b,c`, `a\\\;b\,c`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"short", "SUMMARY:Interview"},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67)},
+		{"76 octets", "SUMMARY:" + strings.Repeat("a", 68)},
+		{"several lines", "DESCRIPTION:" + strings.Repeat("abcdefghij", 30)},
+		{"two byte runes", "SUMMARY:" + strings.Repeat("é", 100)},
+		{"three byte runes", "SUMMARY:" + strings.Repeat("面", 60)},
+		{"four byte runes", "SUMMARY:" + strings.Repeat("😀", 50)},
+		{"mixed widths", "SUMMARY:" + strings.Repeat("a面é😀", 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fold(tt.in)
+			lines := strings.Split(got, "\r\n")
+
+			for i, l := range lines {
+				if len(l) > maxLineOctets {
+					t.Errorf("line %d has %d octets", i, len(l))
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, l)
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+			}
+
+			if len(tt.in) <= maxLineOctets && len(lines) != 1 {
+				t.Errorf("line of %d octets was folded", len(tt.in))
+			}
+
+			// Unfolding, RFC 5545 section 3.1, gives back the original line
+			if unfolded := strings.ReplaceAll(got, "\r\n ", ""); unfolded != tt.in {
+				t.Errorf("unfolded line differs:\n got %q\nwant %q", unfolded, tt.in)
+			}
+		})
+	}
+}
+
+func TestFoldFillsLines(t *testing.T) {
+	// After the 8 octet name, 2 octet runes cross the 75 octet limit mid rune, so the first line
+	// backs off to 74 octets
+	got := strings.Split(fold("SUMMARY:"+strings.Repeat("é", 100)), "\r\n")
+	if len(got[0]) != 74 {
+		t.Errorf("first line has %d octets, want 74", len(got[0]))
+	}
+
+	got = strings.Split(fold("SUMMARY:"+strings.Repeat("a", 200)), "\r\n")
+	if len(got[0]) != maxLineOctets {
+		t.Errorf("first line has %d octets, want %d", len(got[0]), maxLineOctets)
+	}
+	if len(got[1]) != maxLineOctets {
+		t.Errorf("continuation line has %d octets, want %d", len(got[1]), maxLineOctets)
+	}
+}
+
+func testEvent() Event {
+	start := time.Date(2026, 3, 10, 9, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+	return Event{
+		UID:         "interview-42@careerlink",
+		Sequence:    2,
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "Interview: Backend Engineer, Acme",
+		Description: "Bring your portfolio;\nask for reception",
+		Location:    "https://meet.example.com/abc",
+		Organizer:   Person{Name: "Acme \"Hiring\"", Email: "jobs@acme.test"},
+		Attendee:    Person{Name: "Dewi", Email: "dewi@example.com"},
+		Stamp:       time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// unfold returns the content lines of a calendar
+func unfold(cal []byte) []string {
+	s := strings.TrimSuffix(strings.ReplaceAll(string(cal), "\r\n ", ""), "\r\n")
+	return strings.Split(s, "\r\n")
+}
+
+func hasLine(lines []string, want string) bool {
+	for _, l := range lines {
+		if l == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInvite(t *testing.T) {
+	tests := []struct {
+		method Method
+		status string
+	}{
+		{MethodRequest, "STATUS:CONFIRMED"},
+		{MethodCancel, "STATUS:CANCELLED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			cal := Invite(tt.method, testEvent())
+
+			if !strings.HasSuffix(string(cal), "\r\n") || strings.Contains(strings.ReplaceAll(string(cal), "\r\n", ""), "\n") {
+				t.Error("content lines are not terminated by CRLF")
+			}
+
+			lines := unfold(cal)
+			for _, want := range []string{
+				"BEGIN:VCALENDAR",
+				"METHOD:" + string(tt.method),
+				"UID:interview-42@careerlink",
+				"SEQUENCE:2",
+				"DTSTAMP:20260301T120000Z",
+				"DTSTART:20260310T023000Z",
+				"DTEND:20260310T033000Z",
+				`SUMMARY:Interview: Backend Engineer\, Acme`,
+				`DESCRIPTION:Bring your portfolio\;\nask for reception`,
+				"LOCATION:https://meet.example.com/abc",
+				`ORGANIZER;CN="Acme Hiring":mailto:jobs@acme.test`,
+				`ATTENDEE;CN="Dewi";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:dewi@example.com`,
+				tt.status,
+				"END:VCALENDAR",
+			} {
+				if !hasLine(lines, want) {
+					t.Errorf("missing line %q", want)
+				}
+			}
+
+			if got := ContentType(tt.method); got != "text/calendar; charset=utf-8; method="+string(tt.method) {
+				t.Errorf("got content type %q", got)
+			}
+		})
+	}
+}
+
+func TestInviteOmitsEmptyOptionalFields(t *testing.T) {
+	event := testEvent()
+	event.Description = ""
+	event.Location = ""
+	event.Attendee.Name = ""
+
+	lines := unfold(Invite(MethodRequest, event))
+	for _, l := range lines {
+		if strings.HasPrefix(l, "DESCRIPTION") || strings.HasPrefix(l, "LOCATION") {
+			t.Errorf("unexpected line %q", l)
+		}
+	}
+	if !hasLine(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:dewi@example.com") {
+		t.Error("attendee without a name has a CN parameter")
+	}
+}
+
+func TestInviteFoldsLongLines(t *testing.T) {
+	event := testEvent()
+	event.Description = strings.Repeat("Wawancara dengan tim teknik — ", 10)
+
+	cal := Invite(MethodRequest, event)
+	for i, l := range strings.Split(strings.TrimSuffix(string(cal), "\r\n"), "\r\n") {
+		if len(l) > maxLineOctets {
+			t.Errorf("line %d has %d octets", i, len(l))
+		}
+		if !utf8.ValidString(l) {
+			t.Errorf("line %d splits a UTF-8 sequence", i)
+		}
+	}
+
+	if !hasLine(unfold(cal), "DESCRIPTION:"+escapeText(event.Description)) {
+		t.Error("description does not unfold to the original text")
+	}
+}
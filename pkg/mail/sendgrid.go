@@ -2,6 +2,8 @@ package mail
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"github.com/agpprastyo/career-link/config"
 	"github.com/agpprastyo/career-link/pkg/logger"
 	"github.com/sendgrid/sendgrid-go"
@@ -24,6 +26,15 @@ type EmailMessage struct {
 	IsHTML       bool
 	TemplateName string
 	TemplateData map[string]interface{}
+	Headers      map[string]string // Extra headers, e.g. List-Unsubscribe
+	Attachments  []Attachment
+}
+
+// Attachment is a file sent along with an email
+type Attachment struct {
+	Filename    string
+	ContentType string // MIME type, e.g. "text/calendar; method=REQUEST"
+	Content     []byte
 }
 
 // NewSendGridClient creates a new SendGrid client
@@ -52,6 +63,17 @@ func (c *Client) SendEmail(ctx context.Context, message EmailMessage) error {
 	}
 
 	msg := mail.NewV3MailInit(from, message.Subject, to, content)
+	for key, value := range message.Headers {
+		msg.SetHeader(key, value)
+	}
+	for _, file := range message.Attachments {
+		attachment := mail.NewAttachment()
+		attachment.SetFilename(file.Filename)
+		attachment.SetType(file.ContentType)
+		attachment.SetContent(base64.StdEncoding.EncodeToString(file.Content))
+		attachment.SetDisposition("attachment")
+		msg.AddAttachment(attachment)
+	}
 
 	response, err := c.client.Send(msg)
 	if err != nil {
@@ -59,6 +81,17 @@ func (c *Client) SendEmail(ctx context.Context, message EmailMessage) error {
 		return err
 	}
 
+	// SendGrid reports rejected requests through the status code rather than an error
+	if response.StatusCode >= 300 {
+		c.log.WithFields(logrus.Fields{
+			"to":       message.To,
+			"subject":  message.Subject,
+			"status":   response.StatusCode,
+			"response": response.Body,
+		}).Error("SendGrid rejected email")
+		return fmt.Errorf("sendgrid responded with status %d: %s", response.StatusCode, response.Body)
+	}
+
 	c.log.WithFields(logrus.Fields{
 		"from":     c.config.FromEmail,
 		"to":       message.To,
@@ -0,0 +1,35 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/application_rejected.html
+var applicationRejectedTemplate string
+
+// ApplicationRejectedData contains the data needed for the application rejection email
+type ApplicationRejectedData struct {
+	FirstName       string
+	JobTitle        string
+	CompanyName     string
+	ApplicationsURL string
+	AppName         string
+	SupportEmail    string
+}
+
+// GetApplicationRejectedHTML renders the application rejection email template
+func GetApplicationRejectedHTML(data ApplicationRejectedData) (string, error) {
+	tmpl, err := template.New("application_rejected").Parse(applicationRejectedTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
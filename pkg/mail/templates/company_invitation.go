@@ -0,0 +1,36 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/company_invitation.html
+var companyInvitationTemplate string
+
+// CompanyInvitationData contains the data needed for the company invitation email
+type CompanyInvitationData struct {
+	CompanyName   string
+	InviterName   string
+	Role          string
+	InvitationURL string // Frontend page that accepts the invitation token
+	ExpiresIn     string // Human readable token lifetime, e.g. "7 days"
+	AppName       string
+	SupportEmail  string
+}
+
+// GetCompanyInvitationHTML renders the company invitation email template
+func GetCompanyInvitationHTML(data CompanyInvitationData) (string, error) {
+	tmpl, err := template.New("company_invitation").Parse(companyInvitationTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,35 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/company_verification.html
+var companyVerificationTemplate string
+
+// CompanyVerificationData contains the data needed for the company verification result email
+type CompanyVerificationData struct {
+	CompanyName  string
+	Approved     bool
+	Reason       string // Only shown when the request was rejected
+	DashboardURL string
+	AppName      string
+	SupportEmail string
+}
+
+// GetCompanyVerificationHTML renders the company verification result email template
+func GetCompanyVerificationHTML(data CompanyVerificationData) (string, error) {
+	tmpl, err := template.New("company_verification").Parse(companyVerificationTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,42 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/deadline_reminder.html
+var deadlineReminderTemplate string
+
+// DeadlineReminderItem is one saved posting listed in a deadline reminder email
+type DeadlineReminderItem struct {
+	Title       string
+	CompanyName string
+	Deadline    string
+	URL         string
+}
+
+// DeadlineReminderData contains the data needed for the saved job deadline reminder email
+type DeadlineReminderData struct {
+	FirstName    string
+	Jobs         []DeadlineReminderItem
+	SavedJobsURL string
+	AppName      string
+	SupportEmail string
+}
+
+// GetDeadlineReminderHTML renders the saved job deadline reminder email template
+func GetDeadlineReminderHTML(data DeadlineReminderData) (string, error) {
+	tmpl, err := template.New("deadline_reminder").Parse(deadlineReminderTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
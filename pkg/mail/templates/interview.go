@@ -0,0 +1,53 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/interview.html
+var interviewTemplate string
+
+// Interview email kinds
+const (
+	InterviewProposed    = "proposed"
+	InterviewScheduled   = "scheduled"
+	InterviewRescheduled = "rescheduled"
+	InterviewCancelled   = "cancelled"
+)
+
+// InterviewData contains the data needed for the interview emails sent to the candidate and the company
+type InterviewData struct {
+	Kind          string // One of the interview email kinds
+	ForCompany    bool   // The email goes to the company rather than the candidate
+	RecipientName string
+	CandidateName string
+	JobTitle      string
+	CompanyName   string
+	// Start times already formatted in the interview timezone; a single one unless Kind is proposed
+	Times        []string
+	Timezone     string
+	Duration     string
+	Location     string
+	Notes        string
+	CancelReason string
+	ActionURL    string
+	AppName      string
+	SupportEmail string
+}
+
+// GetInterviewHTML renders the interview email template
+func GetInterviewHTML(data InterviewData) (string, error) {
+	tmpl, err := template.New("interview").Parse(interviewTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
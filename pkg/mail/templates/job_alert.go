@@ -0,0 +1,94 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/job_alert.html
+var jobAlertTemplate string
+
+//go:embed html/unsubscribe_confirm.html
+var unsubscribeConfirmTemplate string
+
+//go:embed html/unsubscribe_success.html
+var unsubscribeSuccessTemplate string
+
+// JobAlertItem is one posting listed in a job alert email
+type JobAlertItem struct {
+	Title       string
+	CompanyName string
+	Location    string
+	IsRemote    bool
+	Salary      string // Formatted salary range, empty when the posting has none
+	URL         string
+}
+
+// JobAlertData contains the data needed for the job alert digest email
+type JobAlertData struct {
+	FirstName      string
+	SearchName     string
+	Jobs           []JobAlertItem
+	MoreCount      int // Matching postings not listed in the email
+	SearchURL      string
+	UnsubscribeURL string
+	AppName        string
+	SupportEmail   string
+}
+
+// GetJobAlertHTML renders the job alert digest email template
+func GetJobAlertHTML(data JobAlertData) (string, error) {
+	tmpl, err := template.New("job_alert").Parse(jobAlertTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// GetUnsubscribeConfirmHTML renders the page that asks the user to confirm unsubscribing from job
+// alerts. The form posts back to actionURL.
+func GetUnsubscribeConfirmHTML(searchName, actionURL string) (string, error) {
+	tmpl, err := template.New("unsubscribe_confirm").Parse(unsubscribeConfirmTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		SearchName string
+		ActionURL  string
+	}{searchName, actionURL}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// GetUnsubscribeSuccessHTML renders the page shown after unsubscribing from job alerts
+func GetUnsubscribeSuccessHTML(searchName, manageURL string) (string, error) {
+	tmpl, err := template.New("unsubscribe_success").Parse(unsubscribeSuccessTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		SearchName string
+		ManageURL  string
+	}{searchName, manageURL}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
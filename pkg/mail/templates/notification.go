@@ -0,0 +1,72 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/notification.html
+var notificationTemplate string
+
+//go:embed html/notification_digest.html
+var notificationDigestTemplate string
+
+// NotificationData contains the data needed for the email of a single notification
+type NotificationData struct {
+	Username     string
+	Title        string
+	Body         string
+	ActionURL    string
+	SettingsURL  string
+	AppName      string
+	SupportEmail string
+}
+
+// NotificationDigestItem is one notification listed in a digest email
+type NotificationDigestItem struct {
+	Title string
+	Body  string
+	URL   string
+	Time  string
+}
+
+// NotificationDigestData contains the data needed for the notification digest email
+type NotificationDigestData struct {
+	Username         string
+	Items            []NotificationDigestItem
+	NotificationsURL string
+	SettingsURL      string
+	AppName          string
+	SupportEmail     string
+}
+
+// GetNotificationHTML renders the single notification email template
+func GetNotificationHTML(data NotificationData) (string, error) {
+	tmpl, err := template.New("notification").Parse(notificationTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// GetNotificationDigestHTML renders the notification digest email template
+func GetNotificationDigestHTML(data NotificationDigestData) (string, error) {
+	tmpl, err := template.New("notification_digest").Parse(notificationDigestTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,34 @@
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html/password_reset.html
+var passwordResetTemplate string
+
+// PasswordResetData contains the data needed for the password reset email
+type PasswordResetData struct {
+	Username     string
+	ResetURL     string // Frontend page that submits the token with the new password
+	ExpiresIn    string // Human readable token lifetime, e.g. "1 hour"
+	CompanyName  string
+	SupportEmail string
+}
+
+// GetPasswordResetHTML renders the password reset email template
+func GetPasswordResetHTML(data PasswordResetData) (string, error) {
+	tmpl, err := template.New("password_reset").Parse(passwordResetTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -60,6 +60,30 @@ func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 	return result > 0, err
 }
 
+// Incr increments a counter and starts its expiration when the counter is created
+func (c *Client) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	count, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := c.client.Expire(ctx, key, expiration).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// Publish sends a message to every subscriber of a channel
+func (c *Client) Publish(ctx context.Context, channel string, message interface{}) error {
+	return c.client.Publish(ctx, channel, message).Err()
+}
+
+// PSubscribe subscribes to every channel matching the patterns. The caller must close the subscription.
+func (c *Client) PSubscribe(ctx context.Context, patterns ...string) *redis.PubSub {
+	return c.client.PSubscribe(ctx, patterns...)
+}
+
 // GetClient returns the underlying Redis client
 func (c *Client) GetClient() *redis.Client {
 	return c.client
@@ -73,6 +97,7 @@ func (c *Client) Del(ctx context.Context, id string) interface{} {
 	return c.client.Del(ctx, id)
 }
 
-func (c *Client) Ping() (interface{}, interface{}) {
-	return c.client.Ping(context.Background()), nil
+// Ping checks the Redis connection
+func (c *Client) Ping() (string, error) {
+	return c.client.Ping(context.Background()).Result()
 }
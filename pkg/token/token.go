@@ -3,6 +3,7 @@ package token
 import (
 	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"time"
 )
@@ -15,16 +16,27 @@ var (
 
 // Payload contains the payload data of the token
 type Payload struct {
-	UserID    string    `json:"user_id"`
-	Email     string    `json:"email"`
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	ID          string    `json:"jti"` // Unique token ID, used to revoke a single token
+	SessionID   string    `json:"sid"` // Device session the token was issued to
+	MFAVerified bool      `json:"mfa"` // Whether the session passed a second factor
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	IssuedAt    time.Time `json:"issued_at"` // Millisecond precision, so revocations can be told apart from new logins
+	ExpiredAt   time.Time `json:"expired_at"`
+}
+
+// Subject identifies who and which session a token is issued to
+type Subject struct {
+	UserID      string
+	Email       string
+	SessionID   string
+	MFAVerified bool
 }
 
 // Maker is an interface for managing tokens
 type Maker interface {
-	// CreateToken creates a new token for a specific user
-	CreateToken(userID string, email string, duration time.Duration) (string, error)
+	// CreateToken creates a new token for a specific user session
+	CreateToken(subject Subject, duration time.Duration) (string, error)
 
 	// VerifyToken checks if the token is valid
 	VerifyToken(token string) (*Payload, error)
@@ -41,14 +53,23 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 }
 
 // CreateToken creates a new JWT token
-func (maker *JWTMaker) CreateToken(userID string, email string, duration time.Duration) (string, error) {
+func (maker *JWTMaker) CreateToken(subject Subject, duration time.Duration) (string, error) {
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(duration)
 
 	claims := jwt.MapClaims{
-		"user_id":    userID,
-		"email":      email,
+		"jti":        tokenID.String(),
+		"sid":        subject.SessionID,
+		"mfa":        subject.MFAVerified,
+		"user_id":    subject.UserID,
+		"email":      subject.Email,
 		"issued_at":  issuedAt.Unix(),
+		"iat_ms":     issuedAt.UnixMilli(),
 		"expired_at": expiredAt.Unix(),
 	}
 
@@ -77,6 +98,19 @@ func (maker *JWTMaker) VerifyToken(tokenString string) (*Payload, error) {
 	}
 
 	// Extract claims
+	tokenID, ok := claims["jti"].(string)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	sessionID, ok := claims["sid"].(string)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	// Tokens issued before the mfa claim existed simply count as not verified
+	mfaVerified, _ := claims["mfa"].(bool)
+
 	userID, ok := claims["user_id"].(string)
 	if !ok {
 		return nil, ErrInvalidToken
@@ -96,12 +130,20 @@ func (maker *JWTMaker) VerifyToken(tokenString string) (*Payload, error) {
 	if !ok {
 		return nil, ErrInvalidToken
 	}
+	issuedAtTime := time.Unix(int64(issuedAt), 0)
+	// Tokens issued before the iat_ms claim existed only know the second they were issued in
+	if issuedAtMs, ok := claims["iat_ms"].(float64); ok {
+		issuedAtTime = time.UnixMilli(int64(issuedAtMs))
+	}
 
 	payload := &Payload{
-		UserID:    userID,
-		Email:     email,
-		IssuedAt:  time.Unix(int64(issuedAt), 0),
-		ExpiredAt: time.Unix(int64(expiredAt), 0),
+		ID:          tokenID,
+		SessionID:   sessionID,
+		MFAVerified: mfaVerified,
+		UserID:      userID,
+		Email:       email,
+		IssuedAt:    issuedAtTime,
+		ExpiredAt:   time.Unix(int64(expiredAt), 0),
 	}
 
 	// Check if token is expired
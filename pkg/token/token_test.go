@@ -0,0 +1,68 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func TestVerifyTokenKeepsMillisecondIssuedAt(t *testing.T) {
+	maker, err := NewJWTMaker(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Issue away from a whole second, where a seconds-only issued_at would be indistinguishable
+	for time.Now().Nanosecond() < int(10*time.Millisecond) {
+		time.Sleep(time.Millisecond)
+	}
+
+	before := time.Now().UnixMilli()
+	tokenString, err := maker.CreateToken(Subject{UserID: "user", Email: "user@example.com", SessionID: "session"}, time.Minute)
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	after := time.Now().UnixMilli()
+
+	payload, err := maker.VerifyToken(tokenString)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+
+	if got := payload.IssuedAt.UnixMilli(); got < before || got > after {
+		t.Errorf("issued at %d ms, want between %d and %d", got, before, after)
+	}
+}
+
+func TestVerifyTokenWithoutMillisecondClaim(t *testing.T) {
+	maker, err := NewJWTMaker(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedAt := time.Now().Add(-time.Minute)
+	claims := jwt.MapClaims{
+		"jti":        "token",
+		"sid":        "session",
+		"user_id":    "user",
+		"email":      "user@example.com",
+		"issued_at":  issuedAt.Unix(),
+		"expired_at": issuedAt.Add(time.Hour).Unix(),
+	}
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := maker.VerifyToken(tokenString)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+
+	if !payload.IssuedAt.Equal(time.Unix(issuedAt.Unix(), 0)) {
+		t.Errorf("issued at %s, want %s", payload.IssuedAt, time.Unix(issuedAt.Unix(), 0))
+	}
+}
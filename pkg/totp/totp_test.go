@@ -0,0 +1,176 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed from RFC 6238 appendix B, "12345678901234567890", in base32
+var rfcSecret = b32.EncodeToString([]byte("12345678901234567890"))
+
+// The RFC lists 8 digit codes; a 6 digit code is the same value truncated to its last 6 digits.
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := GenerateCode(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("t=%d: %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("t=%d: got %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestGenerateCodeAcceptsLowercaseAndPaddedSecret(t *testing.T) {
+	want, _ := GenerateCode(rfcSecret, 1)
+
+	for _, secret := range []string{"gezdgnbvgy3tqojqgezdgnbvgy3tqojq", rfcSecret + "===="} {
+		got, err := GenerateCode(secret, 1)
+		if err != nil {
+			t.Fatalf("%s: %v", secret, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %s, want %s", secret, got, want)
+		}
+	}
+}
+
+func TestValidateRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		now := time.Unix(v.unix, 0)
+		step, ok := Validate(v.code, rfcSecret, now)
+		if !ok {
+			t.Errorf("t=%d: code %s rejected", v.unix, v.code)
+			continue
+		}
+		if step != Step(now) {
+			t.Errorf("t=%d: got step %d, want %d", v.unix, step, Step(now))
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		want   bool
+	}{
+		{"two steps behind", -2, false},
+		{"one step behind", -1, true},
+		{"current step", 0, true},
+		{"one step ahead", 1, true},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateCode(rfcSecret, current+tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			step, ok := Validate(code, rfcSecret, now)
+			if ok != tt.want {
+				t.Fatalf("got %v, want %v", ok, tt.want)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("got step %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	for _, code := range []string{"", "28708", "2870820", "94287082", "abcdef"} {
+		if _, ok := Validate(code, rfcSecret, now); ok {
+			t.Errorf("code %q accepted", code)
+		}
+	}
+
+	if _, ok := Validate(" 287082 ", rfcSecret, now); !ok {
+		t.Errorf("code with surrounding spaces rejected")
+	}
+
+	if _, ok := Validate("287082", "not base32!", now); ok {
+		t.Errorf("code accepted for an invalid secret")
+	}
+}
+
+// A code stays valid for the whole skew window, so replay protection relies on the matched step
+// being the same on every attempt and callers only accepting steps newer than the last one used.
+func TestValidateReplay(t *testing.T) {
+	issued := time.Unix(1111111111, 0)
+	code, err := GenerateCode(rfcSecret, Step(issued))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastUsed := int64(0)
+	accept := func(now time.Time) bool {
+		step, ok := Validate(code, rfcSecret, now)
+		if !ok || step <= lastUsed {
+			return false
+		}
+		lastUsed = step
+		return true
+	}
+
+	if !accept(issued) {
+		t.Fatal("first use rejected")
+	}
+	if accept(issued) {
+		t.Error("replay in the same step accepted")
+	}
+	if accept(issued.Add(Period)) {
+		t.Error("replay in the next step accepted")
+	}
+	if accept(issued.Add(2 * Period)) {
+		t.Error("code accepted outside the skew window")
+	}
+
+	next, err := GenerateCode(rfcSecret, Step(issued)+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code = next
+	if !accept(issued.Add(Period)) {
+		t.Error("code for a newer step rejected")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := b32.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret is not base32: %v", err)
+	}
+	if len(key) != secretSize {
+		t.Errorf("got %d byte key, want %d", len(key), secretSize)
+	}
+
+	other, _ := GenerateSecret()
+	if other == secret {
+		t.Error("two generated secrets are equal")
+	}
+}
@@ -0,0 +1,129 @@
+package upload
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// maxFileNameRunes is the longest stored file name, matching the VARCHAR(255) file name columns
+const maxFileNameRunes = 255
+
+// Type is an accepted file extension and the content type its content must be detected as
+type Type struct {
+	Ext         string
+	ContentType string
+}
+
+// DocumentTypes are the file types accepted for documents: PDFs and JPEG or PNG images
+var DocumentTypes = []Type{
+	{Ext: ".pdf", ContentType: "application/pdf"},
+	{Ext: ".jpg", ContentType: "image/jpeg"},
+	{Ext: ".jpeg", ContentType: "image/jpeg"},
+	{Ext: ".png", ContentType: "image/png"},
+}
+
+// Rules describe the files an upload accepts
+type Rules struct {
+	Label   string // What the file is called in client messages, e.g. "Document"
+	MaxSize int64  // Largest accepted file, in bytes
+	Types   []Type
+}
+
+// File is an uploaded file that passed validation
+type File struct {
+	FileName    string
+	FileExt     string
+	FileContent []byte
+	ContentType string
+}
+
+// Read validates an uploaded file against the rules and reads its content. The file extension must be
+// accepted and the detected content type must match it. It returns a client error message when the
+// file is rejected.
+func Read(file *multipart.FileHeader, rules Rules) (File, string, error) {
+	if file.Size > rules.MaxSize {
+		return File{}, rules.tooLargeMessage(), nil
+	}
+
+	fileExt := strings.ToLower(filepath.Ext(file.Filename))
+	expectedType, ok := rules.contentType(fileExt)
+	if !ok {
+		return File{}, rules.invalidTypeMessage(), nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return File{}, "", err
+	}
+	defer func(src multipart.File) {
+		// The file was only read, closing it cannot lose data
+		_ = src.Close()
+	}(src)
+
+	// The header size comes from the client, so the read is bounded too
+	content, err := io.ReadAll(io.LimitReader(src, rules.MaxSize+1))
+	if err != nil {
+		return File{}, "", err
+	}
+	if int64(len(content)) > rules.MaxSize {
+		return File{}, rules.tooLargeMessage(), nil
+	}
+
+	if http.DetectContentType(content) != expectedType {
+		return File{}, rules.Label + " content does not match its file type", nil
+	}
+
+	return File{
+		FileName:    FileName(file.Filename),
+		FileExt:     fileExt,
+		FileContent: content,
+		ContentType: expectedType,
+	}, "", nil
+}
+
+// FileName returns the base name of an uploaded file, keeping its last 255 characters so the
+// extension survives. It never splits a multi-byte character.
+func FileName(name string) string {
+	name = filepath.Base(name)
+
+	runes := []rune(name)
+	if len(runes) > maxFileNameRunes {
+		name = string(runes[len(runes)-maxFileNameRunes:])
+	}
+
+	return name
+}
+
+// contentType returns the content type expected for a file extension
+func (r Rules) contentType(ext string) (string, bool) {
+	for _, t := range r.Types {
+		if t.Ext == ext {
+			return t.ContentType, true
+		}
+	}
+	return "", false
+}
+
+// invalidTypeMessage lists the accepted extensions, e.g. "Only pdf, jpg and png allowed"
+func (r Rules) invalidTypeMessage() string {
+	exts := make([]string, len(r.Types))
+	for i, t := range r.Types {
+		exts[i] = strings.TrimPrefix(t.Ext, ".")
+	}
+
+	list := strings.Join(exts, ", ")
+	if len(exts) > 1 {
+		list = strings.Join(exts[:len(exts)-1], ", ") + " and " + exts[len(exts)-1]
+	}
+
+	return "Invalid file type. Only " + list + " allowed"
+}
+
+// tooLargeMessage tells the client the largest accepted size in megabytes
+func (r Rules) tooLargeMessage() string {
+	return fmt.Sprintf("%s too large (max %dMB)", r.Label, r.MaxSize/(1024*1024))
+}
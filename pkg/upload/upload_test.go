@@ -0,0 +1,99 @@
+package upload
+
+import (
+	"bytes"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+// fileHeader builds the header of an uploaded file the way a multipart request would
+func fileHeader(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = form.RemoveAll() })
+
+	return form.File["file"][0]
+}
+
+func TestRead(t *testing.T) {
+	rules := Rules{Label: "Document", MaxSize: 1024 * 1024, Types: DocumentTypes}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantMsg  string
+	}{
+		{"accepted", "logo.PNG", pngHeader, ""},
+		{"unknown extension", "logo.gif", pngHeader, "Invalid file type. Only pdf, jpg, jpeg and png allowed"},
+		{"content does not match", "report.pdf", pngHeader, "Document content does not match its file type"},
+		{"too large", "logo.png", append(append([]byte{}, pngHeader...), make([]byte, 1024*1024)...), "Document too large (max 1MB)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, msg, err := Read(fileHeader(t, tt.filename, tt.content), rules)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if msg != tt.wantMsg {
+				t.Fatalf("message: got %q, want %q", msg, tt.wantMsg)
+			}
+			if msg != "" {
+				return
+			}
+			if file.FileName != tt.filename || file.FileExt != ".png" || file.ContentType != "image/png" {
+				t.Errorf("got %+v", file)
+			}
+			if !bytes.Equal(file.FileContent, tt.content) {
+				t.Errorf("content: got %d bytes, want %d", len(file.FileContent), len(tt.content))
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"short", "cv.pdf", "cv.pdf"},
+		{"strips directories", "../../etc/cv.pdf", "cv.pdf"},
+		{"keeps the extension", strings.Repeat("a", 300) + ".pdf", strings.Repeat("a", 251) + ".pdf"},
+		{"multi-byte runes", strings.Repeat("面", 300) + ".pdf", strings.Repeat("面", 251) + ".pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FileName(tt.in)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("%q is not valid UTF-8", got)
+			}
+		})
+	}
+}
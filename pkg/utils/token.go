@@ -2,6 +2,10 @@ package utils
 
 import (
 	"context"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -36,3 +40,18 @@ func GenerateUniqueToken(ctx context.Context, repo func(ctx context.Context, tok
 	}
 	return "", errors.New("failed to generate unique token")
 }
+
+// GenerateSecureToken generates a URL-safe random token from n bytes of crypto/rand entropy
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex encoded SHA-256 digest of a token, for storing tokens at rest
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}